@@ -0,0 +1,256 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AuditOptions configures the background self-audit started by MutableTree.StartAudit().
+type AuditOptions struct {
+	// Interval is the minimum time between the start of two audit rounds. Defaults to 1 second.
+	Interval time.Duration
+
+	// Samples is the number of random keys checked in each round, i.e. the sampling rate is
+	// Samples per Interval. Defaults to 10.
+	Samples int
+
+	// CPUBudget is the maximum fraction of the time spent auditing, between 0 and 1. Rounds are
+	// delayed beyond Interval when necessary to stay within budget. Defaults to 0.05.
+	CPUBudget float64
+
+	// OnInconsistency is called with each inconsistency found, e.g. to log it.
+	OnInconsistency func(AuditInconsistency)
+
+	// OnRound is called after each round, e.g. to update metrics.
+	OnRound func(AuditRound)
+}
+
+// AuditInconsistency describes an inconsistency found by the audit.
+type AuditInconsistency struct {
+	Version int64  // The audited version.
+	Index   int64  // The sampled index.
+	Key     []byte // The sampled key, if known.
+	Err     error  // The inconsistency found.
+}
+
+// String implements fmt.Stringer.
+func (i AuditInconsistency) String() string {
+	return fmt.Sprintf("version %v index %v key %X: %v", i.Version, i.Index, i.Key, i.Err)
+}
+
+// AuditRound contains the results of a single audit round.
+type AuditRound struct {
+	Version         int64 // The audited version, or 0 if no version was audited.
+	Samples         int   // The number of keys sampled.
+	Inconsistencies []AuditInconsistency
+	Duration        time.Duration
+}
+
+// Auditor periodically audits the latest saved version of a MutableTree in the background. It is
+// created by MutableTree.StartAudit(), and must be stopped with Stop().
+type Auditor struct {
+	tree *MutableTree
+	opts AuditOptions
+	rand *rand.Rand
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// StartAudit starts a background self-audit of the tree, to catch silent corruption of persisted
+// nodes. Each round samples random indices of the latest saved version with GetByIndex(), and
+// checks that the value matches a proof verified against the stored root hash. It also re-reads
+// the path to each sampled key directly from the database, bypassing the node cache, and verifies
+// the hash and contents of every node on it. Inconsistencies are reported via the option hooks.
+//
+// The audit only reads saved versions, so it can run concurrently with writes to the tree.
+// Sampled versions that are deleted during a round are skipped.
+func (tree *MutableTree) StartAudit(opts AuditOptions) (*Auditor, error) {
+	if opts.Interval == 0 {
+		opts.Interval = time.Second
+	}
+	if opts.Samples == 0 {
+		opts.Samples = 10
+	}
+	if opts.CPUBudget == 0 {
+		opts.CPUBudget = 0.05
+	}
+	if opts.Interval < 0 {
+		return nil, errors.Errorf("audit interval cannot be negative, got %v", opts.Interval)
+	}
+	if opts.Samples < 0 {
+		return nil, errors.Errorf("audit samples cannot be negative, got %v", opts.Samples)
+	}
+	if opts.CPUBudget < 0 || opts.CPUBudget > 1 {
+		return nil, errors.Errorf("audit CPU budget must be between 0 and 1, got %v", opts.CPUBudget)
+	}
+
+	a := &Auditor{
+		tree: tree,
+		opts: opts,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go a.run()
+	return a, nil
+}
+
+// Stop stops the audit, and waits for the current round to complete. It is safe to call multiple
+// times.
+func (a *Auditor) Stop() {
+	a.once.Do(func() { close(a.stop) })
+	<-a.done
+}
+
+// run runs audit rounds until stopped.
+func (a *Auditor) run() {
+	defer close(a.done)
+	for {
+		round := a.tree.auditRound(a.rand, a.opts.Samples)
+		for _, inconsistency := range round.Inconsistencies {
+			if a.opts.OnInconsistency != nil {
+				a.opts.OnInconsistency(inconsistency)
+			}
+		}
+		if a.opts.OnRound != nil {
+			a.opts.OnRound(round)
+		}
+
+		// Stay within the CPU budget, by waiting at least the round duration scaled by the budget.
+		wait := time.Duration(float64(round.Duration) * (1 - a.opts.CPUBudget) / a.opts.CPUBudget)
+		if wait < a.opts.Interval-round.Duration {
+			wait = a.opts.Interval - round.Duration
+		}
+		select {
+		case <-a.stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// auditRound audits the given number of random samples of the latest saved version.
+func (tree *MutableTree) auditRound(r *rand.Rand, samples int) AuditRound {
+	start := time.Now()
+	tree.mtx.RLock()
+	version := tree.version
+	tree.mtx.RUnlock()
+
+	round := AuditRound{Version: version}
+	if version == 0 {
+		round.Duration = time.Since(start)
+		return round
+	}
+	for i := 0; i < samples; i++ {
+		inconsistency, ok := tree.auditSample(r, version)
+		if !ok {
+			// The version was deleted while auditing it.
+			round.Version = 0
+			round.Inconsistencies = nil
+			break
+		}
+		round.Samples++
+		if inconsistency != nil {
+			round.Inconsistencies = append(round.Inconsistencies, *inconsistency)
+		}
+	}
+	round.Duration = time.Since(start)
+	return round
+}
+
+// auditSample audits a random sample of the given version, returning any inconsistency found. It
+// returns false if the version no longer exists.
+func (tree *MutableTree) auditSample(r *rand.Rand, version int64) (inconsistency *AuditInconsistency, ok bool) {
+	inconsistency = &AuditInconsistency{Version: version, Index: -1}
+	defer func() {
+		// Missing or undecodable nodes cause panics. These, like any other errors, are only
+		// inconsistencies if the version was not deleted (atomically with its nodes) meanwhile.
+		if p := recover(); p != nil {
+			inconsistency.Err, ok = errors.Errorf("panic: %v", p), true
+		}
+		if inconsistency != nil {
+			if exists, err := tree.ndb.HasRoot(version); err == nil && !exists {
+				inconsistency, ok = nil, false
+			}
+		}
+	}()
+
+	rootHash, err := tree.ndb.getRoot(version)
+	if err != nil {
+		inconsistency.Err = err
+		return inconsistency, true
+	}
+	if rootHash == nil {
+		return nil, false
+	}
+	if len(rootHash) == 0 {
+		return nil, true // empty trees have nothing to sample
+	}
+	itree := &ImmutableTree{root: tree.ndb.GetNode(rootHash), ndb: tree.ndb, version: version}
+
+	inconsistency.Index = r.Int63n(itree.Size())
+	key, value := itree.GetByIndex(inconsistency.Index)
+	if key == nil {
+		inconsistency.Err = errors.New("no key found at index")
+		return inconsistency, true
+	}
+	inconsistency.Key = key
+
+	proofValue, proof, err := itree.GetWithProof(key)
+	switch {
+	case err != nil:
+		inconsistency.Err = errors.Wrap(err, "failed to generate proof")
+	case !bytes.Equal(proofValue, value):
+		inconsistency.Err = errors.Errorf("proof value %X does not match value %X", proofValue, value)
+	default:
+		if err = proof.Verify(rootHash); err != nil {
+			inconsistency.Err = errors.Wrap(err, "proof does not match stored root hash")
+		} else if err = proof.VerifyItem(key, value); err != nil {
+			inconsistency.Err = errors.Wrap(err, "proof does not prove key")
+		} else if err = tree.ndb.auditPath(rootHash, key, value); err != nil {
+			inconsistency.Err = err
+		}
+	}
+	if inconsistency.Err != nil {
+		return inconsistency, true
+	}
+	return nil, true
+}
+
+// auditPath reads the path from the root to the given leaf directly from the database, bypassing
+// the node cache, and verifies the hash of each node and the contents of the leaf.
+func (ndb *nodeDB) auditPath(rootHash []byte, key, value []byte) error {
+	hash := rootHash
+	for {
+		node, _, _, err := readNode(ndb.get, hash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read node %X", hash)
+		}
+		if node == nil {
+			return errors.Errorf("node %X is missing from the database", hash)
+		}
+		node.hash = nil // recompute the hash from the stored node
+		if !bytes.Equal(node._hash(), hash) {
+			return errors.Errorf("stored node %X has hash %X", hash, node.hash)
+		}
+
+		if node.isLeaf() {
+			if !bytes.Equal(node.key, key) || !bytes.Equal(node.value, value) {
+				return errors.Errorf("stored leaf %X has key %X value %X, expected key %X value %X",
+					hash, node.key, node.value, key, value)
+			}
+			return nil
+		}
+		if bytes.Compare(key, node.key) < 0 {
+			hash = node.leftHash
+		} else {
+			hash = node.rightHash
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func setupAuditTree(t *testing.T, memDB db.DB, size int) *MutableTree {
+	tree, err := NewMutableTree(memDB, 100)
+	require.NoError(t, err)
+	for i := 0; i < size; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte(fmt.Sprintf("value-%d", i)))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	return tree
+}
+
+// corruptLeaf overwrites the stored leaf nodes for the given key with a different value, keeping
+// the cached nodes intact.
+func corruptLeaf(t *testing.T, memDB db.DB, key []byte) {
+	iter, err := memDB.Iterator(nodeKeyFormat.Key(), nodeKeyFormat.Key(bytes.Repeat([]byte{0xff}, hashSize)))
+	require.NoError(t, err)
+	corrupted := map[string][]byte{}
+	for ; iter.Valid(); iter.Next() {
+		node, err := MakeNode(iter.Value())
+		require.NoError(t, err)
+		if node.isLeaf() && bytes.Equal(node.key, key) {
+			node.value = []byte("corrupted")
+			var buf bytes.Buffer
+			require.NoError(t, node.writeBytes(&buf))
+			corrupted[string(iter.Key())] = buf.Bytes()
+		}
+	}
+	require.NoError(t, iter.Close())
+	require.NotEmpty(t, corrupted, "leaf %s not found", key)
+	for k, v := range corrupted {
+		require.NoError(t, memDB.Set([]byte(k), v))
+	}
+}
+
+func TestMutableTree_AuditRound(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupAuditTree(t, memDB, 10)
+	r := rand.New(rand.NewSource(1))
+
+	round := tree.auditRound(r, 100)
+	require.EqualValues(t, 1, round.Version)
+	require.Equal(t, 100, round.Samples)
+	require.Empty(t, round.Inconsistencies)
+
+	// Corruption on disk is detected even though the cached node is intact, and reads of the
+	// tree itself still succeed.
+	corruptLeaf(t, memDB, []byte("key-005"))
+	_, value := tree.Get([]byte("key-005"))
+	require.Equal(t, []byte("value-5"), value)
+
+	round = tree.auditRound(r, 100)
+	require.Equal(t, 100, round.Samples)
+	require.NotEmpty(t, round.Inconsistencies)
+	for _, inconsistency := range round.Inconsistencies {
+		require.EqualValues(t, 1, inconsistency.Version)
+		require.Equal(t, []byte("key-005"), inconsistency.Key)
+		require.Error(t, inconsistency.Err)
+	}
+}
+
+func TestMutableTree_AuditRound_Empty(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	r := rand.New(rand.NewSource(1))
+
+	// Without saved versions, nothing is audited.
+	round := tree.auditRound(r, 10)
+	require.EqualValues(t, 0, round.Version)
+	require.Equal(t, 0, round.Samples)
+
+	// Empty saved versions have nothing to sample, but are consistent.
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	round = tree.auditRound(r, 10)
+	require.EqualValues(t, 1, round.Version)
+	require.Empty(t, round.Inconsistencies)
+}
+
+func TestMutableTree_AuditRound_MissingNode(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTree(memDB, 0) // no cache, so the missing node is read from disk
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte{byte(i)})
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	require.NoError(t, memDB.Delete(tree.ndb.nodeKey(tree.root.leftHash)))
+
+	round := tree.auditRound(rand.New(rand.NewSource(1)), 10)
+	require.EqualValues(t, 1, round.Version)
+	require.NotEmpty(t, round.Inconsistencies)
+}
+
+func TestMutableTree_StartAudit(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupAuditTree(t, memDB, 10)
+
+	_, err := tree.StartAudit(AuditOptions{CPUBudget: 2})
+	require.Error(t, err)
+	_, err = tree.StartAudit(AuditOptions{Samples: -1})
+	require.Error(t, err)
+
+	var (
+		mtx             sync.Mutex
+		rounds          []AuditRound
+		inconsistencies []AuditInconsistency
+	)
+	auditor, err := tree.StartAudit(AuditOptions{
+		Interval:  time.Millisecond,
+		Samples:   20,
+		CPUBudget: 1,
+		OnInconsistency: func(inconsistency AuditInconsistency) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			inconsistencies = append(inconsistencies, inconsistency)
+		},
+		OnRound: func(round AuditRound) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			rounds = append(rounds, round)
+		},
+	})
+	require.NoError(t, err)
+	defer auditor.Stop()
+
+	// Writes and pruning can run concurrently with the audit.
+	for v := 0; v < 20; v++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", v)), []byte{byte(v)})
+		_, version, err := tree.SaveVersion()
+		require.NoError(t, err)
+		require.NoError(t, tree.DeleteVersion(version-1))
+		time.Sleep(time.Millisecond)
+	}
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(rounds) > 0 && rounds[len(rounds)-1].Version == tree.Version()
+	}, 5*time.Second, time.Millisecond)
+
+	mtx.Lock()
+	found := inconsistencies
+	mtx.Unlock()
+	require.Empty(t, found, "%v", found)
+
+	corruptLeaf(t, memDB, []byte("key-015"))
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(inconsistencies) > 0
+	}, 5*time.Second, time.Millisecond)
+
+	auditor.Stop()
+	auditor.Stop()
+}
+
+func TestMutableTree_StartAudit_Rollback(t *testing.T) {
+	tree := setupAuditTree(t, db.NewMemDB(), 10)
+	auditor, err := tree.StartAudit(AuditOptions{Interval: time.Millisecond, Samples: 5, CPUBudget: 1})
+	require.NoError(t, err)
+	defer auditor.Stop()
+
+	// Replacing the working tree does not race with the audit (run with -race).
+	for v := 0; v < 20; v++ {
+		tree.Set([]byte("key-000"), []byte{byte(v)})
+		tree.Rollback()
+		_, version, err := tree.SaveVersion()
+		require.NoError(t, err)
+		_, err = tree.LoadVersion(version - 1)
+		require.NoError(t, err)
+		_, _, err = tree.SaveVersion() // saving the loaded version again is a no-op.
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+}
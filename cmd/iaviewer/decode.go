@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ValueDecoder decodes protobuf values as JSON, using the message type mapped to the longest
+// matching key prefix. All message types are resolved from a local FileDescriptorSet.
+type ValueDecoder struct {
+	types    *protoregistry.Types
+	prefixes []valuePrefix // sorted by descending prefix length
+}
+
+// valuePrefix maps a key prefix to the message type of its values.
+type valuePrefix struct {
+	prefix  []byte
+	message protoreflect.MessageType
+}
+
+// LoadValueDecoder loads a value decoder from a binary FileDescriptorSet file, e.g. as generated by
+// `buf build -o` or `protoc --include_imports --descriptor_set_out`, and a type mapping file.
+//
+// Each line of the type mapping file contains a hex-encoded key prefix and a fully qualified
+// message name separated by whitespace, e.g. "02 cosmos.base.v1beta1.Coin". The prefix "*"
+// matches all keys. Empty lines and lines starting with # are ignored.
+func LoadValueDecoder(descriptorFile, typesFile string) (*ValueDecoder, error) {
+	bz, err := ioutil.ReadFile(descriptorFile)
+	if err != nil {
+		return nil, err
+	}
+	fdset := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(bz, fdset); err != nil {
+		return nil, fmt.Errorf("invalid file descriptor set %s: %w", descriptorFile, err)
+	}
+	files, err := protodesc.NewFiles(fdset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file descriptor set %s: %w", descriptorFile, err)
+	}
+
+	// Register all message types, such that google.protobuf.Any values can be resolved.
+	d := &ValueDecoder{types: &protoregistry.Types{}}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		err = registerMessages(d.types, fd.Messages())
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(typesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected <prefix> <message type>", typesFile, line)
+		}
+		var prefix []byte
+		if fields[0] != "*" {
+			prefix, err = hex.DecodeString(strings.TrimPrefix(fields[0], "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid hex prefix %q", typesFile, line, fields[0])
+			}
+		}
+		message, err := d.types.FindMessageByName(protoreflect.FullName(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: unknown message type %q", typesFile, line, fields[1])
+		}
+		d.prefixes = append(d.prefixes, valuePrefix{prefix: prefix, message: message})
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(d.prefixes, func(i, j int) bool {
+		return len(d.prefixes[i].prefix) > len(d.prefixes[j].prefix)
+	})
+	return d, nil
+}
+
+// registerMessages registers dynamic types for the given messages and their nested messages.
+func registerMessages(types *protoregistry.Types, messages protoreflect.MessageDescriptors) error {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		if err := types.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+			return err
+		}
+		if err := registerMessages(types, md.Messages()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decode decodes the value of the given key as JSON. It returns false if no message type is mapped
+// to the key, or if the value is not a valid message of the mapped type.
+func (d *ValueDecoder) Decode(key, value []byte) (string, bool) {
+	for _, p := range d.prefixes {
+		if !bytes.HasPrefix(key, p.prefix) {
+			continue
+		}
+		msg := p.message.New()
+		err := proto.UnmarshalOptions{Resolver: d.types}.Unmarshal(value, msg.Interface())
+		// Arbitrary bytes often parse as unknown fields, so treat these as a mismatch.
+		if err != nil || len(msg.GetUnknown()) > 0 {
+			return "", false
+		}
+		bz, err := protojson.MarshalOptions{Resolver: d.types}.Marshal(msg.Interface())
+		if err != nil {
+			return "", false
+		}
+		// protojson output is deliberately unstable, so compact it to make output diffable.
+		var buf bytes.Buffer
+		if err = json.Compact(&buf, bz); err != nil {
+			return "", false
+		}
+		return buf.String(), true
+	}
+	return "", false
+}
+
+// formatValue formats a value for display, as JSON if it can be decoded, and as hex otherwise.
+func formatValue(decoder *ValueDecoder, key, value []byte) string {
+	if decoder != nil {
+		if decoded, ok := decoder.Decode(key, value); ok {
+			return decoded
+		}
+	}
+	return strings.ToUpper(hex.EncodeToString(value))
+}
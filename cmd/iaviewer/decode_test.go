@@ -0,0 +1,89 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func setupValueDecoder(t *testing.T, types string) (*ValueDecoder, error) {
+	dir, err := ioutil.TempDir("", "iaviewer")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	fdset := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+		protodesc.ToFileDescriptorProto(durationpb.File_google_protobuf_duration_proto),
+		protodesc.ToFileDescriptorProto(anypb.File_google_protobuf_any_proto),
+	}}
+	bz, err := proto.Marshal(fdset)
+	require.NoError(t, err)
+	descriptorFile := filepath.Join(dir, "descriptors.pb")
+	require.NoError(t, ioutil.WriteFile(descriptorFile, bz, 0600))
+	typesFile := filepath.Join(dir, "types.txt")
+	require.NoError(t, ioutil.WriteFile(typesFile, []byte(types), 0600))
+
+	return LoadValueDecoder(descriptorFile, typesFile)
+}
+
+func TestValueDecoder(t *testing.T) {
+	decoder, err := setupValueDecoder(t, `
+# comment
+01   google.protobuf.Timestamp
+0x0102 google.protobuf.Duration
+02 google.protobuf.Any
+`)
+	require.NoError(t, err)
+
+	timestamp, err := proto.Marshal(&timestamppb.Timestamp{Seconds: 1600000000})
+	require.NoError(t, err)
+	duration, err := proto.Marshal(&durationpb.Duration{Seconds: 3})
+	require.NoError(t, err)
+	anyValue, err := anypb.New(&durationpb.Duration{Seconds: 5})
+	require.NoError(t, err)
+	anyBytes, err := proto.Marshal(anyValue)
+	require.NoError(t, err)
+
+	testcases := map[string]struct {
+		key    []byte
+		value  []byte
+		expect string
+	}{
+		"timestamp":       {[]byte{1, 9}, timestamp, `"2020-09-13T12:26:40Z"`},
+		"longest prefix":  {[]byte{1, 2, 9}, duration, `"3s"`},
+		"any":             {[]byte{2}, anyBytes, `{"@type":"type.googleapis.com/google.protobuf.Duration","value":"5s"}`},
+		"invalid value":   {[]byte{1}, []byte{0xff, 0x01}, "FF01"},
+		"empty value":     {[]byte{1}, []byte{}, `"1970-01-01T00:00:00Z"`},
+		"unmapped prefix": {[]byte{3}, timestamp, "0880A0F8FA05"},
+		"unknown fields":  {[]byte{1, 2}, []byte{0x18, 0x01}, "1801"},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expect, formatValue(decoder, tc.key, tc.value))
+		})
+	}
+	require.Equal(t, "0102", formatValue(nil, []byte{1}, []byte{1, 2}))
+}
+
+func TestValueDecoder_Invalid(t *testing.T) {
+	_, err := setupValueDecoder(t, "01 unknown.Type\n")
+	require.Error(t, err)
+	_, err = setupValueDecoder(t, "xyz google.protobuf.Timestamp\n")
+	require.Error(t, err)
+	_, err = setupValueDecoder(t, "01\n")
+	require.Error(t, err)
+
+	decoder, err := setupValueDecoder(t, "* google.protobuf.Duration\n")
+	require.NoError(t, err)
+	require.Equal(t, `"0s"`, formatValue(decoder, []byte("any key"), []byte{}))
+}
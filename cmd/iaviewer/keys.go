@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KeyDecoder formats keys for display, and parses key arguments. Formatted keys should parse back
+// into the original keys.
+type KeyDecoder interface {
+	FormatKey(key []byte) string
+	ParseKey(arg string) ([]byte, error)
+}
+
+// NewKeyDecoder returns the named key decoder:
+//
+//	weave:   weave-style keys, i.e. an ASCII prefix and an ID separated by :. Binary keys are shown
+//	         as hex, and key arguments are strings or hex with a 0x prefix.
+//	cosmos:  Cosmos SDK keys, i.e. a prefix byte followed by length-prefixed addresses shown as
+//	         bech32 with the given prefix, and an optional big-endian integer (#n), string or hex
+//	         (0x...) suffix, separated by /. E.g. 0x02/cosmos1.../uatom.
+//	hex:     hex keys.
+//	a file:  Cosmos SDK keys, decoded using rules read from the file. See NewRulesKeyDecoder().
+func NewKeyDecoder(name string, bech32Prefix string) (KeyDecoder, error) {
+	switch name {
+	case "weave":
+		return weaveKeyDecoder{}, nil
+	case "cosmos":
+		return cosmosKeyDecoder{bech32Prefix: bech32Prefix}, nil
+	case "hex":
+		return hexKeyDecoder{}, nil
+	default:
+		if _, err := os.Stat(name); err != nil {
+			return nil, fmt.Errorf("unknown key decoder %q, expected weave, cosmos, hex or a rules file", name)
+		}
+		return NewRulesKeyDecoder(name, bech32Prefix)
+	}
+}
+
+// weaveKeyDecoder decodes weave-style keys.
+type weaveKeyDecoder struct{}
+
+func (weaveKeyDecoder) FormatKey(key []byte) string {
+	return parseWeaveKey(key)
+}
+
+func (weaveKeyDecoder) ParseKey(arg string) ([]byte, error) {
+	if strings.HasPrefix(arg, "0x") {
+		return hex.DecodeString(arg[2:])
+	}
+	return []byte(arg), nil
+}
+
+// hexKeyDecoder decodes hex keys.
+type hexKeyDecoder struct{}
+
+func (hexKeyDecoder) FormatKey(key []byte) string {
+	return strings.ToUpper(hex.EncodeToString(key))
+}
+
+func (hexKeyDecoder) ParseKey(arg string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(arg, "0x"))
+}
+
+// cosmosKeyDecoder decodes Cosmos SDK keys by convention.
+type cosmosKeyDecoder struct {
+	bech32Prefix string
+}
+
+func (d cosmosKeyDecoder) FormatKey(key []byte) string {
+	if len(key) == 0 {
+		return ""
+	}
+	parts := []string{formatHexComponent(key[:1])}
+	rest := key[1:]
+	for len(rest) > 0 && (rest[0] == 20 || rest[0] == 32) && len(rest) > int(rest[0]) {
+		addr, err := bech32Encode(d.bech32Prefix, rest[1:1+rest[0]])
+		if err != nil {
+			break
+		}
+		parts = append(parts, addr)
+		rest = rest[1+rest[0]:]
+	}
+	if len(rest) > 0 {
+		parts = append(parts, formatComponent(rest))
+	}
+	return strings.Join(parts, "/")
+}
+
+func (d cosmosKeyDecoder) ParseKey(arg string) ([]byte, error) {
+	var key []byte
+	for _, part := range strings.Split(arg, "/") {
+		bz, err := parseComponent(part)
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, bz...)
+	}
+	return key, nil
+}
+
+// formatComponent formats a key component as a big-endian integer (#n) if it has 8 bytes, as a
+// string if it is printable and unambiguous, or as hex (0x...) otherwise.
+func formatComponent(bz []byte) string {
+	if len(bz) == 8 {
+		return "#" + strconv.FormatUint(binary.BigEndian.Uint64(bz), 10)
+	}
+	s := string(bz)
+	if len(s) == 0 || strings.ContainsAny(s, "/#") || strings.HasPrefix(s, "0x") {
+		return formatHexComponent(bz)
+	}
+	if _, _, err := bech32Decode(s); err == nil {
+		return formatHexComponent(bz)
+	}
+	if !isPrintable(bz) {
+		return formatHexComponent(bz)
+	}
+	return s
+}
+
+// isPrintable checks whether bytes are printable ASCII.
+func isPrintable(bz []byte) bool {
+	for _, b := range bz {
+		if b < 0x20 || b >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func formatHexComponent(bz []byte) string {
+	return "0x" + strings.ToUpper(hex.EncodeToString(bz))
+}
+
+// parseComponent parses a key component formatted by formatComponent() or a bech32 address, which
+// is length-prefixed.
+func parseComponent(s string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(s, "0x"):
+		return hex.DecodeString(s[2:])
+	case strings.HasPrefix(s, "#"):
+		return parseUint(s[1:], 8)
+	}
+	if _, addr, err := bech32Decode(s); err == nil {
+		return lengthPrefix(addr)
+	}
+	return []byte(s), nil
+}
+
+// parseUint parses a decimal integer into size big-endian bytes.
+func parseUint(s string, size int) ([]byte, error) {
+	n, err := strconv.ParseUint(s, 10, size*8)
+	if err != nil {
+		return nil, err
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, n)
+	return bz[8-size:], nil
+}
+
+// lengthPrefix prefixes bytes with their length.
+func lengthPrefix(bz []byte) ([]byte, error) {
+	if len(bz) > 255 {
+		return nil, fmt.Errorf("length prefixed component too long: %v bytes", len(bz))
+	}
+	return append([]byte{byte(len(bz))}, bz...), nil
+}
+
+// RulesKeyDecoder decodes Cosmos SDK keys using a set of rules mapping prefixes to key layouts.
+// Keys not matched by any rule are decoded by convention like the cosmos decoder.
+type RulesKeyDecoder struct {
+	rules    []keyRule // sorted by descending prefix length
+	fallback cosmosKeyDecoder
+}
+
+// keyRule describes the layout of keys with the given prefix.
+type keyRule struct {
+	prefix   []byte
+	segments []keySegment
+}
+
+// keySegment is a segment of a key layout. hrp is the bech32 prefix of addresses.
+type keySegment struct {
+	kind string
+	hrp  string
+}
+
+// NewRulesKeyDecoder loads a key decoder from a rules file. Each line of the file contains a
+// hex-encoded key prefix followed by the segments of the rest of the key, separated by whitespace:
+//
+//	addr        a length-prefixed address, shown as bech32 with the default prefix
+//	addr:<hrp>  a length-prefixed address, shown as bech32 with the given prefix
+//	u64, u32    a big-endian unsigned integer
+//	str         the remaining bytes as a string
+//	hex         the remaining bytes as hex
+//
+// For example, "31 addr addr:cosmosvaloper" decodes staking delegations. Keys are formatted as
+// the hex prefix followed by the decoded segments separated by /, e.g. 0x31/cosmos1.../cosmosvaloper1...,
+// and any undecodable remainder as hex. Empty lines and lines starting with # are ignored.
+func NewRulesKeyDecoder(file string, bech32Prefix string) (*RulesKeyDecoder, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d := &RulesKeyDecoder{fallback: cosmosKeyDecoder{bech32Prefix: bech32Prefix}}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		prefix, err := hex.DecodeString(strings.TrimPrefix(fields[0], "0x"))
+		if err != nil || len(prefix) == 0 {
+			return nil, fmt.Errorf("%s:%d: invalid hex prefix %q", file, line, fields[0])
+		}
+		rule := keyRule{prefix: prefix}
+		for i, field := range fields[1:] {
+			segment := keySegment{kind: field}
+			switch {
+			case field == "addr":
+				segment.hrp = bech32Prefix
+			case strings.HasPrefix(field, "addr:"):
+				segment.kind, segment.hrp = "addr", field[5:]
+			case field == "u64", field == "u32":
+			case field == "str", field == "hex":
+				if i != len(fields)-2 {
+					return nil, fmt.Errorf("%s:%d: segment %q must be last", file, line, field)
+				}
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown segment %q", file, line, field)
+			}
+			rule.segments = append(rule.segments, segment)
+		}
+		d.rules = append(d.rules, rule)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(d.rules, func(i, j int) bool {
+		return len(d.rules[i].prefix) > len(d.rules[j].prefix)
+	})
+	return d, nil
+}
+
+func (d *RulesKeyDecoder) FormatKey(key []byte) string {
+	for _, rule := range d.rules {
+		if !bytes.HasPrefix(key, rule.prefix) {
+			continue
+		}
+		parts := []string{formatHexComponent(rule.prefix)}
+		rest := key[len(rule.prefix):]
+		for _, segment := range rule.segments {
+			if len(rest) == 0 {
+				break
+			}
+			s, n, ok := segment.format(rest)
+			if !ok {
+				break
+			}
+			parts = append(parts, s)
+			rest = rest[n:]
+		}
+		if len(rest) > 0 {
+			parts = append(parts, formatHexComponent(rest))
+		}
+		return strings.Join(parts, "/")
+	}
+	return d.fallback.FormatKey(key)
+}
+
+func (d *RulesKeyDecoder) ParseKey(arg string) ([]byte, error) {
+	parts := strings.Split(arg, "/")
+	if !strings.HasPrefix(parts[0], "0x") {
+		return d.fallback.ParseKey(arg)
+	}
+	prefix, err := hex.DecodeString(parts[0][2:])
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range d.rules {
+		if !bytes.Equal(prefix, rule.prefix) {
+			continue
+		}
+		key := prefix
+		for i, part := range parts[1:] {
+			var bz []byte
+			if i < len(rule.segments) && !strings.HasPrefix(part, "0x") {
+				bz, err = rule.segments[i].parse(part)
+			} else {
+				bz, err = parseComponent(part)
+			}
+			if err != nil {
+				return nil, err
+			}
+			key = append(key, bz...)
+		}
+		return key, nil
+	}
+	return d.fallback.ParseKey(arg)
+}
+
+// format formats the segment at the start of bz, returning the number of bytes consumed.
+func (s keySegment) format(bz []byte) (string, int, bool) {
+	switch s.kind {
+	case "addr":
+		n := int(bz[0])
+		if len(bz) <= n {
+			return "", 0, false
+		}
+		addr, err := bech32Encode(s.hrp, bz[1:1+n])
+		if err != nil {
+			return "", 0, false
+		}
+		return addr, 1 + n, true
+	case "u64":
+		if len(bz) < 8 {
+			return "", 0, false
+		}
+		return strconv.FormatUint(binary.BigEndian.Uint64(bz), 10), 8, true
+	case "u32":
+		if len(bz) < 4 {
+			return "", 0, false
+		}
+		return strconv.FormatUint(uint64(binary.BigEndian.Uint32(bz)), 10), 4, true
+	case "str":
+		if strings.Contains(string(bz), "/") || strings.HasPrefix(string(bz), "0x") || !isPrintable(bz) {
+			return "", 0, false
+		}
+		return string(bz), len(bz), true
+	default:
+		return formatHexComponent(bz), len(bz), true
+	}
+}
+
+// parse parses a formatted segment.
+func (s keySegment) parse(arg string) ([]byte, error) {
+	switch s.kind {
+	case "addr":
+		_, addr, err := bech32Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		return lengthPrefix(addr)
+	case "u64":
+		return parseUint(arg, 8)
+	case "u32":
+		return parseUint(arg, 4)
+	case "str":
+		return []byte(arg), nil
+	default:
+		return hex.DecodeString(strings.TrimPrefix(arg, "0x"))
+	}
+}
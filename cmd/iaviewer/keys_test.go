@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBech32(t *testing.T) {
+	hrp, data, err := bech32Decode("abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw")
+	require.NoError(t, err)
+	require.Equal(t, "abcdef", hrp)
+	require.Equal(t, "00443214c74254b635cf84653a56d7c675be77df", hex.EncodeToString(data))
+
+	s, err := bech32Encode(hrp, data)
+	require.NoError(t, err)
+	require.Equal(t, "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", s)
+
+	_, _, err = bech32Decode("abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx")
+	require.Error(t, err)
+	_, _, err = bech32Decode("uatom")
+	require.Error(t, err)
+}
+
+func testKeyDecoder(t *testing.T, decoder KeyDecoder, key []byte, expect string) {
+	require.Equal(t, expect, decoder.FormatKey(key))
+	parsed, err := decoder.ParseKey(expect)
+	require.NoError(t, err)
+	require.Equal(t, key, parsed)
+}
+
+func TestKeyDecoders(t *testing.T) {
+	addr := make([]byte, 20)
+	for i := range addr {
+		addr[i] = byte(i)
+	}
+	bech32Addr, err := bech32Encode("cosmos", addr)
+	require.NoError(t, err)
+	valAddr, err := bech32Encode("cosmosvaloper", addr)
+	require.NoError(t, err)
+	lpAddr := append([]byte{20}, addr...)
+
+	weave, err := NewKeyDecoder("weave", "cosmos")
+	require.NoError(t, err)
+	testKeyDecoder(t, weave, []byte("sigs:abc"), "sigs:abc")
+	parsed, err := weave.ParseKey("0x0102")
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, parsed)
+
+	hexKeys, err := NewKeyDecoder("hex", "cosmos")
+	require.NoError(t, err)
+	testKeyDecoder(t, hexKeys, []byte{0xab, 1}, "AB01")
+
+	cosmos, err := NewKeyDecoder("cosmos", "cosmos")
+	require.NoError(t, err)
+	testKeyDecoder(t, cosmos, append(append([]byte{2}, lpAddr...), "uatom"...), "0x02/"+bech32Addr+"/uatom")
+	testKeyDecoder(t, cosmos, append(append([]byte{0x31}, lpAddr...), lpAddr...), "0x31/"+bech32Addr+"/"+bech32Addr)
+	testKeyDecoder(t, cosmos, []byte{0, 0, 0, 0, 0, 0, 0, 1, 2}, "0x00/#258")
+	testKeyDecoder(t, cosmos, []byte{1, 0xff, 0x02}, "0x01/0xFF02")
+	testKeyDecoder(t, cosmos, []byte("\x01a/b"), "0x01/0x612F62")
+	testKeyDecoder(t, cosmos, []byte{1}, "0x01")
+	_, err = cosmos.ParseKey("0x01/#x")
+	require.Error(t, err)
+
+	dir, err := ioutil.TempDir("", "iaviewer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	rulesFile := filepath.Join(dir, "keys.rules")
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte(`
+# staking delegations
+31 addr addr:cosmosvaloper
+0x21 u32 str
+22 u64 hex
+`), 0600))
+	rules, err := NewKeyDecoder(rulesFile, "cosmos")
+	require.NoError(t, err)
+	testKeyDecoder(t, rules, append(append([]byte{0x31}, lpAddr...), lpAddr...), "0x31/"+bech32Addr+"/"+valAddr)
+	testKeyDecoder(t, rules, []byte("\x21\x00\x00\x01\x00denom"), "0x21/256/denom")
+	testKeyDecoder(t, rules, []byte("\x21\x00\x00\x01\x00\x00"), "0x21/256/0x00")
+	testKeyDecoder(t, rules, []byte{0x22, 0, 0, 0, 0, 0, 0, 0, 7, 0xab}, "0x22/7/0xAB")
+	testKeyDecoder(t, rules, []byte{0x22, 0, 1}, "0x22/0x0001")
+	// Unmatched keys are decoded by convention.
+	testKeyDecoder(t, rules, append([]byte{0x02}, lpAddr...), "0x02/"+bech32Addr)
+
+	_, err = NewKeyDecoder("unknown", "cosmos")
+	require.Error(t, err)
+	for _, invalid := range []string{"xx addr", "01 str u64", "01 foo"} {
+		require.NoError(t, ioutil.WriteFile(rulesFile, []byte(invalid), 0600))
+		_, err = NewKeyDecoder(rulesFile, "cosmos")
+		require.Error(t, err, invalid)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
@@ -19,24 +20,61 @@ const (
 )
 
 func main() {
-	args := os.Args[1:]
-	if len(args) < 3 || (args[0] != "data" && args[0] != "shape" && args[0] != "versions") {
-		fmt.Fprintln(os.Stderr, "Usage: iaviewer <data|shape|versions> <leveldb dir> <prefix> [version number]")
-		fmt.Fprintln(os.Stderr, "<prefix> is the prefix of db, and the iavl tree of different modules in cosmos-sdk uses ")
-		fmt.Fprintln(os.Stderr, "different <prefix> to identify, just like \"s/k:gov/\" represents the prefix of gov module")
+	descriptors := flag.String("descriptors", "", "binary FileDescriptorSet file used to decode protobuf values as JSON")
+	types := flag.String("types", "", "file mapping hex key prefixes to protobuf message types, used with -descriptors")
+	keyDecoderName := flag.String("keys", "weave", "key decoder: weave, cosmos, hex or a rules file")
+	bech32Prefix := flag.String("bech32-prefix", "cosmos", "bech32 prefix of addresses shown by the cosmos and rules key decoders")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 3 {
+		usage()
+		os.Exit(1)
+	}
+	var nargs int // the number of arguments excluding the optional version
+	switch args[0] {
+	case "data", "shape", "versions":
+		nargs = 3
+	case "get":
+		nargs = 4
+	case "range":
+		nargs = 5
+	}
+	if nargs == 0 || len(args) < nargs || len(args) > nargs+1 {
+		usage()
 		os.Exit(1)
 	}
 
 	version := 0
-	if len(args) == 4 {
+	if len(args) == nargs+1 {
 		var err error
-		version, err = strconv.Atoi(args[3])
+		version, err = strconv.Atoi(args[nargs])
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid version number: %s\n", err)
 			os.Exit(1)
 		}
 	}
 
+	keys, err := NewKeyDecoder(*keyDecoderName, *bech32Prefix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading key decoder: %s\n", err)
+		os.Exit(1)
+	}
+
+	var decoder *ValueDecoder
+	if *descriptors != "" || *types != "" {
+		if *descriptors == "" || *types == "" {
+			fmt.Fprintln(os.Stderr, "Both -descriptors and -types must be given to decode values")
+			os.Exit(1)
+		}
+		decoder, err = LoadValueDecoder(*descriptors, *types)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading value types: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
 	tree, err := ReadTree(args[1], version, []byte(args[2]))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading data: %s\n", err)
@@ -45,16 +83,32 @@ func main() {
 
 	switch args[0] {
 	case "data":
-		PrintKeys(tree)
+		PrintKeys(tree, keys, decoder)
 		fmt.Printf("Hash: %X\n", tree.Hash())
 		fmt.Printf("Size: %X\n", tree.Size())
 	case "shape":
-		PrintShape(tree)
+		PrintShape(tree, keys)
 	case "versions":
 		PrintVersions(tree)
+	case "get":
+		PrintValue(tree, keys, parseKeyArg(keys, args[3]), decoder)
+	case "range":
+		PrintRange(tree, keys, parseKeyArg(keys, args[3]), parseKeyArg(keys, args[4]), decoder)
 	}
 }
 
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: iaviewer [flags] <data|shape|versions> <leveldb dir> <prefix> [version number]")
+	fmt.Fprintln(os.Stderr, "       iaviewer [flags] get <leveldb dir> <prefix> <key> [version number]")
+	fmt.Fprintln(os.Stderr, "       iaviewer [flags] range <leveldb dir> <prefix> <start key> <end key> [version number]")
+	fmt.Fprintln(os.Stderr, "<prefix> is the prefix of db, and the iavl tree of different modules in cosmos-sdk uses ")
+	fmt.Fprintln(os.Stderr, "different <prefix> to identify, just like \"s/k:gov/\" represents the prefix of gov module")
+	fmt.Fprintln(os.Stderr, "Keys are shown and given in the format of the key decoder. Range end keys are exclusive,")
+	fmt.Fprintln(os.Stderr, "and empty range keys are unbounded.")
+	fmt.Fprintln(os.Stderr, "Flags:")
+	flag.PrintDefaults()
+}
+
 func OpenDB(dir string) (dbm.DB, error) {
 	switch {
 	case strings.HasSuffix(dir, ".db"):
@@ -122,16 +176,57 @@ func ReadTree(dir string, version int, prefix []byte) (*iavl.MutableTree, error)
 	return tree, err
 }
 
-func PrintKeys(tree *iavl.MutableTree) {
-	fmt.Println("Printing all keys with hashed values (to detect diff)")
+// PrintKeys prints all keys with the hashes of their values, or with their decoded values if a
+// decoder is given.
+func PrintKeys(tree *iavl.MutableTree, keys KeyDecoder, decoder *ValueDecoder) {
+	if decoder != nil {
+		fmt.Println("Printing all keys with decoded values")
+	} else {
+		fmt.Println("Printing all keys with hashed values (to detect diff)")
+	}
 	tree.Iterate(func(key []byte, value []byte) bool {
-		printKey := parseWeaveKey(key)
-		digest := sha256.Sum256(value)
-		fmt.Printf("  %s\n    %X\n", printKey, digest)
+		printKey := keys.FormatKey(key)
+		if decoder != nil {
+			fmt.Printf("  %s\n    %s\n", printKey, formatValue(decoder, key, value))
+		} else {
+			digest := sha256.Sum256(value)
+			fmt.Printf("  %s\n    %X\n", printKey, digest)
+		}
+		return false
+	})
+}
+
+// PrintValue prints the value of the given key.
+func PrintValue(tree *iavl.MutableTree, keys KeyDecoder, key []byte, decoder *ValueDecoder) {
+	_, value := tree.Get(key)
+	if value == nil {
+		fmt.Printf("Key %s not found\n", keys.FormatKey(key))
+		return
+	}
+	fmt.Printf("  %s\n    %s\n", keys.FormatKey(key), formatValue(decoder, key, value))
+}
+
+// PrintRange prints all keys in the range [start, end) with their values.
+func PrintRange(tree *iavl.MutableTree, keys KeyDecoder, start, end []byte, decoder *ValueDecoder) {
+	tree.IterateRange(start, end, true, func(key []byte, value []byte) bool {
+		fmt.Printf("  %s\n    %s\n", keys.FormatKey(key), formatValue(decoder, key, value))
 		return false
 	})
 }
 
+// parseKeyArg parses a key argument with the key decoder. Empty keys are nil.
+func parseKeyArg(keys KeyDecoder, arg string) []byte {
+	if arg == "" {
+		return nil
+	}
+	key, err := keys.ParseKey(arg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid key %s: %s\n", arg, err)
+		os.Exit(1)
+	}
+	return key
+}
+
 // parseWeaveKey assumes a separating : where all in front should be ascii,
 // and all afterwards may be ascii or binary
 func parseWeaveKey(key []byte) string {
@@ -154,21 +249,23 @@ func encodeID(id []byte) string {
 	return string(id)
 }
 
-func PrintShape(tree *iavl.MutableTree) {
+func PrintShape(tree *iavl.MutableTree, keys KeyDecoder) {
 	// shape := tree.RenderShape("  ", nil)
-	shape := tree.RenderShape("  ", nodeEncoder)
+	shape := tree.RenderShape("  ", nodeEncoder(keys))
 	fmt.Println(strings.Join(shape, "\n"))
 }
 
-func nodeEncoder(id []byte, depth int, isLeaf bool) string {
-	prefix := fmt.Sprintf("-%d ", depth)
-	if isLeaf {
-		prefix = fmt.Sprintf("*%d ", depth)
-	}
-	if len(id) == 0 {
-		return fmt.Sprintf("%s<nil>", prefix)
+func nodeEncoder(keys KeyDecoder) func(id []byte, depth int, isLeaf bool) string {
+	return func(id []byte, depth int, isLeaf bool) string {
+		prefix := fmt.Sprintf("-%d ", depth)
+		if isLeaf {
+			prefix = fmt.Sprintf("*%d ", depth)
+		}
+		if len(id) == 0 {
+			return fmt.Sprintf("%s<nil>", prefix)
+		}
+		return fmt.Sprintf("%s%s", prefix, keys.FormatKey(id))
 	}
-	return fmt.Sprintf("%s%s", prefix, parseWeaveKey(id))
 }
 
 func PrintVersions(tree *iavl.MutableTree) {
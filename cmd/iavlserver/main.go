@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"flag"
 	"io/ioutil"
 	"net"
@@ -31,6 +32,7 @@ var (
 	dbBackend       = flag.String("db-backend", string(dbm.GoLevelDBBackend), "The database backend")
 	version         = flag.Int64("version", 0, "The IAVL version to load")
 	cacheSize       = flag.Int64("cache-size", 10000, "Tree cache size")
+	proofCacheSize  = flag.Int64("proof-cache-size", server.DefaultProofCacheSize, "Number of proofs at saved versions to cache, 0 to disable")
 	gRPCEndpoint    = flag.String("grpc-endpoint", "localhost:8090", "The gRPC server endpoint (host:port)")
 	gatewayEndpoint = flag.String("gateway-endpoint", "localhost:8091", "The gRPC-Gateway server endpoint (host:port)")
 	noGateway       = flag.Bool("no-gateway", false, "Disables the gRPC-Gateway server")
@@ -75,10 +77,11 @@ func main() {
 		log.Fatalf("failed to open DB: %s", err)
 	}
 
-	svr, err := server.New(db, *cacheSize, *version)
+	svr, err := server.NewWithProofCache(db, *cacheSize, *version, *proofCacheSize)
 	if err != nil {
 		log.Fatalf("failed to create IAVL server: %s", err)
 	}
+	expvar.Publish("proof_cache", expvar.Func(func() interface{} { return svr.ProofCacheStats() }))
 
 	pb.RegisterIAVLServiceServer(grpcServer, svr)
 
@@ -121,6 +124,8 @@ func startRPCGateway() error {
 
 	r := http.NewServeMux()
 	r.Handle("/", gatewayMux)
+	// Metrics, e.g. proof cache hit rates
+	r.Handle("/debug/vars", expvar.Handler())
 
 	// Register pprof handlers
 	if *withProfiling {
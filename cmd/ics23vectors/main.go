@@ -0,0 +1,56 @@
+// ics23vectors generates JSON test vectors of ICS23 proofs for IAVL trees, which can be used to
+// test proof verifiers in other languages against the Go implementation.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	out := flag.String("out", "testdata", "directory to write the test vectors to")
+	seed := flag.Int64("seed", 1, "seed used to generate the trees")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() > 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating output directory: %s\n", err)
+		os.Exit(1)
+	}
+	for _, spec := range DefaultTrees(*seed) {
+		path := filepath.Join(*out, spec.Name()+".json")
+		if err := WriteFile(path, spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating %s: %s\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Println(path)
+	}
+}
+
+// WriteFile generates the test vectors for a tree spec, and writes them to the given path.
+func WriteFile(path string, spec TreeSpec) error {
+	file, err := Generate(spec)
+	if err != nil {
+		return err
+	}
+	bz, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(bz, '\n'), 0644)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: ics23vectors [-out DIR] [-seed SEED]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Generates a JSON file of ICS23 test vectors for each of a set of deterministic trees.")
+	flag.PrintDefaults()
+}
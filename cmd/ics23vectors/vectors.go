@@ -0,0 +1,525 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+
+	ics23 "github.com/confio/ics23/go"
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+	_ "github.com/tendermint/tm-db/metadb" // dbm.NewMemDB() opens the mem backend registered here
+
+	"github.com/cosmos/iavl"
+)
+
+// Shape describes how a test tree is built.
+type Shape string
+
+const (
+	// ShapeSequential inserts keys in ascending order.
+	ShapeSequential Shape = "sequential"
+	// ShapeReverse inserts keys in descending order.
+	ShapeReverse Shape = "reverse"
+	// ShapeRandom inserts random keys in random order.
+	ShapeRandom Shape = "random"
+	// ShapeRemoved inserts twice as many random keys, then removes half of them.
+	ShapeRemoved Shape = "removed"
+	// ShapeVersioned builds the tree over several versions, updating, removing and inserting keys
+	// in each, such that leaves have different versions.
+	ShapeVersioned Shape = "versioned"
+)
+
+// Vector types, which determine how a vector is verified.
+const (
+	TypeExist         = "exist"          // ics23.VerifyMembership(IavlSpec, root, proof, key, value)
+	TypeNonExist      = "nonexist"       // ics23.VerifyNonMembership(IavlSpec, root, proof, key)
+	TypeBatchExist    = "batch_exist"    // ics23.BatchVerifyMembership(IavlSpec, root, proof, items)
+	TypeBatchNonExist = "batch_nonexist" // ics23.BatchVerifyNonMembership(IavlSpec, root, proof, keys)
+)
+
+// All keys start with keyPrefix, so nonExistLeft and nonExistRight are before and after all keys.
+const (
+	keyPrefix     = "k"
+	nonExistLeft  = "a"
+	nonExistRight = "z"
+)
+
+// TreeSpec specifies a deterministic test tree.
+type TreeSpec struct {
+	Shape Shape
+	Size  int // the number of keys in the final tree
+	Seed  int64
+}
+
+// Name returns the name of the tree, which is also used as the file name.
+func (s TreeSpec) Name() string {
+	return fmt.Sprintf("%v_%v", s.Shape, s.Size)
+}
+
+// DefaultTrees returns the trees generated by default, i.e. every shape in a range of sizes.
+func DefaultTrees(seed int64) []TreeSpec {
+	specs := []TreeSpec{}
+	for _, shape := range []Shape{ShapeSequential, ShapeReverse, ShapeRandom, ShapeRemoved, ShapeVersioned} {
+		for _, size := range []int{1, 2, 7, 100, 1000} {
+			specs = append(specs, TreeSpec{Shape: shape, Size: size, Seed: seed})
+		}
+	}
+	return specs
+}
+
+// File is a JSON file of test vectors for a single tree. All byte strings are hex-encoded.
+type File struct {
+	Spec    string   `json:"spec"`
+	Tree    TreeInfo `json:"tree"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// TreeInfo describes the tree the vectors were generated from.
+type TreeInfo struct {
+	Name    string `json:"name"`
+	Shape   Shape  `json:"shape"`
+	Size    int    `json:"size"`
+	Seed    int64  `json:"seed"`
+	Version int64  `json:"version"`
+	Root    string `json:"root"`
+}
+
+// Vector is a single test vector. Key and Value are set for single proofs, and Items for batch
+// proofs (without values for non-existence). Proof is a protobuf-encoded ics23.CommitmentProof, and
+// Valid is the expected verification outcome.
+type Vector struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Root  string `json:"root"`
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+	Items []Item `json:"items,omitempty"`
+	Proof string `json:"proof"`
+	Valid bool   `json:"valid"`
+}
+
+// Item is a key/value pair of a batch vector.
+type Item struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// Verify verifies the vector with the Go ICS23 implementation, returning the outcome.
+func (v Vector) Verify() (bool, error) {
+	root, err := hex.DecodeString(v.Root)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid root")
+	}
+	proofBytes, err := hex.DecodeString(v.Proof)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid proof")
+	}
+	proof := &ics23.CommitmentProof{}
+	if err = proof.Unmarshal(proofBytes); err != nil {
+		return false, errors.Wrap(err, "invalid proof")
+	}
+	key, err := hex.DecodeString(v.Key)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid key")
+	}
+	value, err := hex.DecodeString(v.Value)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid value")
+	}
+	items := make(map[string][]byte, len(v.Items))
+	keys := make([][]byte, 0, len(v.Items))
+	for _, item := range v.Items {
+		itemKey, err := hex.DecodeString(item.Key)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid item key")
+		}
+		itemValue, err := hex.DecodeString(item.Value)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid item value")
+		}
+		items[string(itemKey)] = itemValue
+		keys = append(keys, itemKey)
+	}
+
+	switch v.Type {
+	case TypeExist:
+		return ics23.VerifyMembership(ics23.IavlSpec, root, proof, key, value), nil
+	case TypeNonExist:
+		return ics23.VerifyNonMembership(ics23.IavlSpec, root, proof, key), nil
+	case TypeBatchExist:
+		return ics23.BatchVerifyMembership(ics23.IavlSpec, root, proof, items), nil
+	case TypeBatchNonExist:
+		return ics23.BatchVerifyNonMembership(ics23.IavlSpec, root, proof, keys), nil
+	default:
+		return false, errors.Errorf("unknown vector type %q", v.Type)
+	}
+}
+
+// BuildTree builds the tree for the given spec, returning the latest version.
+func BuildTree(spec TreeSpec) (*iavl.ImmutableTree, error) {
+	if spec.Size < 1 {
+		return nil, errors.Errorf("tree size must be positive, got %v", spec.Size)
+	}
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 0)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(spec.Seed))
+
+	switch spec.Shape {
+	case ShapeSequential:
+		for i := 0; i < spec.Size; i++ {
+			tree.Set(sequentialKey(i), randValue(r))
+		}
+	case ShapeReverse:
+		for i := spec.Size - 1; i >= 0; i-- {
+			tree.Set(sequentialKey(i), randValue(r))
+		}
+	case ShapeRandom:
+		for tree.Size() < int64(spec.Size) {
+			tree.Set(randKey(r), randValue(r))
+		}
+	case ShapeRemoved:
+		keys := [][]byte{}
+		for tree.Size() < int64(2*spec.Size) {
+			key := randKey(r)
+			if !tree.Has(key) {
+				keys = append(keys, key)
+			}
+			tree.Set(key, randValue(r))
+		}
+		r.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+		for _, key := range keys[:spec.Size] {
+			tree.Remove(key)
+		}
+	case ShapeVersioned:
+		keys := [][]byte{}
+		for len(keys) < spec.Size {
+			key := randKey(r)
+			if !tree.Has(key) {
+				keys = append(keys, key)
+			}
+			tree.Set(key, randValue(r))
+		}
+		for v := 0; v < 4; v++ {
+			if _, _, err = tree.SaveVersion(); err != nil {
+				return nil, err
+			}
+			for i := 0; i < spec.Size/4+1; i++ {
+				tree.Set(keys[r.Intn(len(keys))], randValue(r))
+			}
+			for i := 0; i < spec.Size/8+1; i++ {
+				j := r.Intn(len(keys))
+				tree.Remove(keys[j])
+				key := randKey(r)
+				for tree.Has(key) {
+					key = randKey(r)
+				}
+				keys[j] = key
+				tree.Set(key, randValue(r))
+			}
+		}
+	default:
+		return nil, errors.Errorf("unknown tree shape %q", spec.Shape)
+	}
+
+	_, version, err := tree.SaveVersion()
+	if err != nil {
+		return nil, err
+	}
+	return tree.GetImmutable(version)
+}
+
+// sequentialKey returns the i'th key of sequential trees.
+func sequentialKey(i int) []byte {
+	return []byte(fmt.Sprintf("%v%08d", keyPrefix, i))
+}
+
+// randKey returns a random key. Keys have the same length, such that appending a 0 byte to a key
+// gives a key between it and the next key.
+func randKey(r *rand.Rand) []byte {
+	key := make([]byte, 8)
+	r.Read(key)
+	return []byte(keyPrefix + hex.EncodeToString(key))
+}
+
+// randValue returns a random non-empty value of varying length.
+func randValue(r *rand.Rand) []byte {
+	value := make([]byte, 1+r.Intn(32))
+	r.Read(value)
+	return value
+}
+
+// Generate generates the test vectors for the given tree spec. The expected outcome of every vector
+// is checked before it is returned.
+func Generate(spec TreeSpec) (*File, error) {
+	tree, err := BuildTree(spec)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{tree: tree, root: tree.Hash()}
+	tree.Iterate(func(key, value []byte) bool {
+		g.keys = append(g.keys, key)
+		g.values = append(g.values, value)
+		return false
+	})
+	if err = g.generate(); err != nil {
+		return nil, errors.Wrapf(err, "tree %v", spec.Name())
+	}
+	for _, vector := range g.vectors {
+		valid, err := vector.Verify()
+		if err != nil {
+			return nil, errors.Wrapf(err, "tree %v vector %v", spec.Name(), vector.Name)
+		}
+		if valid != vector.Valid {
+			return nil, errors.Errorf("tree %v vector %v: expected valid=%v, got %v",
+				spec.Name(), vector.Name, vector.Valid, valid)
+		}
+	}
+
+	return &File{
+		Spec: "iavl",
+		Tree: TreeInfo{
+			Name:    spec.Name(),
+			Shape:   spec.Shape,
+			Size:    spec.Size,
+			Seed:    spec.Seed,
+			Version: tree.Version(),
+			Root:    hex.EncodeToString(g.root),
+		},
+		Vectors: g.vectors,
+	}, nil
+}
+
+// generator generates the vectors of a single tree.
+type generator struct {
+	tree    *iavl.ImmutableTree
+	root    []byte
+	keys    [][]byte // in order
+	values  [][]byte
+	vectors []Vector
+}
+
+func (g *generator) generate() error {
+	n := len(g.keys)
+	middle := (n - 1) / 2
+
+	// Existence proofs, and corrupted variants of them.
+	exist := map[int]*ics23.CommitmentProof{}
+	existIdx := uniqueInts(0, middle, n-1)
+	for _, i := range existIdx {
+		proof, err := g.tree.GetMembershipProof(g.keys[i])
+		if err != nil {
+			return err
+		}
+		exist[i] = proof
+	}
+	for _, c := range []struct {
+		name string
+		i    int
+	}{{"left", 0}, {"middle", middle}, {"right", n - 1}} {
+		g.exist("exist_"+c.name, g.root, g.keys[c.i], g.values[c.i], exist[c.i], true)
+	}
+
+	proof := exist[middle]
+	key, value := g.keys[middle], g.values[middle]
+	g.exist("exist_wrong_value", g.root, key, flipLast(value), proof, false)
+	g.exist("exist_wrong_key", g.root, append(clone(key), 0), value, proof, false)
+	g.exist("exist_wrong_root", flipLast(g.root), key, value, proof, false)
+
+	corrupted := cloneProof(proof)
+	corrupted.GetExist().Leaf.Prefix = flipLast(corrupted.GetExist().Leaf.Prefix)
+	g.exist("exist_corrupt_leaf_prefix", g.root, key, value, corrupted, false)
+
+	corrupted = cloneProof(proof)
+	corrupted.GetExist().Leaf.Hash = ics23.HashOp_SHA512
+	g.exist("exist_wrong_leaf_hash", g.root, key, value, corrupted, false)
+
+	if len(proof.GetExist().Path) > 0 {
+		corrupted = cloneProof(proof)
+		step := corrupted.GetExist().Path[0]
+		if len(step.Prefix) > len(step.Suffix) {
+			step.Prefix = flipLast(step.Prefix)
+		} else {
+			step.Suffix = flipLast(step.Suffix)
+		}
+		g.exist("exist_corrupt_sibling", g.root, key, value, corrupted, false)
+
+		corrupted = cloneProof(proof)
+		path := corrupted.GetExist().Path
+		corrupted.GetExist().Path = path[:len(path)-1]
+		g.exist("exist_truncated_path", g.root, key, value, corrupted, false)
+
+		corrupted = cloneProof(proof)
+		path = corrupted.GetExist().Path
+		corrupted.GetExist().Path = append(path, path[len(path)-1])
+		g.exist("exist_extended_path", g.root, key, value, corrupted, false)
+	}
+
+	// Non-existence proofs, and corrupted variants of them.
+	nonExistKeys := [][]byte{[]byte(nonExistLeft)}
+	if n > 1 {
+		nonExistKeys = append(nonExistKeys, append(clone(g.keys[middle]), 0))
+	}
+	nonExistKeys = append(nonExistKeys, []byte(nonExistRight))
+	nonExist := make([]*ics23.CommitmentProof, 0, len(nonExistKeys))
+	for _, key := range nonExistKeys {
+		proof, err := g.tree.GetNonMembershipProof(key)
+		if err != nil {
+			return err
+		}
+		nonExist = append(nonExist, proof)
+	}
+	g.nonExist("nonexist_left", g.root, nonExistKeys[0], nonExist[0], true)
+	g.nonExist("nonexist_right", g.root, nonExistKeys[len(nonExistKeys)-1], nonExist[len(nonExist)-1], true)
+	if n > 1 {
+		key, proof := nonExistKeys[1], nonExist[1]
+		g.nonExist("nonexist_middle", g.root, key, proof, true)
+		g.nonExist("nonexist_existing_key", g.root, g.keys[middle], proof, false)
+		g.nonExist("nonexist_wrong_root", flipLast(g.root), key, proof, false)
+
+		corrupted := cloneProof(proof)
+		corrupted.GetNonexist().Left = nil
+		g.nonExist("nonexist_missing_left", g.root, key, corrupted, false)
+
+		corrupted = cloneProof(proof)
+		corrupted.GetNonexist().Right = nil
+		g.nonExist("nonexist_missing_right", g.root, key, corrupted, false)
+
+		corrupted = cloneProof(proof)
+		left := corrupted.GetNonexist().Left
+		left.Value = flipLast(left.Value)
+		g.nonExist("nonexist_corrupt_left", g.root, key, corrupted, false)
+	}
+	if n > 2 {
+		// Both neighbours exist, but are not adjacent.
+		left, right := exist[0], exist[n-1]
+		corrupted := &ics23.CommitmentProof{Proof: &ics23.CommitmentProof_Nonexist{
+			Nonexist: &ics23.NonExistenceProof{
+				Key:   nonExistKeys[1],
+				Left:  cloneProof(left).GetExist(),
+				Right: cloneProof(right).GetExist(),
+			},
+		}}
+		g.nonExist("nonexist_not_neighbors", g.root, nonExistKeys[1], corrupted, false)
+	}
+
+	// Batch proofs, both compressed and uncompressed.
+	existProofs := make([]*ics23.CommitmentProof, 0, len(existIdx))
+	existItems := make([]Item, 0, len(existIdx))
+	for _, i := range existIdx {
+		existProofs = append(existProofs, exist[i])
+		existItems = append(existItems, Item{Key: hex.EncodeToString(g.keys[i]), Value: hex.EncodeToString(g.values[i])})
+	}
+	batch, err := ics23.CombineProofs(existProofs)
+	if err != nil {
+		return err
+	}
+	g.batch("batch_exist", TypeBatchExist, g.root, existItems, batch, true)
+	g.batch("batch_exist_uncompressed", TypeBatchExist, g.root, existItems, ics23.Decompress(batch), true)
+
+	wrongItems := append([]Item{}, existItems...)
+	wrongItems[len(wrongItems)-1].Value = hex.EncodeToString(flipLast(g.values[existIdx[len(existIdx)-1]]))
+	g.batch("batch_exist_wrong_value", TypeBatchExist, g.root, wrongItems, batch, false)
+	g.batch("batch_exist_wrong_root", TypeBatchExist, flipLast(g.root), existItems, batch, false)
+
+	nonExistItems := make([]Item, 0, len(nonExistKeys))
+	for _, key := range nonExistKeys {
+		nonExistItems = append(nonExistItems, Item{Key: hex.EncodeToString(key)})
+	}
+	batch, err = ics23.CombineProofs(nonExist)
+	if err != nil {
+		return err
+	}
+	g.batch("batch_nonexist", TypeBatchNonExist, g.root, nonExistItems, batch, true)
+	g.batch("batch_nonexist_uncompressed", TypeBatchNonExist, g.root, nonExistItems, ics23.Decompress(batch), true)
+	existingItems := append(append([]Item{}, nonExistItems...), Item{Key: hex.EncodeToString(g.keys[middle])})
+	g.batch("batch_nonexist_existing_key", TypeBatchNonExist, g.root, existingItems, batch, false)
+
+	return nil
+}
+
+// exist adds an existence vector.
+func (g *generator) exist(name string, root, key, value []byte, proof *ics23.CommitmentProof, valid bool) {
+	g.vectors = append(g.vectors, Vector{
+		Name:  name,
+		Type:  TypeExist,
+		Root:  hex.EncodeToString(root),
+		Key:   hex.EncodeToString(key),
+		Value: hex.EncodeToString(value),
+		Proof: encodeProof(proof),
+		Valid: valid,
+	})
+}
+
+// nonExist adds a non-existence vector.
+func (g *generator) nonExist(name string, root, key []byte, proof *ics23.CommitmentProof, valid bool) {
+	g.vectors = append(g.vectors, Vector{
+		Name:  name,
+		Type:  TypeNonExist,
+		Root:  hex.EncodeToString(root),
+		Key:   hex.EncodeToString(key),
+		Proof: encodeProof(proof),
+		Valid: valid,
+	})
+}
+
+// batch adds a batch vector.
+func (g *generator) batch(name, typ string, root []byte, items []Item, proof *ics23.CommitmentProof, valid bool) {
+	g.vectors = append(g.vectors, Vector{
+		Name:  name,
+		Type:  typ,
+		Root:  hex.EncodeToString(root),
+		Items: items,
+		Proof: encodeProof(proof),
+		Valid: valid,
+	})
+}
+
+// encodeProof hex-encodes the protobuf encoding of a proof.
+func encodeProof(proof *ics23.CommitmentProof) string {
+	bz, err := proof.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(bz)
+}
+
+// cloneProof deep-copies a proof, such that it can be corrupted without affecting the original.
+func cloneProof(proof *ics23.CommitmentProof) *ics23.CommitmentProof {
+	bz, err := proof.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	clone := &ics23.CommitmentProof{}
+	if err = clone.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return clone
+}
+
+// clone copies a byte slice.
+func clone(bz []byte) []byte {
+	return append([]byte{}, bz...)
+}
+
+// flipLast returns a copy of a non-empty byte slice with the last bit flipped.
+func flipLast(bz []byte) []byte {
+	bz = clone(bz)
+	bz[len(bz)-1] ^= 1
+	return bz
+}
+
+// uniqueInts returns the given ints without duplicates, in order.
+func uniqueInts(ints ...int) []int {
+	unique := []int{}
+	seen := map[int]bool{}
+	for _, i := range ints {
+		if !seen[i] {
+			seen[i] = true
+			unique = append(unique, i)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, spec := range DefaultTrees(1) {
+		spec := spec
+		t.Run(spec.Name(), func(t *testing.T) {
+			file, err := Generate(spec)
+			require.NoError(t, err)
+			require.Equal(t, "iavl", file.Spec)
+			require.NotEmpty(t, file.Tree.Root)
+
+			tree, err := BuildTree(spec)
+			require.NoError(t, err)
+			require.EqualValues(t, spec.Size, tree.Size())
+
+			names := map[string]bool{}
+			valid := 0
+			for _, vector := range file.Vectors {
+				require.False(t, names[vector.Name], "duplicate vector %v", vector.Name)
+				names[vector.Name] = true
+				require.Equal(t, file.Tree.Root != vector.Root, strings.HasSuffix(vector.Name, "wrong_root"))
+				ok, err := vector.Verify()
+				require.NoError(t, err)
+				require.Equal(t, vector.Valid, ok, vector.Name)
+				if ok {
+					valid++
+				}
+			}
+			require.Less(t, valid, len(file.Vectors))
+			for _, name := range []string{"exist_left", "exist_middle", "exist_right", "nonexist_left",
+				"nonexist_right", "batch_exist", "batch_nonexist"} {
+				require.True(t, names[name], "missing vector %v", name)
+			}
+			if spec.Size > 1 {
+				require.True(t, names["nonexist_middle"])
+			}
+		})
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ics23vectors")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, spec := range []TreeSpec{{ShapeRandom, 100, 1}, {ShapeVersioned, 100, 1}} {
+		path := filepath.Join(dir, spec.Name()+".json")
+		require.NoError(t, WriteFile(path, spec))
+		first, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, WriteFile(path, spec))
+		second, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+
+		other, err := Generate(TreeSpec{spec.Shape, spec.Size, 2})
+		require.NoError(t, err)
+		file, err := Generate(spec)
+		require.NoError(t, err)
+		require.NotEqual(t, file.Tree.Root, other.Tree.Root)
+	}
+}
+
+func TestBuildTree_Versioned(t *testing.T) {
+	tree, err := BuildTree(TreeSpec{ShapeVersioned, 100, 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 5, tree.Version())
+
+	_, err = BuildTree(TreeSpec{"unknown", 100, 1})
+	require.Error(t, err)
+	_, err = BuildTree(TreeSpec{ShapeRandom, 0, 1})
+	require.Error(t, err)
+}
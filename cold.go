@@ -0,0 +1,341 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// DefaultColdHotVersions is the default number of latest versions whose nodes are kept in the hot
+// database when cold storage is enabled.
+const DefaultColdHotVersions = 100
+
+// ColdOptions configures cold storage, set via Options.Cold. Nodes which are only referenced by
+// old versions are rarely read, so they can be moved from the (hot) tree database to a secondary,
+// cheaper cold database by a ColdMover, see MutableTree.StartColdMover(). Node reads fall through
+// from the hot to the cold database transparently, and pruning deletes nodes from both.
+//
+// Only nodes are moved, all other entries such as roots and orphans stay in the hot database. A
+// tree with cold storage must always be opened with the same cold database, since the hot
+// database alone is incomplete.
+type ColdOptions struct {
+	// DB is the cold database.
+	DB dbm.DB
+
+	// HotVersions is the number of latest versions whose nodes are kept in the hot database, i.e.
+	// nodes are moved once no version within this window references them. Zero uses
+	// DefaultColdHotVersions.
+	HotVersions int64
+}
+
+// ColdStats contains cold storage metrics.
+type ColdStats struct {
+	MovedNodes int64 // The number of nodes moved to the cold database.
+	MovedBytes int64 // The number of node bytes moved to the cold database.
+	ColdReads  int64 // The number of node reads which fell through to the cold database.
+}
+
+// String implements fmt.Stringer.
+func (s ColdStats) String() string {
+	return fmt.Sprintf("%v nodes (%v bytes) moved, %v cold reads", s.MovedNodes, s.MovedBytes, s.ColdReads)
+}
+
+// ColdStats returns the cold storage metrics, or zero metrics if Options.Cold is not set.
+func (tree *MutableTree) ColdStats() ColdStats {
+	c := tree.ndb.cold
+	if c == nil {
+		return ColdStats{}
+	}
+	return ColdStats{
+		MovedNodes: atomic.LoadInt64(&c.movedNodes),
+		MovedBytes: atomic.LoadInt64(&c.movedBytes),
+		ColdReads:  atomic.LoadInt64(&c.reads),
+	}
+}
+
+// coldDB is the cold database of a nodeDB.
+type coldDB struct {
+	db          dbm.DB
+	hotVersions int64
+	batch       dbm.Batch // pending node deletions, written once the hot batch has been written
+
+	// Orphan entries before next have been moved. Rewritten orphan entries may end up before it,
+	// so rewind is the smallest orphan key written to the pending hot batch, which next is
+	// rewound to once the batch has been written.
+	next   []byte
+	rewind []byte
+
+	movedNodes int64 // accessed atomically
+	movedBytes int64 // accessed atomically
+	reads      int64 // accessed atomically
+}
+
+func newColdDB(opts *ColdOptions) *coldDB {
+	hotVersions := opts.HotVersions
+	if hotVersions == 0 {
+		hotVersions = DefaultColdHotVersions
+	}
+	return &coldDB{
+		db:          opts.DB,
+		hotVersions: hotVersions,
+		batch:       opts.DB.NewBatch(),
+		next:        orphanKeyFormat.Key(),
+	}
+}
+
+// validateColdOptions checks the cold storage options, if any.
+func validateColdOptions(opts *ColdOptions) error {
+	if opts == nil {
+		return nil
+	}
+	if opts.DB == nil {
+		return errors.New("cold storage requires a database")
+	}
+	if opts.HotVersions < 0 {
+		return errors.Errorf("cold storage hot versions cannot be negative, got %v", opts.HotVersions)
+	}
+	return nil
+}
+
+// deleteNode deletes a node from the cold database once the hot batch has been written.
+func (c *coldDB) deleteNode(key []byte) error {
+	return c.batch.Delete(key)
+}
+
+// orphanWritten notes an orphan entry written to the pending hot batch.
+func (c *coldDB) orphanWritten(key []byte) {
+	if c.rewind == nil || bytes.Compare(key, c.rewind) < 0 {
+		c.rewind = key
+	}
+}
+
+// committed writes the pending cold database changes, and must be called once the hot batch has
+// been written. Node deletions are written after the hot batch, such that a crash in between can
+// only leave unreferenced nodes in the cold database rather than losing referenced ones.
+func (c *coldDB) committed() error {
+	if err := c.batch.Write(); err != nil {
+		return errors.Wrap(err, "failed to write cold batch")
+	}
+	c.batch.Close()
+	c.batch = c.db.NewBatch()
+	if c.rewind != nil && bytes.Compare(c.rewind, c.next) < 0 {
+		c.next = c.rewind
+	}
+	c.rewind = nil
+	return nil
+}
+
+// get reads a node database entry, falling through to the cold database if it is not in the hot
+// database.
+func (ndb *nodeDB) get(key []byte) ([]byte, error) {
+	value, err := ndb.db.Get(key)
+	if err != nil || value != nil || ndb.cold == nil {
+		return value, err
+	}
+	value, err = ndb.cold.db.Get(key)
+	if value != nil {
+		atomic.AddInt64(&ndb.cold.reads, 1)
+	}
+	return value, err
+}
+
+// deleteNodeKey deletes a node entry from the hot database and, once the batch is written, from
+// the cold database.
+func (ndb *nodeDB) deleteNodeKey(key []byte) error {
+	if err := ndb.batch.Delete(key); err != nil {
+		return err
+	}
+	if ndb.cold != nil {
+		return ndb.cold.deleteNode(key)
+	}
+	return nil
+}
+
+// ColdMoveRound contains the results of a single cold mover round.
+type ColdMoveRound struct {
+	Nodes    int   // The number of nodes moved.
+	Bytes    int64 // The number of node bytes moved.
+	Err      error // The error which failed the round, if any.
+	Duration time.Duration
+}
+
+// moveCold moves up to limit nodes which are not referenced by any of the hot versions from the
+// hot database to the cold database. It returns true if there may be more nodes to move.
+func (ndb *nodeDB) moveCold(limit int) (round ColdMoveRound, more bool) {
+	start := time.Now()
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+
+	more, round.Err = ndb.moveColdLocked(limit, &round)
+	round.Duration = time.Since(start)
+	return round, more
+}
+
+func (ndb *nodeDB) moveColdLocked(limit int, round *ColdMoveRound) (bool, error) {
+	c := ndb.cold
+
+	// Nodes orphaned at or before the target version are not referenced by any hot version. Orphan
+	// keys are ordered by the last version referencing the node.
+	target := ndb.getLatestVersion() - c.hotVersions
+	if target < 1 {
+		return false, nil
+	}
+	end := orphanKeyFormat.Key(target + 1)
+	if bytes.Compare(c.next, end) >= 0 {
+		return false, nil
+	}
+
+	// Collect the orphans first, since the database may not be read while iterating.
+	var last []byte
+	var hashes [][]byte
+	itr, err := ndb.db.Iterator(c.next, end)
+	if err != nil {
+		return false, err
+	}
+	for ; itr.Valid() && len(hashes) < limit; itr.Next() {
+		last = append([]byte{}, itr.Key()...)
+		hashes = append(hashes, append([]byte{}, itr.Value()...))
+	}
+	err = itr.Error()
+	itr.Close()
+	if err != nil {
+		return false, err
+	}
+
+	// The nodes are written to the cold database before they are deleted from the hot database.
+	// The deletions deliberately use a plain batch rather than ndb.newBatch(): the shadow database
+	// has no cold tier, so it must keep the moved nodes for sampled reads to find them, and they
+	// are deleted from it once pruned via deleteNodeKey().
+	coldBatch := c.db.NewBatch()
+	defer coldBatch.Close()
+	hotBatch := ndb.db.NewBatch()
+	defer hotBatch.Close()
+	for _, hash := range hashes {
+		key := ndb.nodeKey(hash)
+		value, err := ndb.db.Get(key)
+		if err != nil {
+			return false, err
+		}
+		if value == nil {
+			continue // already moved, or pruned
+		}
+		if err = coldBatch.Set(key, value); err != nil {
+			return false, err
+		}
+		if err = hotBatch.Delete(key); err != nil {
+			return false, err
+		}
+		round.Nodes++
+		round.Bytes += int64(len(value))
+	}
+	if err = coldBatch.WriteSync(); err != nil {
+		return false, errors.Wrap(err, "failed to write cold batch")
+	}
+	if ndb.opts.Sync {
+		err = hotBatch.WriteSync()
+	} else {
+		err = hotBatch.Write()
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "failed to write hot batch")
+	}
+
+	atomic.AddInt64(&c.movedNodes, int64(round.Nodes))
+	atomic.AddInt64(&c.movedBytes, round.Bytes)
+	if len(hashes) < limit {
+		c.next = end
+		return false, nil
+	}
+	c.next = append(last, 0)
+	return true, nil
+}
+
+// ColdMoverOptions configures the background cold mover started by MutableTree.StartColdMover().
+type ColdMoverOptions struct {
+	// Interval is the time between rounds once all movable nodes have been moved. Defaults to 1
+	// second.
+	Interval time.Duration
+
+	// BatchSize is the maximum number of nodes moved in each round, which are held in memory.
+	// Defaults to 1000.
+	BatchSize int
+
+	// OnRound is called after each round, e.g. to log errors or update metrics.
+	OnRound func(ColdMoveRound)
+}
+
+// ColdMover moves nodes of old versions to the cold database in the background. It is created by
+// MutableTree.StartColdMover(), and must be stopped with Stop().
+type ColdMover struct {
+	tree *MutableTree
+	opts ColdMoverOptions
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// StartColdMover starts moving nodes which are not referenced by any of the latest
+// ColdOptions.HotVersions versions to the cold database in the background, see Options.Cold. Rounds
+// run back to back while there are nodes to move, and otherwise every Interval.
+//
+// The mover can run concurrently with all tree operations. Nodes of versions which become old
+// while the mover is stopped are moved once it is started again.
+func (tree *MutableTree) StartColdMover(opts ColdMoverOptions) (*ColdMover, error) {
+	if tree.ndb.cold == nil {
+		return nil, errors.New("cold storage is not enabled, see Options.Cold")
+	}
+	if opts.Interval == 0 {
+		opts.Interval = time.Second
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = 1000
+	}
+	if opts.Interval < 0 {
+		return nil, errors.Errorf("cold mover interval cannot be negative, got %v", opts.Interval)
+	}
+	if opts.BatchSize < 0 {
+		return nil, errors.Errorf("cold mover batch size cannot be negative, got %v", opts.BatchSize)
+	}
+
+	m := &ColdMover{
+		tree: tree,
+		opts: opts,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go m.run()
+	return m, nil
+}
+
+// Stop stops the mover, and waits for the current round to complete. It is safe to call multiple
+// times.
+func (m *ColdMover) Stop() {
+	m.once.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+// run runs mover rounds until stopped.
+func (m *ColdMover) run() {
+	defer close(m.done)
+	for {
+		round, more := m.tree.ndb.moveCold(m.opts.BatchSize)
+		if m.opts.OnRound != nil {
+			m.opts.OnRound(round)
+		}
+
+		var wait time.Duration
+		if round.Err != nil || !more {
+			wait = m.opts.Interval
+		}
+		select {
+		case <-m.stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
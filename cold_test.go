@@ -0,0 +1,221 @@
+package iavl
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// moveAllCold moves all movable nodes to the cold database, returning the number moved.
+func moveAllCold(t *testing.T, tree *MutableTree) int {
+	moved := 0
+	for more := true; more; {
+		var round ColdMoveRound
+		round, more = tree.ndb.moveCold(10)
+		require.NoError(t, round.Err)
+		moved += round.Nodes
+	}
+	return moved
+}
+
+// requireSameVersions checks that all versions of the trees have the same contents.
+func requireSameVersions(t *testing.T, expected, actual *MutableTree) {
+	require.Equal(t, expected.AvailableVersions(), actual.AvailableVersions())
+	for _, version := range expected.AvailableVersions() {
+		expectedTree, err := expected.GetImmutable(int64(version))
+		require.NoError(t, err)
+		actualTree, err := actual.GetImmutable(int64(version))
+		require.NoError(t, err)
+		require.Equal(t, expectedTree.Hash(), actualTree.Hash())
+		var expectedItems, actualItems []string
+		expectedTree.Iterate(func(key, value []byte) bool {
+			expectedItems = append(expectedItems, string(key)+"="+string(value))
+			return false
+		})
+		actualTree.Iterate(func(key, value []byte) bool {
+			actualItems = append(actualItems, string(key)+"="+string(value))
+			return false
+		})
+		require.Equal(t, expectedItems, actualItems, "version %v", version)
+	}
+}
+
+func TestCold(t *testing.T) {
+	for _, levels := range []int{0, 3} {
+		levels := levels
+		t.Run(fmt.Sprintf("pages %v", levels), func(t *testing.T) {
+			testCold(t, levels)
+		})
+	}
+}
+
+func testCold(t *testing.T, pageLevels int) {
+	hotDB, coldDB := db.NewMemDB(), db.NewMemDB()
+	plain, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	opts := &Options{NodePageLevels: pageLevels, Cold: &ColdOptions{DB: coldDB, HotVersions: 2}}
+	tree, err := NewMutableTreeWithOpts(hotDB, 0, opts)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	for v := 1; v <= 10; v++ {
+		for i := 0; i < 50; i++ {
+			key := []byte(fmt.Sprintf("key-%03d", r.Intn(200)))
+			value := []byte(fmt.Sprintf("value-%v-%v", v, i))
+			plain.Set(key, value)
+			tree.Set(key, value)
+		}
+		_, _, err = plain.SaveVersion()
+		require.NoError(t, err)
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+		if v == 5 {
+			require.Greater(t, moveAllCold(t, tree), 0)
+		}
+	}
+	require.Greater(t, moveAllCold(t, tree), 0)
+	require.Zero(t, moveAllCold(t, tree))
+	require.Equal(t, nodeHashes(plain.ndb), nodeHashes(tree.ndb))
+	stats := tree.ColdStats()
+	require.EqualValues(t, countPrefix(dumpDB(t, coldDB), 'n'), stats.MovedNodes)
+	require.Greater(t, stats.MovedBytes, stats.MovedNodes)
+
+	// Nodes of the hot versions are all in the hot database.
+	for _, version := range []int64{9, 10} {
+		hot, err := tree.GetImmutable(version)
+		require.NoError(t, err)
+		hot.root.traverse(hot, true, func(node *Node) bool {
+			has, err := hotDB.Has(nodeKeyFormat.Key(node.hash))
+			require.NoError(t, err)
+			require.True(t, has, "node %X of version %v is not hot", node.hash, version)
+			return false
+		})
+	}
+
+	// Reads of old versions fall through to the cold database.
+	tree, err = NewMutableTreeWithOpts(hotDB, 0, opts)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	requireSameVersions(t, plain, tree)
+	require.Greater(t, tree.ColdStats().ColdReads, int64(0))
+
+	// Pruning deletes nodes from both databases.
+	require.NoError(t, plain.DeleteVersion(2))
+	require.NoError(t, tree.DeleteVersion(2))
+	require.NoError(t, plain.DeleteVersionsRange(4, 9))
+	require.NoError(t, tree.DeleteVersionsRange(4, 9))
+	require.Equal(t, nodeHashes(plain.ndb), nodeHashes(tree.ndb))
+	requireSameVersions(t, plain, tree)
+
+	// Overwriting versions deletes nodes from both databases.
+	_, err = plain.LoadVersionForOverwriting(3)
+	require.NoError(t, err)
+	_, err = tree.LoadVersionForOverwriting(3)
+	require.NoError(t, err)
+	require.Equal(t, nodeHashes(plain.ndb), nodeHashes(tree.ndb))
+	for v := 4; v <= 6; v++ {
+		plain.Set([]byte("key-000"), []byte(fmt.Sprintf("overwritten-%v", v)))
+		tree.Set([]byte("key-000"), []byte(fmt.Sprintf("overwritten-%v", v)))
+		_, _, err = plain.SaveVersion()
+		require.NoError(t, err)
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	moveAllCold(t, tree)
+	require.NoError(t, plain.DeleteVersion(1))
+	require.NoError(t, tree.DeleteVersion(1))
+	require.Equal(t, nodeHashes(plain.ndb), nodeHashes(tree.ndb))
+	requireSameVersions(t, plain, tree)
+}
+
+func TestColdMover(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	_, err = tree.StartColdMover(ColdMoverOptions{})
+	require.Error(t, err)
+	_, err = NewMutableTreeWithOpts(db.NewMemDB(), 0, &Options{Cold: &ColdOptions{}})
+	require.Error(t, err)
+	_, err = NewMutableTreeWithOpts(db.NewMemDB(), 0, &Options{Cold: &ColdOptions{DB: db.NewMemDB(), HotVersions: -1}})
+	require.Error(t, err)
+
+	tree, err = NewMutableTreeWithOpts(db.NewMemDB(), 0, &Options{Cold: &ColdOptions{DB: db.NewMemDB(), HotVersions: 1}})
+	require.NoError(t, err)
+	var mtx sync.Mutex
+	var errs []error
+	mover, err := tree.StartColdMover(ColdMoverOptions{
+		Interval:  time.Millisecond,
+		BatchSize: 5,
+		OnRound: func(round ColdMoveRound) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			if round.Err != nil {
+				errs = append(errs, round.Err)
+			}
+		},
+	})
+	require.NoError(t, err)
+	defer mover.Stop()
+
+	// The mover runs concurrently with writes, and eventually moves all orphaned nodes.
+	for v := 0; v < 20; v++ {
+		for i := 0; i < 20; i++ {
+			tree.Set([]byte(fmt.Sprintf("key-%02d", i)), []byte(fmt.Sprintf("value-%v", v)))
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	orphans := len(tree.ndb.orphans())
+	require.Eventually(t, func() bool {
+		return tree.ColdStats().MovedNodes == int64(orphans)
+	}, 5*time.Second, 10*time.Millisecond)
+	mover.Stop()
+	mover.Stop()
+	require.Empty(t, errs)
+
+	for v := int64(1); v <= 20; v++ {
+		_, value := tree.GetVersioned([]byte("key-00"), v)
+		require.Equal(t, []byte(fmt.Sprintf("value-%v", v-1)), value)
+	}
+}
+
+func TestCold_Shadow(t *testing.T) {
+	hotDB, coldDB, shadowDB := db.NewMemDB(), db.NewMemDB(), db.NewMemDB()
+	var divergences []ShadowDivergence
+	tree, err := NewMutableTreeWithOpts(hotDB, 0, &Options{
+		Cold: &ColdOptions{DB: coldDB, HotVersions: 1},
+		Shadow: &ShadowOptions{DB: shadowDB, ReadSampleRate: 1, OnDivergence: func(d ShadowDivergence) {
+			divergences = append(divergences, d)
+		}},
+	})
+	require.NoError(t, err)
+	for v := 1; v <= 5; v++ {
+		for i := 0; i < 20; i++ {
+			tree.Set([]byte(fmt.Sprintf("key-%02d", (i*v)%30)), []byte(fmt.Sprintf("value-%v-%v", v, i)))
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	require.Greater(t, moveAllCold(t, tree), 0)
+
+	// The shadow database keeps moved nodes, so reads of cold nodes do not diverge.
+	require.Equal(t, countPrefix(dumpDB(t, hotDB), 'n')+countPrefix(dumpDB(t, coldDB), 'n'),
+		countPrefix(dumpDB(t, shadowDB), 'n'))
+	old, err := tree.GetImmutable(1)
+	require.NoError(t, err)
+	old.Iterate(func(key, value []byte) bool { return false })
+	require.Greater(t, tree.ColdStats().ColdReads, int64(0))
+	require.Empty(t, divergences)
+
+	// Pruned nodes are deleted from all databases.
+	require.NoError(t, tree.DeleteVersionsRange(1, 5))
+	require.Equal(t, countPrefix(dumpDB(t, hotDB), 'n')+countPrefix(dumpDB(t, coldDB), 'n'),
+		countPrefix(dumpDB(t, shadowDB), 'n'))
+	require.Empty(t, divergences)
+}
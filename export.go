@@ -28,19 +28,34 @@ type ExportNode struct {
 // depth-first post-order (LRN), this order must be preserved when importing in order to recreate
 // the same tree structure.
 type Exporter struct {
-	tree   *ImmutableTree
-	ch     chan *ExportNode
-	cancel context.CancelFunc
+	tree     *ImmutableTree
+	traverse func(cb func(*Node) bool) bool
+	ch       chan *ExportNode
+	cancel   context.CancelFunc
+	span     Span
+	nodes    int64  // number of nodes exported, written by the export goroutine
+	onClose  func() // called once when closed, e.g. to release a SyncMutableTree lock
 }
 
 // NewExporter creates a new Exporter. Callers must call Close() when done.
 func newExporter(tree *ImmutableTree) *Exporter {
+	return newNodeExporter(tree, func(cb func(*Node) bool) bool {
+		return tree.root.traversePost(tree, true, cb)
+	})
+}
+
+// newNodeExporter creates a new Exporter which exports the nodes visited by the given post-order
+// traversal. Callers must call Close() when done.
+func newNodeExporter(tree *ImmutableTree, traverse func(cb func(*Node) bool) bool) *Exporter {
 	ctx, cancel := context.WithCancel(context.Background())
 	exporter := &Exporter{
-		tree:   tree,
-		ch:     make(chan *ExportNode, exportBufferSize),
-		cancel: cancel,
+		tree:     tree,
+		traverse: traverse,
+		ch:       make(chan *ExportNode, exportBufferSize),
+		cancel:   cancel,
+		span:     tree.ndb.startSpan(SpanExport),
 	}
+	exporter.span.SetAttribute("version", tree.version)
 
 	tree.ndb.incrVersionReaders(tree.version)
 	go exporter.export(ctx)
@@ -50,7 +65,7 @@ func newExporter(tree *ImmutableTree) *Exporter {
 
 // export exports nodes
 func (e *Exporter) export(ctx context.Context) {
-	e.tree.root.traversePost(e.tree, true, func(node *Node) bool {
+	e.traverse(func(node *Node) bool {
 		exportNode := &ExportNode{
 			Key:     node.key,
 			Value:   node.value,
@@ -60,6 +75,7 @@ func (e *Exporter) export(ctx context.Context) {
 
 		select {
 		case e.ch <- exportNode:
+			e.nodes++
 			return false
 		case <-ctx.Done():
 			return true
@@ -83,6 +99,66 @@ func (e *Exporter) Close() {
 	}
 	if e.tree != nil {
 		e.tree.ndb.decrVersionReaders(e.tree.version)
+		e.span.SetAttribute("nodes", e.nodes)
+		e.span.End()
+		if e.onClose != nil {
+			e.onClose()
+		}
 	}
 	e.tree = nil
 }
+
+// ExportSegments splits the tree at the given depth into independently exported segments, for
+// use with MutableTree.ImportParallel(). The root has depth 0. Callers must call Close() on all
+// returned exporters when done.
+//
+// Each segment is the subtree rooted at a node at the given depth, or at a leaf node above it, and
+// segments are returned in key order. The top exporter exports all nodes above and including the
+// segment roots in depth-first post-order (LRN), which allows the importer to stitch the segments
+// together with their parents.
+func (t *ImmutableTree) ExportSegments(depth int) (top *Exporter, segments []*Exporter, err error) {
+	if depth < 0 {
+		return nil, nil, errors.New("depth cannot be negative")
+	}
+
+	var roots []*Node
+	t.root.traversePostToDepth(t, depth, func(node *Node, segmentRoot bool) bool {
+		if segmentRoot {
+			roots = append(roots, node)
+		}
+		return false
+	})
+
+	top = newNodeExporter(t, func(cb func(*Node) bool) bool {
+		return t.root.traversePostToDepth(t, depth, func(node *Node, _ bool) bool {
+			return cb(node)
+		})
+	})
+	segments = make([]*Exporter, 0, len(roots))
+	for _, root := range roots {
+		root := root
+		segments = append(segments, newNodeExporter(t, func(cb func(*Node) bool) bool {
+			return root.traversePost(t, true, cb)
+		}))
+	}
+	return top, segments, nil
+}
+
+// traversePostToDepth traverses the node and its descendants down to the given depth in
+// depth-first post-order, stopping at leaves and at nodes at that depth which are reported as
+// segment roots.
+func (node *Node) traversePostToDepth(t *ImmutableTree, depth int, cb func(node *Node, segmentRoot bool) bool) bool {
+	if node == nil {
+		return false
+	}
+	if depth == 0 || node.isLeaf() {
+		return cb(node, true)
+	}
+	if node.getLeftNode(t).traversePostToDepth(t, depth-1, cb) {
+		return true
+	}
+	if node.getRightNode(t).traversePostToDepth(t, depth-1, cb) {
+		return true
+	}
+	return cb(node, false)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	dbm "github.com/tendermint/tm-db"
 )
 
@@ -204,6 +205,16 @@ func (t *ImmutableTree) IterateRangeInclusive(start, end []byte, ascending bool,
 	})
 }
 
+// GetEncodedNode returns the persisted node with the given hash in its canonical encoding, as
+// decoded by MakeNode(), or nil if it does not exist. It can be used to serve nodes to remote
+// clients, e.g. RemoteNodeDB. Any persisted node can be returned, regardless of tree version.
+func (t *ImmutableTree) GetEncodedNode(hash []byte) ([]byte, error) {
+	if t.ndb == nil {
+		return nil, errors.New("in-memory trees have no persisted nodes")
+	}
+	return t.ndb.GetEncodedNode(hash)
+}
+
 // Clone creates a clone of the tree.
 // Used internally by MutableTree.
 func (t *ImmutableTree) clone() *ImmutableTree {
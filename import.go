@@ -27,6 +27,10 @@ type Importer struct {
 	batch     db.Batch
 	batchSize uint32
 	stack     []*Node
+	span      Span
+	nodes     int64
+	bytes     int64
+	onClose   func() // called once when closed, e.g. to release a SyncMutableTree lock
 }
 
 // newImporter creates a new Importer for an empty MutableTree.
@@ -44,11 +48,14 @@ func newImporter(tree *MutableTree, version int64) (*Importer, error) {
 		return nil, errors.New("tree must be empty")
 	}
 
+	span := tree.ndb.startSpan(SpanImport)
+	span.SetAttribute("version", version)
 	return &Importer{
 		tree:    tree,
 		version: version,
-		batch:   tree.ndb.db.NewBatch(),
+		batch:   tree.ndb.newBatch(),
 		stack:   make([]*Node, 0, 8),
+		span:    span,
 	}, nil
 }
 
@@ -58,6 +65,14 @@ func (i *Importer) Close() {
 	if i.batch != nil {
 		i.batch.Close()
 	}
+	if i.tree != nil {
+		i.span.SetAttribute("nodes", i.nodes)
+		i.span.SetAttribute("bytes", i.bytes)
+		i.span.End()
+		if i.onClose != nil {
+			i.onClose()
+		}
+	}
 	i.batch = nil
 	i.tree = nil
 }
@@ -119,38 +134,68 @@ func (i *Importer) Add(exportNode *ExportNode) error {
 		return err
 	}
 
-	isTrackable := i.tree.ndb.db.IsTrackable()
+	if i.tree.ndb.pageLevels() > 0 {
+		// The node is written along with its page once the page is complete, or by Commit().
+		err = i.tree.ndb.flushChildPages(node, i.writePage)
+	} else {
+		err = i.writeNode(node)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Update the stack now that we know there were no errors
+	switch {
+	case node.leftHash != nil && node.rightHash != nil:
+		i.stack = i.stack[:stackSize-2]
+	case node.leftHash != nil || node.rightHash != nil:
+		i.stack = i.stack[:stackSize-1]
+	}
+	i.stack = append(i.stack, node)
+
+	return nil
+}
 
+// writeNode writes a single node to the batch.
+func (i *Importer) writeNode(node *Node) error {
 	var buf bytes.Buffer
-	err = node.writeBytesEx(&buf, isTrackable)
+	err := node.writeBytesEx(&buf, i.tree.ndb.db.IsTrackable())
 	if err != nil {
 		return err
 	}
-
 	if err = i.batch.Set(i.tree.ndb.nodeKey(node.hash), buf.Bytes()); err != nil {
 		return err
 	}
+	i.nodes++
+	i.bytes += int64(buf.Len())
+	return i.written(1)
+}
 
-	i.batchSize++
+// writePage writes a page of nodes to the batch.
+func (i *Importer) writePage(nodes []*Node) error {
+	i.tree.ndb.mtx.Lock()
+	size, err := i.tree.ndb.writePage(i.batch, nodes)
+	i.tree.ndb.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	i.nodes += int64(len(nodes))
+	i.bytes += int64(size)
+	return i.written(len(nodes))
+}
+
+// written records nodes written to the batch, and flushes the batch when it is full.
+func (i *Importer) written(nodes int) error {
+	i.batchSize += uint32(nodes)
 	if i.batchSize >= maxBatchSize {
-		err = i.batch.Write()
+		err := i.batch.Write()
 		if err != nil {
 			return err
 		}
 		i.batch.Close()
-		i.batch = i.tree.ndb.db.NewBatch()
+		i.batch = i.tree.ndb.newBatch()
 		i.batchSize = 0
 	}
-
-	// Update the stack now that we know there were no errors
-	switch {
-	case node.leftHash != nil && node.rightHash != nil:
-		i.stack = i.stack[:stackSize-2]
-	case node.leftHash != nil || node.rightHash != nil:
-		i.stack = i.stack[:stackSize-1]
-	}
-	i.stack = append(i.stack, node)
-
 	return nil
 }
 
@@ -168,6 +213,11 @@ func (i *Importer) Commit() error {
 			panic(err)
 		}
 	case 1:
+		if root := i.stack[0]; i.tree.ndb.pageLevels() > 0 && !root.persisted {
+			if err := i.writePage(root.pageNodes(nil)); err != nil {
+				return err
+			}
+		}
 		if err := i.batch.Set(i.tree.ndb.rootKey(i.version), i.stack[0].hash); err != nil {
 			panic(err)
 		}
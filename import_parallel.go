@@ -0,0 +1,201 @@
+package iavl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ExportNodeReader reads ExportNodes in depth-first post-order (LRN), returning ExportDone once
+// all nodes have been read. It is implemented by Exporter.
+type ExportNodeReader interface {
+	Next() (*ExportNode, error)
+}
+
+var _ ExportNodeReader = (*Exporter)(nil)
+
+// ParallelImporter imports independently exported tree segments into an empty MutableTree, as
+// returned by ImmutableTree.ExportSegments(). It is created by MutableTree.ImportParallel(). Users
+// must call Close() when done.
+//
+// Each segment is hashed and written to the database in its own goroutine. The segments are then
+// stitched together with their parents when committing, and the resulting root hash is verified.
+// The produced tree is identical to one imported sequentially via MutableTree.Import().
+//
+// ParallelImporter is not concurrency-safe, it is the caller's responsibility to ensure the tree
+// is not modified while performing an import.
+type ParallelImporter struct {
+	top      *Importer
+	segments []*segmentImport
+	wg       sync.WaitGroup
+}
+
+// segmentImport contains the result of importing a single segment.
+type segmentImport struct {
+	root *Node
+	err  error
+}
+
+// ImportParallel returns a parallel importer for tree segments previously exported by
+// ImmutableTree.ExportSegments(), producing an identical IAVL tree. The caller must call Close()
+// on the importer when done.
+//
+// version should correspond to the version that was initially exported. It must be greater than
+// or equal to the highest ExportNode version number given.
+//
+// ImportParallel can only be called on an empty tree. It is the callers responsibility that no
+// other modifications are made to the tree while importing.
+func (tree *MutableTree) ImportParallel(version int64) (*ParallelImporter, error) {
+	top, err := newImporter(tree, version)
+	if err != nil {
+		return nil, err
+	}
+	return &ParallelImporter{top: top}, nil
+}
+
+// AddSegment starts importing a segment in a new goroutine, reading its nodes from the given
+// reader until it returns ExportDone. Segments must be added in key order, i.e. in the order
+// returned by ImmutableTree.ExportSegments(). Nodes are flushed to the database as they are
+// imported, but the imported version is not visible until Commit() is called.
+func (i *ParallelImporter) AddSegment(reader ExportNodeReader) error {
+	if i.top == nil || i.top.tree == nil {
+		return ErrNoImport
+	}
+	if reader == nil {
+		return errors.New("segment reader cannot be nil")
+	}
+
+	// The segment goroutine must not access the top importer, since it may be closed
+	// concurrently.
+	tree, version := i.top.tree, i.top.version
+	segment := &segmentImport{}
+	i.segments = append(i.segments, segment)
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		segment.root, segment.err = importSegment(tree, version, reader)
+	}()
+	return nil
+}
+
+// importSegment imports all nodes of a single segment into the tree, and returns its root node.
+func importSegment(tree *MutableTree, version int64, reader ExportNodeReader) (*Node, error) {
+	importer := &Importer{
+		tree:    tree,
+		version: version,
+		batch:   tree.ndb.newBatch(),
+		stack:   make([]*Node, 0, 8),
+		span:    tree.ndb.startSpan(SpanImport),
+	}
+	importer.span.SetAttribute("version", importer.version)
+	importer.span.SetAttribute("segment", true)
+	defer importer.Close()
+
+	for {
+		exportNode, err := reader.Next()
+		if err == ExportDone {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if err = importer.Add(exportNode); err != nil {
+			return nil, err
+		}
+	}
+	if len(importer.stack) != 1 {
+		return nil, errors.Errorf("invalid segment structure, found stack size %v after import",
+			len(importer.stack))
+	}
+
+	if err := importer.batch.WriteSync(); err != nil {
+		return nil, err
+	}
+	return importer.stack[0], nil
+}
+
+// Commit waits for all segments to be imported, and then stitches them together by reading the
+// top of the tree from the given reader, as returned by ImmutableTree.ExportSegments(). The root
+// hash of the resulting tree must match rootHash, otherwise an error is returned and the version
+// is not made visible. On success, the version is made visible and the tree metadata is updated.
+// It can only be called once, and calls Close() internally.
+func (i *ParallelImporter) Commit(top ExportNodeReader, rootHash []byte) error {
+	if i.top == nil || i.top.tree == nil {
+		return ErrNoImport
+	}
+	defer i.Close()
+	if top == nil {
+		return errors.New("top reader cannot be nil")
+	}
+
+	i.wg.Wait()
+	for idx, segment := range i.segments {
+		if segment.err != nil {
+			return errors.Wrapf(segment.err, "failed to import segment %v", idx)
+		}
+	}
+
+	next := 0
+	for {
+		exportNode, err := top.Next()
+		if err == ExportDone {
+			break
+		} else if err != nil {
+			return err
+		}
+		if exportNode == nil {
+			return errors.New("node cannot be nil")
+		}
+
+		// Segment roots are given in the top stream too, and are replaced by the imported
+		// segments. Any other node is a parent, which is built from the nodes on the stack.
+		if next < len(i.segments) && i.segments[next].root.matches(exportNode) {
+			i.top.stack = append(i.top.stack, i.segments[next].root)
+			next++
+			continue
+		}
+		stackSize := len(i.top.stack)
+		if exportNode.Height == 0 || stackSize < 2 ||
+			i.top.stack[stackSize-1].height >= exportNode.Height ||
+			i.top.stack[stackSize-2].height >= exportNode.Height {
+			return errors.Errorf("unexpected node %X@%v in top of tree, expected segment root or parent",
+				exportNode.Key, exportNode.Version)
+		}
+		if err = i.top.Add(exportNode); err != nil {
+			return err
+		}
+	}
+	if next != len(i.segments) {
+		return errors.Errorf("only %v of %v segments were found in the top of the tree",
+			next, len(i.segments))
+	}
+
+	hash := sha256.New().Sum(nil)
+	if len(i.top.stack) == 1 {
+		hash = i.top.stack[0].hash
+	}
+	if !bytes.Equal(hash, rootHash) {
+		return errors.Errorf("imported root hash %X does not match expected root hash %X", hash, rootHash)
+	}
+
+	return i.top.Commit()
+}
+
+// Close frees all resources. It is safe to call multiple times. It waits for segments that are
+// still being imported, so their readers must return eventually. Uncommitted nodes may already
+// have been flushed to the database, but will not be visible.
+func (i *ParallelImporter) Close() {
+	i.wg.Wait()
+	if i.top != nil {
+		i.top.Close()
+	}
+}
+
+// matches returns true if the node was built from the given ExportNode.
+func (node *Node) matches(exportNode *ExportNode) bool {
+	return node.height == exportNode.Height &&
+		node.version == exportNode.Version &&
+		bytes.Equal(node.key, exportNode.Key) &&
+		bytes.Equal(node.value, exportNode.Value)
+}
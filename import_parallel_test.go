@@ -0,0 +1,184 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// importSegments exports the given tree as segments split at depth, and imports them in parallel
+// into a new tree.
+func importSegments(t *testing.T, tree *ImmutableTree, depth int, rootHash []byte) (*MutableTree, error) {
+	top, segments, err := tree.ExportSegments(depth)
+	require.NoError(t, err)
+	defer top.Close()
+
+	newTree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	importer, err := newTree.ImportParallel(tree.Version())
+	require.NoError(t, err)
+	defer importer.Close()
+
+	for _, segment := range segments {
+		defer segment.Close()
+		require.NoError(t, importer.AddSegment(segment))
+	}
+	return newTree, importer.Commit(top, rootHash)
+}
+
+func TestParallelImporter(t *testing.T) {
+	testcases := map[string]*ImmutableTree{
+		"empty tree": NewImmutableTree(db.NewMemDB(), 0),
+		"basic tree": setupExportTreeBasic(t),
+	}
+	if !testing.Short() {
+		testcases["sized tree"] = setupExportTreeSized(t, 4096)
+		testcases["random tree"] = setupExportTreeRandom(t)
+	}
+
+	for desc, tree := range testcases {
+		tree := tree
+		for _, depth := range []int{0, 1, 4, 64} {
+			depth := depth
+			t.Run(fmt.Sprintf("%v at depth %v", desc, depth), func(t *testing.T) {
+				newTree, err := importSegments(t, tree, depth, tree.Hash())
+				require.NoError(t, err)
+
+				require.Equal(t, tree.Hash(), newTree.Hash(), "Tree hash mismatch")
+				require.Equal(t, tree.Size(), newTree.Size(), "Tree size mismatch")
+				require.Equal(t, tree.Version(), newTree.Version(), "Tree version mismatch")
+
+				tree.Iterate(func(key, value []byte) bool {
+					index, _ := tree.Get(key)
+					newIndex, newValue := newTree.Get(key)
+					require.Equal(t, index, newIndex, "Index mismatch for key %v", key)
+					require.Equal(t, value, newValue, "Value mismatch for key %v", key)
+					return false
+				})
+			})
+		}
+	}
+}
+
+func TestParallelImporter_RootHashMismatch(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+
+	newTree, err := importSegments(t, tree, 2, []byte("invalid"))
+	require.Error(t, err)
+	require.EqualValues(t, 0, newTree.Version())
+	require.False(t, newTree.VersionExists(tree.Version()))
+}
+
+func TestParallelImporter_SegmentOrder(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+	top, segments, err := tree.ExportSegments(1)
+	require.NoError(t, err)
+	defer top.Close()
+	require.Len(t, segments, 2)
+
+	newTree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	importer, err := newTree.ImportParallel(tree.Version())
+	require.NoError(t, err)
+	defer importer.Close()
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		defer segments[i].Close()
+		require.NoError(t, importer.AddSegment(segments[i]))
+	}
+	require.Error(t, importer.Commit(top, tree.Hash()))
+	require.Equal(t, ErrNoImport, importer.Commit(top, tree.Hash()))
+}
+
+func TestParallelImporter_CloseWhileImporting(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+	top, segments, err := tree.ExportSegments(2)
+	require.NoError(t, err)
+	defer top.Close()
+
+	newTree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	importer, err := newTree.ImportParallel(tree.Version())
+	require.NoError(t, err)
+	for _, segment := range segments {
+		defer segment.Close()
+		require.NoError(t, importer.AddSegment(segment))
+	}
+	importer.Close()
+	require.Equal(t, ErrNoImport, importer.Commit(top, tree.Hash()))
+	require.False(t, newTree.VersionExists(tree.Version()))
+}
+
+func TestParallelImporter_Closed(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	importer, err := tree.ImportParallel(1)
+	require.NoError(t, err)
+
+	importer.Close()
+	exporter := setupExportTreeBasic(t).Export()
+	defer exporter.Close()
+	require.Equal(t, ErrNoImport, importer.AddSegment(exporter))
+	require.Equal(t, ErrNoImport, importer.Commit(exporter, nil))
+	importer.Close()
+}
+
+func TestExportSegments_NegativeDepth(t *testing.T) {
+	_, _, err := setupExportTreeBasic(t).ExportSegments(-1)
+	require.Error(t, err)
+}
+
+func BenchmarkImportParallel(b *testing.B) {
+	b.StopTimer()
+	tree := setupExportTreeSized(b, 4096)
+	readAll := func(exporter *Exporter) []*ExportNode {
+		defer exporter.Close()
+		exported := []*ExportNode{}
+		for {
+			item, err := exporter.Next()
+			if err == ExportDone {
+				return exported
+			}
+			require.NoError(b, err)
+			exported = append(exported, item)
+		}
+	}
+	topExporter, segmentExporters, err := tree.ExportSegments(3)
+	require.NoError(b, err)
+	top := readAll(topExporter)
+	segments := make([][]*ExportNode, 0, len(segmentExporters))
+	for _, exporter := range segmentExporters {
+		segments = append(segments, readAll(exporter))
+	}
+	b.StartTimer()
+
+	for n := 0; n < b.N; n++ {
+		newTree, err := NewMutableTree(db.NewMemDB(), 0)
+		require.NoError(b, err)
+		importer, err := newTree.ImportParallel(tree.Version())
+		require.NoError(b, err)
+		for _, segment := range segments {
+			err = importer.AddSegment(&sliceReader{nodes: segment})
+			require.NoError(b, err)
+		}
+		err = importer.Commit(&sliceReader{nodes: top}, tree.Hash())
+		require.NoError(b, err)
+	}
+}
+
+// sliceReader is an ExportNodeReader over a slice of ExportNodes.
+type sliceReader struct {
+	nodes []*ExportNode
+}
+
+func (r *sliceReader) Next() (*ExportNode, error) {
+	if len(r.nodes) == 0 {
+		return nil, ExportDone
+	}
+	node := r.nodes[0]
+	r.nodes = r.nodes[1:]
+	return node, nil
+}
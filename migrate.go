@@ -0,0 +1,502 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMigrationChunkSize is the default number of keys processed per chunk by Migrate().
+const DefaultMigrationChunkSize = 10000
+
+var (
+	// An interrupted migration is recorded in a checkpoint, along with the nodes of previous
+	// versions which it has orphaned so far and not yet written as orphan entries.
+	migrationKeyFormat       = NewKeyFormat('m')           // m
+	migrationOrphanKeyFormat = NewKeyFormat('M', hashSize) // M<hash>
+)
+
+// MigrationOptions configures a state migration, see MutableTree.Migrate().
+type MigrationOptions struct {
+	// Start and End are the key range [Start, End) to migrate. Nil or empty keys are unbounded.
+	Start []byte
+	End   []byte
+
+	// Delete returns true for keys to delete. Nil deletes no keys.
+	Delete func(key, value []byte) bool
+
+	// Transform returns the new value of a key which is not deleted. Returning an error stops the
+	// migration, which can then be resumed. Nil keeps all values.
+	Transform func(key, value []byte) ([]byte, error)
+
+	// ChunkSize is the number of keys processed per chunk. Zero uses DefaultMigrationChunkSize.
+	ChunkSize int
+
+	// OnProgress is called after each chunk.
+	OnProgress func(MigrationProgress)
+}
+
+// MigrationProgress describes the progress of a migration.
+type MigrationProgress struct {
+	Version     int64  // The version produced by the migration.
+	NextKey     []byte // The key the next chunk starts at.
+	Done        bool   // Whether the migration is complete, and the version saved.
+	Scanned     int64  // The number of keys scanned.
+	Deleted     int64  // The number of keys deleted.
+	Transformed int64  // The number of values changed.
+}
+
+// String implements fmt.Stringer.
+func (p MigrationProgress) String() string {
+	return fmt.Sprintf("version=%v next=%X done=%v scanned=%v deleted=%v transformed=%v",
+		p.Version, p.NextKey, p.Done, p.Scanned, p.Deleted, p.Transformed)
+}
+
+// migrationCheckpoint records the state of an interrupted migration.
+type migrationCheckpoint struct {
+	version int64  // the version being migrated
+	root    []byte // the root hash of the partially migrated tree, or nil if empty
+	start   []byte
+	end     []byte
+	next    []byte
+	saving  bool // whether all keys are migrated, and the orphans are being written
+	aborted bool // whether AbortMigration() has started deleting the migrated nodes
+	MigrationProgress
+}
+
+// Migrate migrates the keys in a range of the latest saved version, deleting keys and transforming
+// values as given by the options, and saves the result as a single new version like
+// SaveVersion(). The working tree must not have any unsaved changes.
+//
+// Keys are read from the latest saved version and processed in chunks of bounded size. After each
+// chunk, the partially migrated tree is persisted along with a checkpoint, such that memory use is
+// bounded by the chunk size rather than the number of changes. If the migration is interrupted,
+// e.g. by a crash or a Transform error, calling Migrate() again with the same range resumes it
+// from the last checkpoint. Delete and Transform must be deterministic for the result to be
+// identical to an uninterrupted migration. An interrupted migration can also be discarded with
+// AbortMigration(). No other versions can be saved while a migration is interrupted.
+//
+// The nodes of the previous version orphaned by the migration are persisted with each checkpoint
+// rather than held in memory. Once all keys are migrated, they are written as orphan entries of
+// the new version in bounded batches, and the new version is saved last.
+func (tree *MutableTree) Migrate(opts MigrationOptions) ([]byte, int64, error) {
+	if err := tree.checkPending(); err != nil {
+		return nil, 0, err
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = DefaultMigrationChunkSize
+	}
+	if opts.ChunkSize < 0 {
+		return nil, 0, errors.Errorf("migration chunk size cannot be negative, got %v", opts.ChunkSize)
+	}
+	if len(opts.End) == 0 {
+		opts.End = nil
+	}
+	if opts.End != nil && bytes.Compare(opts.Start, opts.End) >= 0 {
+		return nil, 0, errors.Errorf("migration start key %X must be before end key %X", opts.Start, opts.End)
+	}
+	if tree.version == 0 {
+		return nil, 0, errors.New("no saved version to migrate")
+	}
+	if tree.root != tree.lastSaved.root || len(tree.orphans) > 0 {
+		return nil, 0, errors.New("cannot migrate a tree with unsaved changes")
+	}
+
+	cp, err := tree.ndb.getMigrationCheckpoint()
+	if err != nil {
+		return nil, 0, err
+	}
+	if cp == nil {
+		cp = &migrationCheckpoint{version: tree.version, start: opts.Start, end: opts.End, next: opts.Start}
+	} else {
+		if cp.aborted {
+			return nil, 0, errors.New("found partially aborted migration, complete it with AbortMigration()")
+		}
+		if cp.version != tree.version {
+			return nil, 0, errors.Errorf("found interrupted migration of version %v, but latest version is %v",
+				cp.version, tree.version)
+		}
+		if !bytes.Equal(cp.start, opts.Start) || !bytes.Equal(cp.end, opts.End) {
+			return nil, 0, errors.Errorf("found interrupted migration of range %X-%X, got range %X-%X",
+				cp.start, cp.end, opts.Start, opts.End)
+		}
+		if len(cp.root) > 0 {
+			tree.root = tree.ndb.GetNode(cp.root)
+		} else {
+			tree.root = nil
+		}
+	}
+	cp.Version = tree.version + 1
+
+	for !cp.saving {
+		var keys, values [][]byte
+		tree.lastSaved.IterateRange(cp.next, opts.End, true, func(key, value []byte) bool {
+			keys = append(keys, key)
+			values = append(values, value)
+			return len(keys) == opts.ChunkSize
+		})
+		for i, key := range keys {
+			cp.Scanned++
+			if opts.Delete != nil && opts.Delete(key, values[i]) {
+				tree.Remove(key)
+				cp.Deleted++
+				continue
+			}
+			if opts.Transform == nil {
+				continue
+			}
+			value, err := opts.Transform(key, values[i])
+			if err == nil && value == nil {
+				err = errors.New("nil value")
+			}
+			if err != nil {
+				tree.Rollback()
+				return nil, 0, errors.Wrapf(err, "failed to transform key %X", key)
+			}
+			if !bytes.Equal(value, values[i]) {
+				tree.Set(key, value)
+				cp.Transformed++
+			}
+		}
+		if len(keys) < opts.ChunkSize {
+			cp.saving = true
+			cp.next = nil
+		} else {
+			cp.next = append(append([]byte{}, keys[len(keys)-1]...), 0)
+		}
+		cp.NextKey = cp.next
+		if err = tree.checkpointMigration(cp); err != nil {
+			tree.Rollback()
+			return nil, 0, err
+		}
+		if opts.OnProgress != nil && !cp.saving {
+			opts.OnProgress(cp.MigrationProgress)
+		}
+	}
+
+	// Write the orphan entries, and save the new version, deleting the checkpoint along with it.
+	if err = tree.ndb.saveMigrationOrphans(cp.version); err != nil {
+		tree.Rollback()
+		return nil, 0, err
+	}
+	if err = tree.ndb.deleteMigrationCheckpoint(); err != nil {
+		tree.Rollback()
+		return nil, 0, err
+	}
+	hash, version, err := tree.saveVersion()
+	if err != nil {
+		return nil, version, err
+	}
+	cp.NextKey = nil
+	cp.Done = true
+	if opts.OnProgress != nil {
+		opts.OnProgress(cp.MigrationProgress)
+	}
+	return hash, version, nil
+}
+
+// checkpointMigration persists the partially migrated working tree along with a checkpoint.
+func (tree *MutableTree) checkpointMigration(cp *migrationCheckpoint) error {
+	tree.collectMigrationOrphans()
+	cp.root = nil
+	if tree.root != nil {
+		tree.root.hashWithCount()
+		cp.root = tree.ndb.SaveBranch(tree.root)
+	}
+	if err := tree.ndb.saveMigrationCheckpoint(cp); err != nil {
+		return err
+	}
+	return tree.ndb.Commit()
+}
+
+// collectMigrationOrphans moves the orphans of the working tree into the migration orphans in the
+// batch. Orphaned nodes of the version being migrated were persisted by a previous checkpoint, but
+// are not part of any version, so they are deleted instead.
+func (tree *MutableTree) collectMigrationOrphans() {
+	ndb := tree.ndb
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+
+	version := tree.version + 1
+	pruner := ndb.newPagePruner()
+	for hash, fromVersion := range tree.orphans {
+		if fromVersion == version {
+			pruner.deleteNode(ndb, []byte(hash))
+			if err := ndb.deleteNodeKey(ndb.nodeKey([]byte(hash))); err != nil {
+				panic(err)
+			}
+			ndb.uncacheNode([]byte(hash))
+			continue
+		}
+		var buf bytes.Buffer
+		if err := encodeVarint(&buf, fromVersion); err != nil {
+			panic(err)
+		}
+		if err := ndb.batch.Set(migrationOrphanKeyFormat.KeyBytes([]byte(hash)), buf.Bytes()); err != nil {
+			panic(err)
+		}
+	}
+	pruner.relocate(ndb)
+	tree.orphans = map[string]int64{}
+}
+
+// AbortMigration discards an interrupted migration, see Migrate(), along with any unsaved changes.
+// The nodes persisted by the migration and the checkpoint are deleted in bounded batches. If the
+// abort is itself interrupted, the migration can no longer be resumed, and AbortMigration() must
+// be called again to complete it.
+func (tree *MutableTree) AbortMigration() error {
+	if err := tree.checkPending(); err != nil {
+		return err
+	}
+	cp, err := tree.ndb.getMigrationCheckpoint()
+	if err != nil {
+		return err
+	}
+	if cp == nil {
+		return errors.New("no interrupted migration found")
+	}
+	tree.Rollback()
+
+	ndb := tree.ndb
+	if !cp.aborted {
+		cp.aborted = true
+		if err = ndb.saveMigrationCheckpoint(cp); err != nil {
+			return err
+		}
+		if err = ndb.Commit(); err != nil {
+			return err
+		}
+	}
+
+	// Since no other versions can be saved while the migration is interrupted, the nodes of the
+	// migrated version are exactly the nodes persisted by the migration, even if older versions
+	// have been loaded for overwriting since.
+	if len(cp.root) > 0 {
+		if err = ndb.deleteMigratedNodes(cp.root, cp.version+1, new(int)); err != nil {
+			return err
+		}
+	}
+	if err = ndb.deleteMigrationOrphans(); err != nil {
+		return err
+	}
+	// While saving, no other versions can be saved either, so all orphan entries expiring at the
+	// migrated version were written by the migration. If the version has been overwritten since,
+	// they have been deleted along with it.
+	if cp.saving {
+		if err = ndb.deleteOrphanEntries(cp.version); err != nil {
+			return err
+		}
+	}
+	ndb.mtx.Lock()
+	err = ndb.batch.Delete(migrationKeyFormat.Key())
+	ndb.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	return ndb.Commit()
+}
+
+// deleteMigratedNodes deletes the nodes of the given version in the subtree rooted at hash,
+// committing the batch every maxBatchSize nodes. Children are deleted before their parents, so
+// missing nodes have already been deleted along with their subtrees by an interrupted call.
+//
+// The migrated nodes of a page all belong to the subtree of the page root, so pages are deleted
+// entirely and no nodes need to be relocated.
+func (ndb *nodeDB) deleteMigratedNodes(hash []byte, version int64, deleted *int) error {
+	ndb.mtx.Lock()
+	node, _, _, err := readNode(ndb.get, hash)
+	ndb.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	if node == nil || node.version != version {
+		return nil
+	}
+	if !node.isLeaf() {
+		if err = ndb.deleteMigratedNodes(node.leftHash, version, deleted); err != nil {
+			return err
+		}
+		if err = ndb.deleteMigratedNodes(node.rightHash, version, deleted); err != nil {
+			return err
+		}
+	}
+
+	ndb.mtx.Lock()
+	err = ndb.deleteNodeKey(ndb.nodeKey(hash))
+	ndb.uncacheNode(hash)
+	ndb.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	*deleted++
+	if *deleted%maxBatchSize == 0 {
+		return ndb.Commit()
+	}
+	return nil
+}
+
+// deleteMigrationOrphans deletes the migration orphans, committing the batch every maxBatchSize
+// orphans.
+func (ndb *nodeDB) deleteMigrationOrphans() error {
+	for {
+		// Collect the keys first, since the database may not be written while iterating.
+		var keys [][]byte
+		ndb.traversePrefix(migrationOrphanKeyFormat.Key(), func(key, value []byte) {
+			if len(keys) < maxBatchSize {
+				keys = append(keys, append([]byte{}, key...))
+			}
+		})
+		if len(keys) == 0 {
+			return nil
+		}
+		ndb.mtx.Lock()
+		for _, key := range keys {
+			if err := ndb.batch.Delete(key); err != nil {
+				ndb.mtx.Unlock()
+				return err
+			}
+		}
+		ndb.mtx.Unlock()
+		if err := ndb.Commit(); err != nil {
+			return err
+		}
+	}
+}
+
+// saveMigrationOrphans moves the migration orphans to orphan entries expiring at the given version,
+// committing the batch every maxBatchSize orphans.
+func (ndb *nodeDB) saveMigrationOrphans(toVersion int64) error {
+	start, end := migrationOrphanKeyFormat.Key(), []byte{migrationOrphanKeyFormat.prefix + 1}
+	for {
+		// Collect the orphans first, since the database may not be written while iterating.
+		var keys, fromVersions [][]byte
+		ndb.traverseRange(start, end, func(key, value []byte) {
+			if len(keys) < maxBatchSize {
+				keys = append(keys, append([]byte{}, key...))
+				fromVersions = append(fromVersions, append([]byte{}, value...))
+			}
+		})
+		if len(keys) == 0 {
+			return nil
+		}
+		ndb.mtx.Lock()
+		for i, key := range keys {
+			fromVersion, _, err := decodeVarint(fromVersions[i])
+			if err != nil {
+				ndb.mtx.Unlock()
+				return errors.Wrap(err, "failed to decode migration orphan")
+			}
+			ndb.saveOrphan(key[1:], fromVersion, toVersion)
+			if err = ndb.batch.Delete(key); err != nil {
+				ndb.mtx.Unlock()
+				return err
+			}
+		}
+		ndb.mtx.Unlock()
+		if err := ndb.Commit(); err != nil {
+			return err
+		}
+		start = append(keys[len(keys)-1], 0)
+	}
+}
+
+// getMigrationCheckpoint returns the checkpoint of an interrupted migration, if any.
+func (ndb *nodeDB) getMigrationCheckpoint() (*migrationCheckpoint, error) {
+	buf, err := ndb.db.Get(migrationKeyFormat.Key())
+	if err != nil || buf == nil {
+		return nil, err
+	}
+
+	cp := &migrationCheckpoint{}
+	var n int
+	decode := func(fields ...interface{}) {
+		for _, field := range fields {
+			if err != nil {
+				return
+			}
+			switch field := field.(type) {
+			case *int64:
+				*field, n, err = decodeVarint(buf)
+			case *[]byte:
+				*field, n, err = decodeBytes(buf)
+				if len(*field) == 0 {
+					*field = nil
+				}
+			}
+			buf = buf[n:]
+		}
+	}
+	var saving, aborted int64
+	decode(&cp.version, &cp.root, &cp.start, &cp.end, &cp.next, &cp.Scanned, &cp.Deleted, &cp.Transformed,
+		&aborted, &saving)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode migration checkpoint")
+	}
+	cp.saving = saving != 0
+	cp.aborted = aborted != 0
+	cp.NextKey = cp.next
+	return cp, nil
+}
+
+// saveMigrationCheckpoint saves a migration checkpoint to the batch.
+func (ndb *nodeDB) saveMigrationCheckpoint(cp *migrationCheckpoint) error {
+	var buf bytes.Buffer
+	if err := encodeVarint(&buf, cp.version); err != nil {
+		return err
+	}
+	for _, bz := range [][]byte{cp.root, cp.start, cp.end, cp.next} {
+		if err := encodeBytes(&buf, bz); err != nil {
+			return err
+		}
+	}
+	var saving, aborted int64
+	if cp.saving {
+		saving = 1
+	}
+	if cp.aborted {
+		aborted = 1
+	}
+	for _, v := range []int64{cp.Scanned, cp.Deleted, cp.Transformed, aborted, saving} {
+		if err := encodeVarint(&buf, v); err != nil {
+			return err
+		}
+	}
+
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+	return ndb.batch.Set(migrationKeyFormat.Key(), buf.Bytes())
+}
+
+// deleteMigrationCheckpoint deletes the migration checkpoint and orphans, if any, in the batch.
+func (ndb *nodeDB) deleteMigrationCheckpoint() (err error) {
+	// Collect the keys first, since the database may not be read while iterating.
+	var keys [][]byte
+	ndb.traversePrefix(migrationOrphanKeyFormat.Key(), func(key, value []byte) {
+		keys = append(keys, append([]byte{}, key...))
+	})
+	keys = append(keys, migrationKeyFormat.Key())
+
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+	for _, key := range keys {
+		if err = ndb.batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMigration returns an error if a migration was interrupted, since saving another version
+// would leak the nodes it has persisted.
+func (ndb *nodeDB) checkMigration() error {
+	cp, err := ndb.getMigrationCheckpoint()
+	if err != nil {
+		return err
+	}
+	if cp != nil {
+		return errors.Errorf("found interrupted migration of version %v, resume it with Migrate() "+
+			"or discard it with AbortMigration()", cp.version)
+	}
+	return nil
+}
@@ -0,0 +1,393 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// setupMigrationTree creates a tree with 2 versions of keys under the prefixes a/, b/ and c/.
+func setupMigrationTree(t *testing.T, memDB db.DB, opts *Options) *MutableTree {
+	tree, err := NewMutableTreeWithOpts(memDB, 0, opts)
+	require.NoError(t, err)
+	for v := 1; v <= 2; v++ {
+		for _, prefix := range []string{"a", "b", "c"} {
+			for i := 0; i < 300; i += v {
+				tree.Set([]byte(fmt.Sprintf("%v/%03d", prefix, i)), []byte(fmt.Sprintf("value-%v", v)))
+			}
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+// migrationOptions deletes the keys under b/ whose last digit is a multiple of 3, and upper-cases
+// the values of those ending in 2, 5 or 8.
+func migrationOptions() MigrationOptions {
+	return MigrationOptions{
+		Start:     []byte("b/"),
+		End:       []byte("c/"),
+		ChunkSize: 40,
+		Delete: func(key, value []byte) bool {
+			return key[len(key)-1]%3 == 0
+		},
+		Transform: func(key, value []byte) ([]byte, error) {
+			if key[len(key)-1]%3 == 1 {
+				return value, nil
+			}
+			return bytes.ToUpper(value), nil
+		},
+	}
+}
+
+// migrateManually applies the migration options to the tree with Set and Remove.
+func migrateManually(t *testing.T, tree *MutableTree, opts MigrationOptions) ([]byte, int64) {
+	var keys, values [][]byte
+	tree.IterateRange(opts.Start, opts.End, true, func(key, value []byte) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return false
+	})
+	for i, key := range keys {
+		if opts.Delete(key, values[i]) {
+			tree.Remove(key)
+			continue
+		}
+		value, err := opts.Transform(key, values[i])
+		require.NoError(t, err)
+		if !bytes.Equal(value, values[i]) {
+			tree.Set(key, value)
+		}
+	}
+	hash, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	return hash, version
+}
+
+func TestMigrate(t *testing.T) {
+	for _, levels := range []int{0, 3} {
+		levels := levels
+		t.Run(fmt.Sprintf("pages %v", levels), func(t *testing.T) {
+			opts := &Options{NodePageLevels: levels}
+			expected := setupMigrationTree(t, db.NewMemDB(), opts)
+			expectedHash, expectedVersion := migrateManually(t, expected, migrationOptions())
+
+			tree := setupMigrationTree(t, db.NewMemDB(), opts)
+			migration := migrationOptions()
+			var progress []MigrationProgress
+			migration.OnProgress = func(p MigrationProgress) {
+				progress = append(progress, p)
+			}
+			hash, version, err := tree.Migrate(migration)
+			require.NoError(t, err)
+			require.Equal(t, expectedHash, hash)
+			require.Equal(t, expectedVersion, version)
+
+			require.Len(t, progress, 8)
+			require.Equal(t, []byte("b/039\x00"), progress[0].NextKey)
+			last := progress[len(progress)-1]
+			require.True(t, last.Done)
+			require.EqualValues(t, 3, last.Version)
+			require.EqualValues(t, 300, last.Scanned)
+			require.EqualValues(t, 120, last.Deleted)
+			require.EqualValues(t, 90, last.Transformed)
+
+			// Nodes persisted by checkpoints and replaced later are deleted, and the orphans are
+			// saved as usual.
+			require.Equal(t, nodeHashes(expected.ndb), nodeHashes(tree.ndb))
+			require.NoError(t, expected.DeleteVersionsRange(1, 3))
+			require.NoError(t, tree.DeleteVersionsRange(1, 3))
+			require.Equal(t, nodeHashes(expected.ndb), nodeHashes(tree.ndb))
+			require.Empty(t, dumpDBPrefix(t, tree.ndb.db, 'm'))
+			require.Empty(t, dumpDBPrefix(t, tree.ndb.db, 'M'))
+		})
+	}
+}
+
+// dumpDBPrefix returns the database entries with the given key prefix.
+func dumpDBPrefix(t *testing.T, memDB db.DB, prefix byte) map[string]string {
+	entries := map[string]string{}
+	for key, value := range dumpDB(t, memDB) {
+		if key[0] == prefix {
+			entries[key] = value
+		}
+	}
+	return entries
+}
+
+func TestMigrate_Resume(t *testing.T) {
+	expected := setupMigrationTree(t, db.NewMemDB(), nil)
+	expectedHash, _ := migrateManually(t, expected, migrationOptions())
+
+	memDB := db.NewMemDB()
+	tree := setupMigrationTree(t, memDB, nil)
+	failing := migrationOptions()
+	transform := failing.Transform
+	failing.Transform = func(key, value []byte) ([]byte, error) {
+		if bytes.Equal(key, []byte("b/151")) {
+			return nil, errors.New("boom")
+		}
+		return transform(key, value)
+	}
+	_, _, err := tree.Migrate(failing)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "boom")
+	require.EqualValues(t, 2, tree.Version())
+	_, value := tree.Get([]byte("b/002"))
+	require.Equal(t, []byte("value-2"), value)
+
+	// The migration can only be resumed with the same range.
+	tree, err = NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	other := migrationOptions()
+	other.End = nil
+	_, _, err = tree.Migrate(other)
+	require.Error(t, err)
+
+	var progress []MigrationProgress
+	resumed := migrationOptions()
+	resumed.OnProgress = func(p MigrationProgress) {
+		progress = append(progress, p)
+	}
+	hash, version, err := tree.Migrate(resumed)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+	require.EqualValues(t, 3, version)
+	require.Equal(t, []byte("b/159\x00"), progress[0].NextKey)
+	require.EqualValues(t, 300, progress[len(progress)-1].Scanned)
+	require.Equal(t, nodeHashes(expected.ndb), nodeHashes(tree.ndb))
+	require.NoError(t, expected.DeleteVersion(2))
+	require.NoError(t, tree.DeleteVersion(2))
+	require.Equal(t, nodeHashes(expected.ndb), nodeHashes(tree.ndb))
+}
+
+func TestMigrate_Abort(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupMigrationTree(t, memDB, nil)
+	before := nodeHashes(tree.ndb)
+	require.Error(t, tree.AbortMigration())
+
+	failing := migrationOptions()
+	failing.Transform = func(key, value []byte) ([]byte, error) {
+		if bytes.Equal(key, []byte("b/202")) {
+			return nil, errors.New("boom")
+		}
+		return value, nil
+	}
+	_, _, err := tree.Migrate(failing)
+	require.Error(t, err)
+	require.NotEqual(t, before, nodeHashes(tree.ndb))
+
+	require.NoError(t, tree.AbortMigration())
+	require.Equal(t, before, nodeHashes(tree.ndb))
+	require.Empty(t, dumpDBPrefix(t, memDB, 'm'))
+	require.Empty(t, dumpDBPrefix(t, memDB, 'M'))
+
+	// A migration of another range can be started.
+	_, version, err := tree.Migrate(MigrationOptions{
+		Start:     []byte("c/"),
+		ChunkSize: 10,
+		Delete:    func(key, value []byte) bool { return true },
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, version)
+	require.EqualValues(t, 600, tree.Size())
+}
+
+// interruptMigration starts a migration of the tree which fails after a few chunks.
+func interruptMigration(t *testing.T, tree *MutableTree) {
+	failing := migrationOptions()
+	failing.Transform = func(key, value []byte) ([]byte, error) {
+		if bytes.Equal(key, []byte("b/202")) {
+			return nil, errors.New("boom")
+		}
+		return value, nil
+	}
+	_, _, err := tree.Migrate(failing)
+	require.Error(t, err)
+}
+
+func TestMigrate_InterruptedBlocksSave(t *testing.T) {
+	tree := setupMigrationTree(t, db.NewMemDB(), nil)
+	interruptMigration(t, tree)
+
+	tree.Set([]byte("new"), []byte{1})
+	_, _, err := tree.SaveVersion()
+	require.Error(t, err)
+	batch := tree.ndb.db.NewBatch()
+	defer batch.Close()
+	_, _, err = tree.SaveVersionInto(batch)
+	require.Error(t, err)
+	_, err = tree.Rebuild()
+	require.Error(t, err)
+
+	require.NoError(t, tree.AbortMigration())
+	tree.Set([]byte("new"), []byte{1})
+	_, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, version)
+}
+
+func TestMigrate_AbortAfterOverwriting(t *testing.T) {
+	tree := setupMigrationTree(t, db.NewMemDB(), nil)
+	interruptMigration(t, tree)
+
+	// Loading an older version for overwriting does not prevent the migrated nodes from being
+	// deleted.
+	_, err := tree.LoadVersionForOverwriting(1)
+	require.NoError(t, err)
+	before := nodeHashes(tree.ndb)
+	require.NoError(t, tree.AbortMigration())
+	require.Less(t, len(nodeHashes(tree.ndb)), len(before))
+	_, err = tree.LoadVersionForOverwriting(1)
+	require.NoError(t, err)
+	for _, node := range tree.ndb.nodes() {
+		require.EqualValues(t, 1, node.version)
+	}
+}
+
+func TestMigrate_AbortInterrupted(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupMigrationTree(t, memDB, nil)
+	before := nodeHashes(tree.ndb)
+	interruptMigration(t, tree)
+
+	// Simulate an abort interrupted after deleting part of the migrated nodes.
+	cp, err := tree.ndb.getMigrationCheckpoint()
+	require.NoError(t, err)
+	cp.aborted = true
+	require.NoError(t, tree.ndb.saveMigrationCheckpoint(cp))
+	root := tree.ndb.GetNode(cp.root)
+	require.NoError(t, tree.ndb.deleteMigratedNodes(root.leftHash, cp.version+1, new(int)))
+	require.NoError(t, tree.ndb.Commit())
+
+	tree, err = NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	_, _, err = tree.Migrate(migrationOptions())
+	require.Error(t, err)
+
+	require.NoError(t, tree.AbortMigration())
+	require.Equal(t, before, nodeHashes(tree.ndb))
+	require.Empty(t, dumpDBPrefix(t, memDB, 'm'))
+	require.Empty(t, dumpDBPrefix(t, memDB, 'M'))
+}
+
+func TestMigrate_Errors(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	_, _, err = tree.Migrate(MigrationOptions{})
+	require.Error(t, err)
+
+	tree = setupMigrationTree(t, db.NewMemDB(), nil)
+	_, _, err = tree.Migrate(MigrationOptions{ChunkSize: -1})
+	require.Error(t, err)
+	_, _, err = tree.Migrate(MigrationOptions{Start: []byte("b"), End: []byte("a")})
+	require.Error(t, err)
+	_, _, err = tree.Migrate(MigrationOptions{Transform: func(key, value []byte) ([]byte, error) {
+		return nil, nil
+	}})
+	require.Error(t, err)
+
+	tree.Set([]byte("foo"), []byte("bar"))
+	_, _, err = tree.Migrate(MigrationOptions{})
+	require.Error(t, err)
+}
+
+// failRootDB is a database whose batches fail to write any root entries, interrupting saves.
+type failRootDB struct {
+	db.DB
+}
+
+func (d failRootDB) NewBatch() db.Batch {
+	return &failRootBatch{Batch: d.DB.NewBatch()}
+}
+
+type failRootBatch struct {
+	db.Batch
+	root bool
+}
+
+func (b *failRootBatch) Set(key, value []byte) error {
+	b.root = b.root || key[0] == rootKeyFormat.prefix
+	return b.Batch.Set(key, value)
+}
+
+func (b *failRootBatch) Write() error {
+	if b.root {
+		return errors.New("disk full")
+	}
+	return b.Batch.Write()
+}
+
+func (b *failRootBatch) WriteSync() error {
+	if b.root {
+		return errors.New("disk full")
+	}
+	return b.Batch.WriteSync()
+}
+
+// interruptMigrationSave runs a migration of the tree in the database which fails to save the new
+// version, after writing the orphan entries.
+func interruptMigrationSave(t *testing.T, memDB db.DB) {
+	tree, err := NewMutableTree(failRootDB{memDB}, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	_, _, err = tree.Migrate(migrationOptions())
+	require.Error(t, err)
+	require.Empty(t, dumpDBPrefix(t, memDB, 'M'))
+	cp, err := tree.ndb.getMigrationCheckpoint()
+	require.NoError(t, err)
+	require.True(t, cp.saving)
+}
+
+func TestMigrate_ResumeSaving(t *testing.T) {
+	expected := setupMigrationTree(t, db.NewMemDB(), nil)
+	expectedHash, _ := migrateManually(t, expected, migrationOptions())
+
+	memDB := db.NewMemDB()
+	setupMigrationTree(t, memDB, nil)
+	interruptMigrationSave(t, memDB)
+
+	// Resuming the migration saves the version with the orphan entries already written.
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	hash, version, err := tree.Migrate(migrationOptions())
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+	require.EqualValues(t, 3, version)
+	require.Equal(t, dumpDBPrefix(t, expected.ndb.db, 'o'), dumpDBPrefix(t, memDB, 'o'))
+	require.NoError(t, expected.DeleteVersion(2))
+	require.NoError(t, tree.DeleteVersion(2))
+	require.Equal(t, nodeHashes(expected.ndb), nodeHashes(tree.ndb))
+	require.Empty(t, dumpDBPrefix(t, memDB, 'm'))
+}
+
+func TestMigrate_AbortSaving(t *testing.T) {
+	memDB := db.NewMemDB()
+	setupMigrationTree(t, memDB, nil)
+	before := dumpDB(t, memDB)
+	orphans := dumpDBPrefix(t, memDB, 'o')
+	interruptMigrationSave(t, memDB)
+	require.Greater(t, len(dumpDBPrefix(t, memDB, 'o')), len(orphans))
+
+	// Aborting deletes the orphan entries written by the migration, along with its nodes.
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	require.NoError(t, tree.AbortMigration())
+	require.Equal(t, before, dumpDB(t, memDB))
+}
@@ -15,6 +15,9 @@ import (
 // ErrVersionDoesNotExist is returned if a requested version does not exist.
 var ErrVersionDoesNotExist = errors.New("version does not exist")
 
+// movePrefixChunkSize is the number of keys read and moved at a time by MovePrefix().
+const movePrefixChunkSize = 1000
+
 // MutableTree is a persistent tree which keeps track of versions. It is not safe for concurrent
 // use, and should be guarded by a Mutex or RWLock as appropriate. An immutable tree at a given
 // version can be returned via GetImmutable, which is safe for concurrent access.
@@ -27,9 +30,10 @@ type MutableTree struct {
 	*ImmutableTree                  // The current, working tree.
 	lastSaved      *ImmutableTree   // The most recently saved tree.
 	orphans        map[string]int64 // Nodes removed by changes to working tree.
-	versions       map[int64]bool   // The previous, saved versions of the tree.
-	allRootLoaded  bool             // Whether all roots are loaded or not(by LazyLoadVersion)
+	versions       map[int64]bool   // The previous, saved versions of the tree known so far.
+	allRootLoaded  bool             // Whether versions contains all saved versions, see loadVersions().
 	ndb            *nodeDB
+	pending        *pendingVersion // The version saved by SaveVersionInto(), until finalized.
 
 	mtx sync.RWMutex // versions Read/write lock.
 }
@@ -41,6 +45,11 @@ func NewMutableTree(db dbm.DB, cacheSize int) (*MutableTree, error) {
 
 // NewMutableTreeWithOpts returns a new tree with the specified options.
 func NewMutableTreeWithOpts(db dbm.DB, cacheSize int, opts *Options) (*MutableTree, error) {
+	if opts != nil {
+		if err := validateColdOptions(opts.Cold); err != nil {
+			return nil, err
+		}
+	}
 	ndb := newNodeDB(db, cacheSize, opts)
 	head := &ImmutableTree{ndb: ndb}
 
@@ -80,9 +89,10 @@ func (tree *MutableTree) VersionExists(version int64) bool {
 
 // AvailableVersions returns all available versions in ascending order
 func (tree *MutableTree) AvailableVersions() []int {
-	tree.mtx.RLock()
-	defer tree.mtx.RUnlock()
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
 
+	tree.loadVersions()
 	res := make([]int, 0, len(tree.versions))
 	for i, v := range tree.versions {
 		if v {
@@ -93,6 +103,23 @@ func (tree *MutableTree) AvailableVersions() []int {
 	return res
 }
 
+// loadVersions discovers all saved versions from the database, unless they are all known already.
+// After LazyLoadVersion() only the loaded version is known, and other versions are looked up on
+// demand. The caller must hold tree.mtx.
+func (tree *MutableTree) loadVersions() {
+	if tree.allRootLoaded {
+		return
+	}
+	versions := map[int64]bool{}
+	tree.ndb.traversePrefix(rootKeyFormat.Key(), func(k, v []byte) {
+		var version int64
+		rootKeyFormat.Scan(k, &version)
+		versions[version] = true
+	})
+	tree.versions = versions
+	tree.allRootLoaded = true
+}
+
 // Hash returns the hash of the latest saved version of the tree, as returned
 // by SaveVersion. If no versions have been saved, Hash returns nil.
 func (tree *MutableTree) Hash() []byte {
@@ -118,8 +145,12 @@ func (tree *MutableTree) prepareOrphansSlice() []*Node {
 // Set sets a key in the working tree. Nil values are invalid. The given
 // key/value byte slices must not be modified after this call, since they point
 // to slices stored within IAVL. It returns true when an existing value was
-// updated, while false means it was a new key.
+// updated, while false means it was a new key. It panics while a version saved
+// by SaveVersionInto() is pending.
 func (tree *MutableTree) Set(key, value []byte) (updated bool) {
+	if err := tree.checkPending(); err != nil {
+		panic(err)
+	}
 	var orphaned []*Node
 	orphaned, updated = tree.set(key, value)
 	tree.addOrphans(orphaned)
@@ -135,6 +166,9 @@ func (tree *MutableTree) Set(key, value []byte) (updated bool) {
 // Import can only be called on an empty tree. It is the callers responsibility that no other
 // modifications are made to the tree while importing.
 func (tree *MutableTree) Import(version int64) (*Importer, error) {
+	if err := tree.checkPending(); err != nil {
+		return nil, err
+	}
 	return newImporter(tree, version)
 }
 
@@ -204,13 +238,92 @@ func (tree *MutableTree) recursiveSet(node *Node, key []byte, value []byte, orph
 }
 
 // Remove removes a key from the working tree. The given key byte slice should not be modified
-// after this call, since it may point to data stored inside IAVL.
+// after this call, since it may point to data stored inside IAVL. It panics while a version saved
+// by SaveVersionInto() is pending.
 func (tree *MutableTree) Remove(key []byte) ([]byte, bool) {
+	if err := tree.checkPending(); err != nil {
+		panic(err)
+	}
 	val, orphaned, removed := tree.remove(key)
 	tree.addOrphans(orphaned)
 	return val, removed
 }
 
+// MovePrefix moves all keys with the prefix from to the prefix to in the working tree, replacing
+// the prefix but keeping the rest of the key and the value. It returns the number of keys moved.
+//
+// Keys are read and moved in bounded chunks, in ascending key order, such that the result is
+// identical to calling Set() with the new key and Remove() with the old key for each key in turn.
+// If any moved key would overwrite an existing key under the target prefix, an error is returned
+// before the tree is modified. The prefixes cannot overlap, i.e. neither can be a prefix of the
+// other, and from cannot be empty.
+//
+// If to is empty, keys are moved to the root of the keyspace by stripping the prefix. Since the
+// target keys then overlap the source range, an error is also returned if any target key would
+// be empty or would itself start with from.
+func (tree *MutableTree) MovePrefix(from, to []byte) (int64, error) {
+	if err := tree.checkPending(); err != nil {
+		return 0, err
+	}
+	if len(from) == 0 {
+		return 0, errors.New("source prefix cannot be empty")
+	}
+	if len(to) > 0 && (bytes.HasPrefix(from, to) || bytes.HasPrefix(to, from)) {
+		return 0, errors.Errorf("prefixes %X and %X cannot overlap", from, to)
+	}
+	end := prefixEnd(from)
+	targetKey := func(key []byte) []byte {
+		target := make([]byte, 0, len(to)+len(key)-len(from))
+		return append(append(target, to...), key[len(from):]...)
+	}
+
+	// Check for collisions first, such that the tree is left untouched on errors.
+	start := from
+	for start != nil {
+		keys, _ := tree.prefixChunk(start, end)
+		start = nil
+		for _, key := range keys {
+			target := targetKey(key)
+			if len(target) == 0 || bytes.HasPrefix(target, from) {
+				return 0, errors.Errorf("cannot move key %X, target key %X is empty or has the source prefix", key, target)
+			}
+			if tree.Has(target) {
+				return 0, errors.Errorf("cannot move key %X, target key %X already exists", key, target)
+			}
+		}
+		if len(keys) == movePrefixChunkSize {
+			start = append(keys[len(keys)-1], 0x00)
+		}
+	}
+
+	// Moved keys are removed from the source range, so each chunk starts at the beginning of it.
+	var moved int64
+	for {
+		keys, values := tree.prefixChunk(from, end)
+		for i, key := range keys {
+			tree.Set(targetKey(key), values[i])
+			tree.Remove(key)
+		}
+		moved += int64(len(keys))
+		if len(keys) < movePrefixChunkSize {
+			return moved, nil
+		}
+	}
+}
+
+// prefixChunk returns up to movePrefixChunkSize keys and values in the range [start, end) of the
+// working tree.
+func (tree *MutableTree) prefixChunk(start, end []byte) (keys [][]byte, values [][]byte) {
+	keys = make([][]byte, 0, movePrefixChunkSize)
+	values = make([][]byte, 0, movePrefixChunkSize)
+	tree.IterateRange(start, end, true, func(key, value []byte) bool {
+		keys = append(keys, cp(key))
+		values = append(values, value)
+		return len(keys) == movePrefixChunkSize
+	})
+	return keys, values
+}
+
 // remove tries to remove a key from the tree and if removed, returns its
 // value, nodes orphaned and 'true'.
 func (tree *MutableTree) remove(key []byte) (value []byte, orphaned []*Node, removed bool) {
@@ -294,13 +407,21 @@ func (tree *MutableTree) Load() (int64, error) {
 }
 
 // LazyLoadVersion attempts to lazy load only the specified target version
-// without loading previous roots/versions. Lazy loading should be used in cases
-// where only reads are expected. Any writes to a lazy loaded tree may result in
-// unexpected behavior. If the targetVersion is non-positive, the latest version
-// will be loaded by default. If the latest version is non-positive, this method
-// performs a no-op. Otherwise, if the root does not exist, an error will be
-// returned.
+// without loading previous roots/versions. Other versions are discovered from
+// the database on demand, so a lazy loaded tree can be written to just like a
+// tree loaded with LoadVersion. If the targetVersion is non-positive, the latest
+// version will be loaded by default. If the latest version is non-positive, this
+// method performs a no-op. Otherwise, if the root does not exist, an error will
+// be returned.
 func (tree *MutableTree) LazyLoadVersion(targetVersion int64) (int64, error) {
+	if err := tree.checkPending(); err != nil {
+		return 0, err
+	}
+	span := tree.ndb.startSpan(SpanLoadVersion)
+	defer span.End()
+	span.SetAttribute("target_version", targetVersion)
+	span.SetAttribute("lazy", true)
+
 	latestVersion := tree.ndb.getLatestVersion()
 	if latestVersion < targetVersion {
 		return latestVersion, fmt.Errorf("wanted to load target %d but only found up to %d", targetVersion, latestVersion)
@@ -327,6 +448,13 @@ func (tree *MutableTree) LazyLoadVersion(targetVersion int64) (int64, error) {
 		return latestVersion, ErrVersionDoesNotExist
 	}
 
+	if initialVersion := int64(tree.ndb.opts.InitialVersion); initialVersion > 0 {
+		if firstVersion := tree.ndb.getFirstVersion(); firstVersion < initialVersion {
+			return latestVersion, fmt.Errorf("initial version set to %v, but found earlier version %v",
+				initialVersion, firstVersion)
+		}
+	}
+
 	tree.mtx.Lock()
 	defer tree.mtx.Unlock()
 	tree.versions[targetVersion] = true
@@ -350,6 +478,13 @@ func (tree *MutableTree) LazyLoadVersion(targetVersion int64) (int64, error) {
 
 // Returns the version number of the latest version found
 func (tree *MutableTree) LoadVersion(targetVersion int64) (int64, error) {
+	if err := tree.checkPending(); err != nil {
+		return 0, err
+	}
+	span := tree.ndb.startSpan(SpanLoadVersion)
+	defer span.End()
+	span.SetAttribute("target_version", targetVersion)
+
 	roots, err := tree.ndb.getRoots()
 	if err != nil {
 		return 0, err
@@ -369,6 +504,7 @@ func (tree *MutableTree) LoadVersion(targetVersion int64) (int64, error) {
 	defer tree.mtx.Unlock()
 
 	var latestRoot []byte
+	tree.versions = map[int64]bool{}
 	for version, r := range roots {
 		tree.versions[version] = true
 		if version > latestVersion && (targetVersion == 0 || version <= targetVersion) {
@@ -404,17 +540,27 @@ func (tree *MutableTree) LoadVersion(targetVersion int64) (int64, error) {
 	tree.lastSaved = t.clone()
 	tree.allRootLoaded = true
 
+	span.SetAttribute("version", latestVersion)
+	span.SetAttribute("versions", len(roots))
 	return latestVersion, nil
 }
 
 // LoadVersionForOverwriting attempts to load a tree at a previously committed
 // version, or the latest version below it. Any versions greater than targetVersion will be deleted.
+// Use DryRunLoadVersionForOverwriting() to see what would be deleted, or
+// SoftLoadVersionForOverwriting() to keep the deleted versions recoverable.
 func (tree *MutableTree) LoadVersionForOverwriting(targetVersion int64) (int64, error) {
+	if err := tree.checkPending(); err != nil {
+		return 0, err
+	}
 	latestVersion, err := tree.LoadVersion(targetVersion)
 	if err != nil {
 		return latestVersion, err
 	}
 
+	if err = tree.ndb.discardRebuild(); err != nil {
+		return latestVersion, err
+	}
 	if err = tree.ndb.DeleteVersionsFrom(targetVersion + 1); err != nil {
 		return latestVersion, err
 	}
@@ -466,8 +612,14 @@ func (tree *MutableTree) GetImmutable(version int64) (*ImmutableTree, error) {
 }
 
 // Rollback resets the working tree to the latest saved version, discarding
-// any unsaved modifications.
+// any unsaved modifications. It panics while a version saved by SaveVersionInto()
+// is pending, see DiscardVersion() instead.
 func (tree *MutableTree) Rollback() {
+	if err := tree.checkPending(); err != nil {
+		panic(err)
+	}
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
 	if tree.version > 0 {
 		tree.ImmutableTree = tree.lastSaved.clone()
 	} else {
@@ -494,10 +646,25 @@ func (tree *MutableTree) GetVersioned(key []byte, version int64) (
 // SaveVersion saves a new tree version to disk, based on the current state of
 // the tree. Returns the hash and new version number.
 func (tree *MutableTree) SaveVersion() ([]byte, int64, error) {
-	version := tree.version + 1
-	if version == 1 && tree.ndb.opts.InitialVersion > 0 {
-		version = int64(tree.ndb.opts.InitialVersion)
+	if err := tree.checkPending(); err != nil {
+		return nil, 0, err
+	}
+	if err := tree.ndb.checkRebuild(); err != nil {
+		return nil, 0, err
+	}
+	if err := tree.ndb.checkMigration(); err != nil {
+		return nil, 0, err
 	}
+	return tree.saveVersion()
+}
+
+// saveVersion saves a new tree version, see SaveVersion(), without checking for interrupted
+// operations.
+func (tree *MutableTree) saveVersion() ([]byte, int64, error) {
+	version := tree.nextVersion()
+	span := tree.ndb.startSpan(SpanSaveVersion)
+	defer span.End()
+	span.SetAttribute("version", version)
 
 	if tree.VersionExists(version) {
 		// If the version already exists, return an error as we're attempting to overwrite.
@@ -516,37 +683,77 @@ func (tree *MutableTree) SaveVersion() ([]byte, int64, error) {
 		var newHash = tree.WorkingHash()
 
 		if bytes.Equal(existingHash, newHash) {
-			tree.version = version
-			tree.ImmutableTree = tree.ImmutableTree.clone()
-			tree.lastSaved = tree.ImmutableTree.clone()
-			tree.orphans = map[string]int64{}
+			tree.finishVersion(version)
 			return existingHash, version, nil
 		}
 
 		return nil, version, fmt.Errorf("version %d was already saved to different hash %X (existing hash %X)", version, newHash, existingHash)
 	}
 
+	rootHash, err := tree.writeVersion(version)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	commitSpan := tree.ndb.startSpan(SpanSaveVersionCommit)
+	err = tree.ndb.Commit()
+	commitSpan.End()
+	if err != nil {
+		return nil, version, err
+	}
+	tree.ndb.compareShadowRoot(version, rootHash)
+	tree.finishVersion(version)
+
+	return tree.Hash(), version, nil
+}
+
+// nextVersion returns the version number of the next saved version.
+func (tree *MutableTree) nextVersion() int64 {
+	version := tree.version + 1
+	if version == 1 && tree.ndb.opts.InitialVersion > 0 {
+		version = int64(tree.ndb.opts.InitialVersion)
+	}
+	return version
+}
+
+// writeVersion writes the nodes, orphans and root of the working tree as the given version to the
+// node database batch, returning the root hash, if any.
+func (tree *MutableTree) writeVersion(version int64) ([]byte, error) {
+	var rootHash []byte
 	if tree.root == nil {
 		// There can still be orphans, for example if the root is the node being
 		// removed.
 		debug("SAVE EMPTY TREE %v\n", version)
-		tree.ndb.SaveOrphans(version, tree.orphans)
+		tree.saveOrphans(version)
 		if err := tree.ndb.SaveEmptyRoot(version); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 	} else {
 		debug("SAVE TREE %v\n", version)
+		hashSpan := tree.ndb.startSpan(SpanSaveVersionHash)
+		_, hashCount := tree.root.hashWithCount()
+		hashSpan.SetAttribute("nodes", hashCount)
+		hashSpan.End()
+
+		branchSpan := tree.ndb.startSpan(SpanSaveVersionSaveBranch)
+		nodes, size, _ := tree.ndb.counters()
 		tree.ndb.SaveBranch(tree.root)
-		tree.ndb.SaveOrphans(version, tree.orphans)
+		savedNodes, savedSize, _ := tree.ndb.counters()
+		branchSpan.SetAttribute("nodes", savedNodes-nodes)
+		branchSpan.SetAttribute("bytes", savedSize-size)
+		branchSpan.End()
+
+		tree.saveOrphans(version)
 		if err := tree.ndb.SaveRoot(tree.root, version); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
+		rootHash = tree.root.hash
 	}
+	return rootHash, nil
+}
 
-	if err := tree.ndb.Commit(); err != nil {
-		return nil, version, err
-	}
-
+// finishVersion makes the working tree the latest saved version, once it has been written.
+func (tree *MutableTree) finishVersion(version int64) {
 	tree.mtx.Lock()
 	defer tree.mtx.Unlock()
 	tree.version = version
@@ -556,15 +763,21 @@ func (tree *MutableTree) SaveVersion() ([]byte, int64, error) {
 	tree.ImmutableTree = tree.ImmutableTree.clone()
 	tree.lastSaved = tree.ImmutableTree.clone()
 	tree.orphans = map[string]int64{}
+}
 
-	return tree.Hash(), version, nil
+// saveOrphans saves the orphans of the working tree for the given version.
+func (tree *MutableTree) saveOrphans(version int64) {
+	span := tree.ndb.startSpan(SpanSaveVersionSaveOrphans)
+	defer span.End()
+	span.SetAttribute("orphans", len(tree.orphans))
+	tree.ndb.SaveOrphans(version, tree.orphans)
 }
 
 func (tree *MutableTree) deleteVersion(version int64) error {
 	if version <= 0 {
 		return errors.New("version must be greater than 0")
 	}
-	if version == tree.version {
+	if version == tree.version || version == tree.ndb.getLatestVersion() {
 		return errors.Errorf("cannot delete latest saved version (%d)", version)
 	}
 	if !tree.VersionExists(version) {
@@ -588,6 +801,9 @@ func (tree *MutableTree) SetInitialVersion(version uint64) {
 // Deprecated: please use DeleteVersionsRange instead.
 func (tree *MutableTree) DeleteVersions(versions ...int64) error {
 	debug("DELETING VERSIONS: %v\n", versions)
+	if err := tree.checkPending(); err != nil {
+		return err
+	}
 
 	if len(versions) == 0 {
 		return nil
@@ -620,6 +836,19 @@ func (tree *MutableTree) DeleteVersions(versions ...int64) error {
 // An error is returned if any single version has active readers.
 // All writes happen in a single batch with a single commit.
 func (tree *MutableTree) DeleteVersionsRange(fromVersion, toVersion int64) error {
+	if err := tree.checkPending(); err != nil {
+		return err
+	}
+	span := tree.ndb.startSpan(SpanDeleteVersionsRange)
+	defer span.End()
+	span.SetAttribute("from_version", fromVersion)
+	span.SetAttribute("to_version", toVersion)
+	_, _, deleted := tree.ndb.counters()
+	defer func() {
+		_, _, nowDeleted := tree.ndb.counters()
+		span.SetAttribute("nodes", nowDeleted-deleted)
+	}()
+
 	if err := tree.ndb.DeleteVersionsRange(fromVersion, toVersion); err != nil {
 		return err
 	}
@@ -641,6 +870,17 @@ func (tree *MutableTree) DeleteVersionsRange(fromVersion, toVersion int64) error
 // longer be accessed.
 func (tree *MutableTree) DeleteVersion(version int64) error {
 	debug("DELETE VERSION: %d\n", version)
+	if err := tree.checkPending(); err != nil {
+		return err
+	}
+	span := tree.ndb.startSpan(SpanDeleteVersion)
+	defer span.End()
+	span.SetAttribute("version", version)
+	_, _, deleted := tree.ndb.counters()
+	defer func() {
+		_, _, nowDeleted := tree.ndb.counters()
+		span.SetAttribute("nodes", nowDeleted-deleted)
+	}()
 
 	if err := tree.deleteVersion(version); err != nil {
 		return err
@@ -107,38 +107,56 @@ func TestMutableTree_DeleteVersions(t *testing.T) {
 	}
 }
 
-func TestMutableTree_LoadVersion_Empty(t *testing.T) {
-	memDB := db.NewMemDB()
-	tree, err := NewMutableTree(memDB, 0)
-	require.NoError(t, err)
-
-	version, err := tree.LoadVersion(0)
-	require.NoError(t, err)
-	assert.EqualValues(t, 0, version)
-
-	version, err = tree.LoadVersion(-1)
-	require.NoError(t, err)
-	assert.EqualValues(t, 0, version)
-
-	_, err = tree.LoadVersion(3)
-	require.Error(t, err)
+// versionLoaders are the ways to load a tree version, which must behave the same.
+var versionLoaders = map[string]func(tree *MutableTree, version int64) (int64, error){
+	"LoadVersion":     (*MutableTree).LoadVersion,
+	"LazyLoadVersion": (*MutableTree).LazyLoadVersion,
 }
 
-func TestMutableTree_LazyLoadVersion_Empty(t *testing.T) {
-	memDB := db.NewMemDB()
-	tree, err := NewMutableTree(memDB, 0)
-	require.NoError(t, err)
+func TestMutableTree_LoadVersion_Empty(t *testing.T) {
+	for name, load := range versionLoaders {
+		load := load
+		t.Run(name, func(t *testing.T) {
+			memDB := db.NewMemDB()
+			tree, err := NewMutableTree(memDB, 0)
+			require.NoError(t, err)
+
+			version, err := load(tree, 0)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, version)
+
+			version, err = load(tree, -1)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, version)
+
+			_, err = load(tree, 3)
+			require.Error(t, err)
+		})
+	}
+}
 
-	version, err := tree.LazyLoadVersion(0)
+func TestMutableTree_LoadVersion_EmptyTree(t *testing.T) {
+	mdb := db.NewMemDB()
+	tree, err := NewMutableTree(mdb, 1000)
 	require.NoError(t, err)
-	assert.EqualValues(t, 0, version)
-
-	version, err = tree.LazyLoadVersion(-1)
+	_, v1, err := tree.SaveVersion()
 	require.NoError(t, err)
-	assert.EqualValues(t, 0, version)
 
-	_, err = tree.LazyLoadVersion(3)
-	require.Error(t, err)
+	roots := map[string]*Node{}
+	for name, load := range versionLoaders {
+		load := load
+		t.Run(name, func(t *testing.T) {
+			newTree, err := NewMutableTree(mdb, 1000)
+			require.NoError(t, err)
+			v2, err := load(newTree, 1)
+			require.NoError(t, err)
+			require.Equal(t, v1, v2)
+			require.True(t, newTree.IsEmpty())
+			roots[name] = newTree.root
+		})
+	}
+	require.Len(t, roots, 2)
+	require.True(t, roots["LoadVersion"] == roots["LazyLoadVersion"])
 }
 
 func TestMutableTree_DeleteVersionsRange(t *testing.T) {
@@ -359,24 +377,216 @@ func TestMutableTree_DeleteVersion(t *testing.T) {
 	require.Error(t, tree.DeleteVersion(2))
 }
 
-func TestMutableTree_LazyLoadVersionWithEmptyTree(t *testing.T) {
-	mdb := db.NewMemDB()
-	tree, err := NewMutableTree(mdb, 1000)
+func TestMutableTree_LoadVersion_Writes(t *testing.T) {
+	dumps := map[string]map[string]string{}
+	for name, load := range versionLoaders {
+		load := load
+		t.Run(name, func(t *testing.T) {
+			memDB := db.NewMemDB()
+			tree, err := NewMutableTree(memDB, 0)
+			require.NoError(t, err)
+			for v := 1; v <= 10; v++ {
+				tree.Set([]byte(fmt.Sprintf("key%v", v)), []byte(fmt.Sprintf("value%v", v)))
+				_, _, err = tree.SaveVersion()
+				require.NoError(t, err)
+			}
+
+			tree, err = NewMutableTree(memDB, 0)
+			require.NoError(t, err)
+			version, err := load(tree, 0)
+			require.NoError(t, err)
+			require.EqualValues(t, 10, version)
+			require.True(t, tree.VersionExists(5))
+			require.False(t, tree.VersionExists(11))
+			require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, tree.AvailableVersions())
+
+			require.Error(t, tree.DeleteVersion(10))
+			require.Error(t, tree.DeleteVersion(11))
+			require.NoError(t, tree.DeleteVersion(3))
+			require.Error(t, tree.DeleteVersion(3))
+			require.NoError(t, tree.DeleteVersionsRange(5, 8))
+			require.False(t, tree.VersionExists(6))
+			require.Equal(t, []int{1, 2, 4, 8, 9, 10}, tree.AvailableVersions())
+
+			tree.Set([]byte("key11"), []byte("value11"))
+			_, version, err = tree.SaveVersion()
+			require.NoError(t, err)
+			require.EqualValues(t, 11, version)
+
+			// Loading an older version does not allow deleting the latest version, nor saving a
+			// different version in place of an existing one.
+			tree, err = NewMutableTree(memDB, 0)
+			require.NoError(t, err)
+			version, err = load(tree, 9)
+			require.NoError(t, err)
+			require.EqualValues(t, 9, version)
+			require.Error(t, tree.DeleteVersion(11))
+			require.NoError(t, tree.DeleteVersion(8))
+			tree.Set([]byte("key10"), []byte("different"))
+			_, _, err = tree.SaveVersion()
+			require.Error(t, err)
+			tree.Set([]byte("key10"), []byte("value10"))
+			_, version, err = tree.SaveVersion()
+			require.NoError(t, err)
+			require.EqualValues(t, 10, version)
+			require.Equal(t, []int{1, 2, 4, 9, 10, 11}, tree.AvailableVersions())
+
+			_, err = tree.LoadVersionForOverwriting(9)
+			require.NoError(t, err)
+			tree.Set([]byte("key10"), []byte("overwritten"))
+			_, version, err = tree.SaveVersion()
+			require.NoError(t, err)
+			require.EqualValues(t, 10, version)
+			require.Equal(t, []int{1, 2, 4, 9, 10}, tree.AvailableVersions())
+			dumps[name] = dumpDB(t, memDB)
+		})
+	}
+	require.Equal(t, dumps["LoadVersion"], dumps["LazyLoadVersion"])
+}
+
+func TestMutableTree_LoadVersion_InitialVersion(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTreeWithOpts(memDB, 0, &Options{InitialVersion: 9})
 	require.NoError(t, err)
-	_, v1, err := tree.SaveVersion()
+	for v := 9; v <= 10; v++ {
+		tree.Set([]byte(fmt.Sprintf("key%v", v)), []byte{0x01})
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+
+	for name, load := range versionLoaders {
+		load := load
+		t.Run(name, func(t *testing.T) {
+			// Reloading the tree with an initial version beyond the lowest should error
+			tree, err := NewMutableTreeWithOpts(memDB, 0, &Options{InitialVersion: 10})
+			require.NoError(t, err)
+			_, err = load(tree, 10)
+			require.Error(t, err)
+
+			tree, err = NewMutableTreeWithOpts(memDB, 0, &Options{InitialVersion: 9})
+			require.NoError(t, err)
+			version, err := load(tree, 10)
+			require.NoError(t, err)
+			require.EqualValues(t, 10, version)
+			require.Equal(t, []int{9, 10}, tree.AvailableVersions())
+		})
+	}
+}
+
+func TestMutableTree_MovePrefix(t *testing.T) {
+	setup := func() *MutableTree {
+		tree, err := NewMutableTree(db.NewMemDB(), 0)
+		require.NoError(t, err)
+		for i := 0; i < 2500; i++ {
+			tree.Set([]byte(fmt.Sprintf("old/%05d", i)), []byte(strconv.Itoa(i)))
+		}
+		tree.Set([]byte("new"), []byte("before"))
+		tree.Set([]byte("new0"), []byte("after"))
+		tree.Set([]byte("ol"), []byte("before"))
+		tree.Set([]byte("old0"), []byte("after"))
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+		return tree
+	}
+
+	// The naive approach, collecting all keys first.
+	expected := setup()
+	keys, values := [][]byte{}, [][]byte{}
+	expected.IterateRange([]byte("old/"), []byte("old0"), true, func(key, value []byte) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return false
+	})
+	for i, key := range keys {
+		expected.Set(append([]byte("new/"), key[4:]...), values[i])
+		expected.Remove(key)
+	}
+	expectedHash, _, err := expected.SaveVersion()
+	require.NoError(t, err)
+
+	tree := setup()
+	moved, err := tree.MovePrefix([]byte("old/"), []byte("new/"))
+	require.NoError(t, err)
+	require.EqualValues(t, 2500, moved)
+	hash, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	_, value := tree.Get([]byte("new/01234"))
+	require.Equal(t, []byte("1234"), value)
+	require.False(t, tree.Has([]byte("old/01234")))
+	require.EqualValues(t, 2504, tree.Size())
+
+	// Moving an empty prefix is a no-op.
+	moved, err = tree.MovePrefix([]byte("old/"), []byte("new/"))
 	require.NoError(t, err)
+	require.EqualValues(t, 0, moved)
+	require.Equal(t, hash, tree.WorkingHash())
+}
 
-	newTree1, err := NewMutableTree(mdb, 1000)
+func TestMutableTree_MovePrefix_Collision(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
 	require.NoError(t, err)
-	v2, err := newTree1.LazyLoadVersion(1)
+	for i := 0; i < 1500; i++ {
+		tree.Set([]byte(fmt.Sprintf("a/%05d", i)), []byte{1})
+	}
+	tree.Set([]byte("b/01400"), []byte{2})
+	hash := tree.WorkingHash()
+
+	_, err = tree.MovePrefix([]byte("a/"), []byte("b/"))
+	require.Error(t, err)
+	require.Equal(t, hash, tree.WorkingHash())
+}
+
+func TestMutableTree_MovePrefix_Invalid(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
 	require.NoError(t, err)
-	require.True(t, v1 == v2)
+	tree.Set([]byte("a"), []byte{1})
 
-	newTree2, err := NewMutableTree(mdb, 1000)
+	testcases := map[string][2][]byte{
+		"empty source":     {nil, []byte("b")},
+		"empty target key": {[]byte("a"), nil},
+		"nested target":    {[]byte("a"), []byte("ab")},
+		"nested source":    {[]byte("ab"), []byte("a")},
+		"identical prefix": {[]byte("a"), []byte("a")},
+	}
+	for desc, tc := range testcases {
+		tc := tc
+		t.Run(desc, func(t *testing.T) {
+			_, err := tree.MovePrefix(tc[0], tc[1])
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMutableTree_MovePrefix_Root(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
 	require.NoError(t, err)
-	v2, err = newTree1.LoadVersion(1)
+	tree.Set([]byte("a/x"), []byte{1})
+	tree.Set([]byte("a/y"), []byte{2})
+	tree.Set([]byte("b"), []byte{3})
+
+	moved, err := tree.MovePrefix([]byte("a/"), nil)
 	require.NoError(t, err)
-	require.True(t, v1 == v2)
+	require.EqualValues(t, 2, moved)
+	var keys []string
+	tree.Iterate(func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return false
+	})
+	require.Equal(t, []string{"b", "x", "y"}, keys)
+
+	// Target keys cannot land in the source range, since they would be moved again.
+	tree.Set([]byte("aab"), []byte{4})
+	hash := tree.WorkingHash()
+	_, err = tree.MovePrefix([]byte("a"), nil)
+	require.Error(t, err)
+	require.Equal(t, hash, tree.WorkingHash())
+}
 
-	require.True(t, newTree1.root == newTree2.root)
+func TestPrefixEnd(t *testing.T) {
+	require.Equal(t, []byte("b"), prefixEnd([]byte("a")))
+	require.Equal(t, []byte{0x01}, prefixEnd([]byte{0x00, 0xFF}))
+	require.Nil(t, prefixEnd([]byte{0xFF, 0xFF}))
+	require.Nil(t, prefixEnd(nil))
 }
@@ -0,0 +1,340 @@
+package iavl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var (
+	// The parent tree and the child trees of a NestedTree are stored under separate prefixes. Child
+	// trees are prefixed by the hash of their parent key, such that no child prefix is a prefix of
+	// another.
+	nestedParentPrefix = []byte("p")
+	nestedChildPrefix  = []byte("c")
+)
+
+// NestedTree is a parent tree whose values are the root hashes of child trees, e.g. one child tree
+// per account. Children are opened with Child(), modified as regular mutable trees, and committed
+// along with the parent by SaveVersion(). Composite proofs of child keys against the parent root
+// are generated by GetVersionedWithNestedProof().
+//
+// The parent tree must not be modified directly, since its values must match the child root
+// hashes. Child trees have their own version numbers, which are not related to the parent
+// version, and are not pruned when parent versions are deleted.
+type NestedTree struct {
+	mtx       sync.Mutex
+	db        dbm.DB
+	cacheSize int
+	parent    *MutableTree
+	children  map[string]*MutableTree // opened child trees, by parent key
+}
+
+// NewNestedTree creates a nested tree stored in the given database. Load() must be called before
+// using the tree, to load the latest version.
+func NewNestedTree(db dbm.DB, cacheSize int) (*NestedTree, error) {
+	parent, err := NewMutableTree(dbm.NewPrefixDB(db, nestedParentPrefix), cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &NestedTree{
+		db:        db,
+		cacheSize: cacheSize,
+		parent:    parent,
+		children:  map[string]*MutableTree{},
+	}, nil
+}
+
+// Load loads the latest version of the parent tree. Child trees are loaded when opened.
+func (tree *NestedTree) Load() (int64, error) {
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+	tree.children = map[string]*MutableTree{}
+	return tree.parent.Load()
+}
+
+// LoadVersionForOverwriting loads the given version of the parent tree, and deletes all later
+// parent versions. Child trees are reverted to the versions referenced by the parent when opened.
+func (tree *NestedTree) LoadVersionForOverwriting(targetVersion int64) (int64, error) {
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+	tree.children = map[string]*MutableTree{}
+	return tree.parent.LoadVersionForOverwriting(targetVersion)
+}
+
+// Parent returns the parent tree, for reading and version management. It must not be modified.
+func (tree *NestedTree) Parent() *MutableTree {
+	return tree.parent
+}
+
+// Version returns the latest saved version of the parent tree.
+func (tree *NestedTree) Version() int64 {
+	return tree.parent.Version()
+}
+
+// Hash returns the root hash of the latest saved version of the parent tree.
+func (tree *NestedTree) Hash() []byte {
+	return tree.parent.Hash()
+}
+
+// childDB returns the database of the child tree under the given parent key.
+func (tree *NestedTree) childDB(key []byte) dbm.DB {
+	hash := sha256.Sum256(key)
+	return dbm.NewPrefixDB(tree.db, append(append([]byte{}, nestedChildPrefix...), hash[:]...))
+}
+
+// Child opens the child tree under the given parent key, creating an empty child if the key is
+// not set. The child is loaded at the version referenced by the parent, and changes to it are
+// committed by SaveVersion(). Opening the same child again returns the same tree, including any
+// unsaved changes.
+func (tree *NestedTree) Child(key []byte) (*MutableTree, error) {
+	if len(key) == 0 {
+		return nil, errors.New("child key cannot be empty")
+	}
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+	if child, ok := tree.children[string(key)]; ok {
+		return child, nil
+	}
+
+	_, rootHash := tree.parent.Get(key)
+	child, err := tree.loadChild(key, rootHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading child tree %X", key)
+	}
+	tree.children[string(key)] = child
+	return child, nil
+}
+
+// loadChild loads the child tree under the given key at the version with the given root hash. If
+// a crash happened between committing the child and the parent, the child is reverted to the
+// version referenced by the parent.
+func (tree *NestedTree) loadChild(key []byte, rootHash []byte) (*MutableTree, error) {
+	db := tree.childDB(key)
+	child, err := NewMutableTree(db, tree.cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = child.Load(); err != nil {
+		return nil, err
+	}
+	if bytes.Equal(childRootHash(child.lastSaved), rootHash) {
+		return child, nil
+	}
+
+	versions := child.AvailableVersions()
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+	for _, version := range versions {
+		t, err := child.GetImmutable(int64(version))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(childRootHash(t), rootHash) {
+			_, err = child.LoadVersionForOverwriting(int64(version))
+			return child, err
+		}
+	}
+	if rootHash != nil {
+		return nil, errors.Errorf("no child version with root hash %X", rootHash)
+	}
+
+	// The child was never referenced by the parent, so its versions can be discarded.
+	if err = deleteAll(db); err != nil {
+		return nil, err
+	}
+	return NewMutableTree(db, tree.cacheSize)
+}
+
+// childRootHash returns the root hash of a child tree as stored in the parent, or nil if the
+// child is empty.
+func childRootHash(t *ImmutableTree) []byte {
+	if t.root == nil {
+		return nil
+	}
+	return t.Hash()
+}
+
+// deleteAll deletes all keys in the database.
+func deleteAll(db dbm.DB) error {
+	itr, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, itr.Key())
+	}
+	if err = itr.Error(); err != nil {
+		itr.Close()
+		return err
+	}
+	itr.Close()
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err = batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+// SaveVersion saves a new version of all modified child trees, sets their root hashes in the
+// parent tree, and saves a new parent version. Empty children are removed from the parent. The
+// children are committed before the parent; if the parent commit fails, the nested tree must be
+// reloaded, which reverts the children to the versions referenced by the parent.
+func (tree *NestedTree) SaveVersion() ([]byte, int64, error) {
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+
+	keys := make([]string, 0, len(tree.children))
+	for key := range tree.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		child := tree.children[key]
+		if child.root == child.lastSaved.root {
+			continue
+		}
+		if _, _, err := child.SaveVersion(); err != nil {
+			return nil, 0, errors.Wrapf(err, "saving child tree %X", key)
+		}
+		if rootHash := childRootHash(child.lastSaved); rootHash == nil {
+			tree.parent.Remove([]byte(key))
+		} else {
+			tree.parent.Set([]byte(key), rootHash)
+		}
+	}
+	return tree.parent.SaveVersion()
+}
+
+// Rollback discards all unsaved changes to the parent and child trees.
+func (tree *NestedTree) Rollback() {
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+	for _, child := range tree.children {
+		child.Rollback()
+	}
+	tree.parent.Rollback()
+}
+
+// GetImmutableChild returns the child tree under the given parent key, as of the given parent
+// version. The child is empty if the key was not set at that version.
+func (tree *NestedTree) GetImmutableChild(key []byte, version int64) (*ImmutableTree, error) {
+	parent, err := tree.parent.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	_, rootHash := parent.Get(key)
+	return tree.immutableChild(key, rootHash)
+}
+
+// immutableChild returns the child tree under the given parent key with the given root hash.
+func (tree *NestedTree) immutableChild(key []byte, rootHash []byte) (*ImmutableTree, error) {
+	ndb := newNodeDB(tree.childDB(key), tree.cacheSize, nil)
+	if rootHash == nil {
+		return &ImmutableTree{ndb: ndb}, nil
+	}
+	root := ndb.GetNode(rootHash)
+	return &ImmutableTree{root: root, ndb: ndb, version: root.version}, nil
+}
+
+// NestedProof is a composite proof of a key in a child tree against the root hash of the parent
+// tree. It consists of a proof of the child root hash in the parent tree, and a proof of the key
+// in the child tree.
+type NestedProof struct {
+	// ParentProof proves the child root hash under the parent key, or its absence. It is nil if
+	// the parent tree is empty, i.e. its root hash is the hash of empty input.
+	ParentProof *RangeProof
+	// ChildProof proves the child key or its absence. It is nil if the child tree is empty.
+	ChildProof *RangeProof
+}
+
+// GetVersionedWithNestedProof gets the value of the child key in the child tree under the parent
+// key, as of the given parent version. A composite proof of existence or absence is returned
+// alongside the value.
+func (tree *NestedTree) GetVersionedWithNestedProof(key, childKey []byte, version int64) (
+	[]byte, *NestedProof, error) {
+
+	parent, err := tree.parent.GetImmutable(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	rootHash, parentProof, err := getWithNestedProof(parent, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	child, err := tree.immutableChild(key, rootHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, childProof, err := getWithNestedProof(child, childKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, &NestedProof{ParentProof: parentProof, ChildProof: childProof}, nil
+}
+
+// getWithNestedProof gets the value of the key with a proof of existence or absence. Unlike
+// GetWithProof(), the proof includes the leaf following the key, such that absence can be verified
+// for keys between two leaves.
+func getWithNestedProof(t *ImmutableTree, key []byte) ([]byte, *RangeProof, error) {
+	proof, keys, values, err := t.getRangeProof(key, nil, 2)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "constructing range proof")
+	}
+	if len(keys) > 0 && bytes.Equal(keys[0], key) {
+		return values[0], proof, nil
+	}
+	return nil, proof, nil
+}
+
+// Verify verifies that the proof proves the value of the child key in the child tree under the
+// parent key, against the parent root hash. A nil value verifies the absence of the child key.
+func (proof *NestedProof) Verify(rootHash, key, childKey, value []byte) error {
+	if proof == nil {
+		return errors.Wrap(ErrInvalidProof, "proof is nil")
+	}
+
+	// An empty child is not set in the parent.
+	var childRoot []byte
+	if proof.ChildProof != nil {
+		childRoot = proof.ChildProof.ComputeRootHash()
+		if childRoot == nil {
+			return errors.Wrap(ErrInvalidProof, "invalid child proof")
+		}
+	} else if value != nil {
+		return errors.Wrap(ErrInvalidProof, "child proof is nil")
+	}
+
+	if proof.ParentProof == nil {
+		empty := sha256.Sum256(nil)
+		if !bytes.Equal(rootHash, empty[:]) || childRoot != nil {
+			return errors.Wrap(ErrInvalidProof, "parent proof is nil")
+		}
+		return nil
+	}
+	if err := proof.ParentProof.Verify(rootHash); err != nil {
+		return errors.Wrap(err, "parent proof")
+	}
+	if childRoot == nil {
+		return errors.Wrap(proof.ParentProof.VerifyAbsence(key), "parent proof")
+	}
+	if err := proof.ParentProof.VerifyItem(key, childRoot); err != nil {
+		return errors.Wrap(err, "parent proof")
+	}
+
+	if err := proof.ChildProof.Verify(childRoot); err != nil {
+		return errors.Wrap(err, "child proof")
+	}
+	if value == nil {
+		return errors.Wrap(proof.ChildProof.VerifyAbsence(childKey), "child proof")
+	}
+	return errors.Wrap(proof.ChildProof.VerifyItem(childKey, value), "child proof")
+}
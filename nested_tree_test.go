@@ -0,0 +1,219 @@
+package iavl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func TestNestedTree(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewNestedTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+
+	alice, err := tree.Child([]byte("alice"))
+	require.NoError(t, err)
+	alice.Set([]byte("item1"), []byte("sword"))
+	alice.Set([]byte("item2"), []byte("shield"))
+	bob, err := tree.Child([]byte("bob"))
+	require.NoError(t, err)
+	bob.Set([]byte("item1"), []byte("bow"))
+	_, err = tree.Child([]byte("carol")) // opened but empty
+	require.NoError(t, err)
+
+	hash1, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+	require.Equal(t, hash1, tree.Hash())
+	require.EqualValues(t, 2, tree.Parent().Size())
+	_, aliceRoot := tree.Parent().Get([]byte("alice"))
+	require.Equal(t, alice.Hash(), aliceRoot)
+
+	// Only modified children get new versions.
+	bob.Set([]byte("item2"), []byte("arrow"))
+	alice.Remove([]byte("item1"))
+	alice.Remove([]byte("item2"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, bob.Version())
+	require.EqualValues(t, 2, alice.Version())
+	require.EqualValues(t, 1, tree.Parent().Size())
+
+	again, err := tree.Child([]byte("bob"))
+	require.NoError(t, err)
+	require.True(t, again == bob)
+
+	// Reopening the tree loads the children referenced by the parent.
+	tree, err = NewNestedTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	bob, err = tree.Child([]byte("bob"))
+	require.NoError(t, err)
+	_, value := bob.Get([]byte("item2"))
+	require.Equal(t, []byte("arrow"), value)
+	alice, err = tree.Child([]byte("alice"))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, alice.Size())
+
+	// Children can be read at past parent versions.
+	child, err := tree.GetImmutableChild([]byte("alice"), 1)
+	require.NoError(t, err)
+	_, value = child.Get([]byte("item1"))
+	require.Equal(t, []byte("sword"), value)
+	child, err = tree.GetImmutableChild([]byte("bob"), 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, child.Size())
+	child, err = tree.GetImmutableChild([]byte("dave"), 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, child.Size())
+	_, err = tree.GetImmutableChild([]byte("bob"), 3)
+	require.Error(t, err)
+
+	_, err = tree.Child(nil)
+	require.Error(t, err)
+}
+
+func TestNestedTree_Proofs(t *testing.T) {
+	tree, err := NewNestedTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+
+	// An empty parent tree proves absence of everything.
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	value, proof, err := tree.GetVersionedWithNestedProof([]byte("alice"), []byte("item1"), 1)
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.NoError(t, proof.Verify(tree.Hash(), []byte("alice"), []byte("item1"), nil))
+	require.Error(t, proof.Verify(tree.Hash(), []byte("alice"), []byte("item1"), []byte("sword")))
+
+	for _, account := range []string{"alice", "bob", "carol"} {
+		child, err := tree.Child([]byte(account))
+		require.NoError(t, err)
+		child.Set([]byte("item1"), []byte(account+"-sword"))
+		child.Set([]byte("item3"), []byte(account+"-shield"))
+	}
+	rootHash, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	value, proof, err = tree.GetVersionedWithNestedProof([]byte("bob"), []byte("item1"), version)
+	require.NoError(t, err)
+	require.Equal(t, []byte("bob-sword"), value)
+	require.NoError(t, proof.Verify(rootHash, []byte("bob"), []byte("item1"), value))
+	require.Error(t, proof.Verify(rootHash, []byte("bob"), []byte("item1"), []byte("alice-sword")))
+	require.Error(t, proof.Verify(rootHash, []byte("alice"), []byte("item1"), value))
+	require.Error(t, proof.Verify(rootHash, []byte("bob"), []byte("item1"), nil))
+	require.Error(t, proof.Verify([]byte("wrong root"), []byte("bob"), []byte("item1"), value))
+
+	// Absent child key.
+	value, proof, err = tree.GetVersionedWithNestedProof([]byte("bob"), []byte("item2"), version)
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.NoError(t, proof.Verify(rootHash, []byte("bob"), []byte("item2"), nil))
+
+	// Absent child tree.
+	value, proof, err = tree.GetVersionedWithNestedProof([]byte("bobby"), []byte("item1"), version)
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.Nil(t, proof.ChildProof)
+	require.NoError(t, proof.Verify(rootHash, []byte("bobby"), []byte("item1"), nil))
+	require.Error(t, proof.Verify(rootHash, []byte("bobby"), []byte("item1"), []byte("bob-sword")))
+
+	for _, account := range []string{"aaron", "zed"} {
+		value, proof, err = tree.GetVersionedWithNestedProof([]byte(account), []byte("item1"), version)
+		require.NoError(t, err)
+		require.Nil(t, value)
+		require.NoError(t, proof.Verify(rootHash, []byte(account), []byte("item1"), nil))
+	}
+	_, proof, err = tree.GetVersionedWithNestedProof([]byte("bobby"), []byte("item1"), version)
+	require.NoError(t, err)
+
+	// A child proof from another child does not verify.
+	_, aliceProof, err := tree.GetVersionedWithNestedProof([]byte("alice"), []byte("item1"), version)
+	require.NoError(t, err)
+	proof.ChildProof = aliceProof.ChildProof
+	require.Error(t, proof.Verify(rootHash, []byte("bobby"), []byte("item1"), []byte("alice-sword")))
+
+	var nilProof *NestedProof
+	require.Error(t, nilProof.Verify(rootHash, []byte("bob"), []byte("item1"), nil))
+}
+
+func TestNestedTree_Recovery(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewNestedTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	alice, err := tree.Child([]byte("alice"))
+	require.NoError(t, err)
+	alice.Set([]byte("item1"), []byte("sword"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	// Simulate a crash after committing the children, but before committing the parent.
+	alice.Set([]byte("item1"), []byte("axe"))
+	_, _, err = alice.SaveVersion()
+	require.NoError(t, err)
+	bob, err := tree.Child([]byte("bob"))
+	require.NoError(t, err)
+	bob.Set([]byte("item1"), []byte("bow"))
+	_, _, err = bob.SaveVersion()
+	require.NoError(t, err)
+
+	tree, err = NewNestedTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	alice, err = tree.Child([]byte("alice"))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, alice.Version())
+	_, value := alice.Get([]byte("item1"))
+	require.Equal(t, []byte("sword"), value)
+	bob, err = tree.Child([]byte("bob"))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, bob.Version())
+	require.EqualValues(t, 0, bob.Size())
+
+	// The children can be written again after recovery.
+	alice.Set([]byte("item2"), []byte("shield"))
+	bob.Set([]byte("item1"), []byte("crossbow"))
+	rootHash, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, version)
+	value, proof, err := tree.GetVersionedWithNestedProof([]byte("bob"), []byte("item1"), version)
+	require.NoError(t, err)
+	require.Equal(t, []byte("crossbow"), value)
+	require.NoError(t, proof.Verify(rootHash, []byte("bob"), []byte("item1"), value))
+
+	// Overwriting parent versions reverts the children when they are opened.
+	_, err = tree.LoadVersionForOverwriting(1)
+	require.NoError(t, err)
+	alice, err = tree.Child([]byte("alice"))
+	require.NoError(t, err)
+	_, value = alice.Get([]byte("item2"))
+	require.Nil(t, value)
+	bob, err = tree.Child([]byte("bob"))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, bob.Size())
+}
+
+func TestNestedTree_Rollback(t *testing.T) {
+	tree, err := NewNestedTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	alice, err := tree.Child([]byte("alice"))
+	require.NoError(t, err)
+	alice.Set([]byte("item1"), []byte("sword"))
+	tree.Rollback()
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 0, tree.Parent().Size())
+	require.EqualValues(t, 0, alice.Size())
+}
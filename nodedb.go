@@ -8,6 +8,7 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	dbm "github.com/tendermint/tm-db"
@@ -39,6 +40,8 @@ type nodeDB struct {
 	mtx            sync.Mutex       // Read/write lock.
 	db             dbm.DB           // Persistent node storage.
 	batch          dbm.Batch        // Batched writing buffer.
+	ownBatch       dbm.Batch        // The batch of the nodeDB, while batch is provided by the caller.
+	pinned         map[string]*Node // Nodes saved into a batch provided by the caller, until it is written.
 	opts           Options          // Options to customize for pruning/writing
 	versionReaders map[int64]uint32 // Number of active version readers
 
@@ -46,6 +49,14 @@ type nodeDB struct {
 	nodeCache      map[string]*list.Element // Node cache.
 	nodeCacheSize  int                      // Node cache size limit in elements.
 	nodeCacheQueue *list.List               // LRU queue of cache elements. Used for deletion.
+
+	savedNodes   int64 // Number of nodes saved, for tracing.
+	savedBytes   int64 // Number of node bytes saved, for tracing.
+	deletedNodes int64 // Number of nodes deleted by pruning, for tracing.
+
+	shadow *shadow // Shadow database mirroring all writes, if any.
+	pages  bool    // Whether the database is known to contain node pages.
+	cold   *coldDB // Cold database holding nodes of old versions, if any.
 }
 
 func newNodeDB(db dbm.DB, cacheSize int, opts *Options) *nodeDB {
@@ -53,16 +64,33 @@ func newNodeDB(db dbm.DB, cacheSize int, opts *Options) *nodeDB {
 		o := DefaultOptions()
 		opts = &o
 	}
-	return &nodeDB{
+	ndb := &nodeDB{
 		db:             db,
-		batch:          db.NewBatch(),
 		opts:           *opts,
 		latestVersion:  0, // initially invalid
 		nodeCache:      make(map[string]*list.Element),
 		nodeCacheSize:  cacheSize,
 		nodeCacheQueue: list.New(),
 		versionReaders: make(map[int64]uint32, 8),
+		pinned:         map[string]*Node{},
+	}
+	if opts.Shadow != nil {
+		ndb.shadow = newShadow(db, opts.Shadow)
+	}
+	if opts.Cold != nil {
+		ndb.cold = newColdDB(opts.Cold)
+	}
+	ndb.batch = ndb.newBatch()
+	return ndb
+}
+
+// newBatch creates a new database batch, which mirrors writes to the shadow database, if any.
+func (ndb *nodeDB) newBatch() dbm.Batch {
+	batch := ndb.db.NewBatch()
+	if ndb.shadow != nil {
+		return ndb.shadow.newBatch(batch)
 	}
+	return batch
 }
 
 // GetNode gets a node from memory or disk. If it is an inner node, it does not
@@ -81,25 +109,40 @@ func (ndb *nodeDB) GetNode(hash []byte) *Node {
 		ndb.nodeCacheQueue.MoveToBack(elem)
 		return elem.Value.(*Node)
 	}
+	if node, ok := ndb.pinned[string(hash)]; ok {
+		return node
+	}
 
 	// Doesn't exist, load.
-	buf, err := ndb.db.Get(ndb.nodeKey(hash))
+	var start time.Time
+	if ndb.opts.Tracer != nil {
+		start = time.Now()
+	}
+	node, page, size, err := readNode(ndb.get, hash)
+	if ndb.opts.Tracer != nil {
+		ndb.traceNodeRead(hash, size, start)
+	}
 	if err != nil {
 		panic(fmt.Sprintf("can't get node %X: %v", hash, err))
 	}
-	if buf == nil {
+	if node == nil {
 		panic(fmt.Sprintf("Value missing for hash %x corresponding to nodeKey %x", hash, ndb.nodeKey(hash)))
 	}
-
-	node, err := MakeNode(buf)
-	if err != nil {
-		panic(fmt.Sprintf("Error reading Node. bytes: %x, error: %v", buf, err))
+	if ndb.shadow != nil && ndb.shadow.sampleRead() {
+		ndb.shadow.compareRead(ndb.nodeKey(hash), hash)
 	}
 
-	node.hash = hash
 	node.persisted = true
 	ndb.cacheNode(node)
 
+	// Cache the rest of the page, since its nodes are likely to be read next.
+	for _, n := range page {
+		if _, ok := ndb.nodeCache[string(n.hash)]; !ok {
+			n.persisted = true
+			ndb.cacheNode(n)
+		}
+	}
+
 	return node
 }
 
@@ -127,23 +170,34 @@ func (ndb *nodeDB) SaveNode(node *Node) {
 	if err := ndb.batch.Set(ndb.nodeKey(node.hash), buf.Bytes()); err != nil {
 		panic(err)
 	}
+	ndb.savedNodes++
+	ndb.savedBytes += int64(buf.Len())
 	debug("BATCH SAVE %X %p\n", node.hash, node)
 	node.persisted = true
 	ndb.cacheNode(node)
+	ndb.pinNode(node)
+}
+
+// pinNode keeps a node saved into a batch provided by the caller in memory, since it cannot be
+// read from the database until the caller has written the batch. The caller must hold the mutex.
+func (ndb *nodeDB) pinNode(node *Node) {
+	if ndb.ownBatch != nil {
+		ndb.pinned[string(node.hash)] = node
+	}
 }
 
 // Has checks if a hash exists in the database.
 func (ndb *nodeDB) Has(hash []byte) (bool, error) {
 	key := ndb.nodeKey(hash)
 
-	if ldb, ok := ndb.db.(*dbm.GoLevelDB); ok {
+	if ldb, ok := ndb.db.(*dbm.GoLevelDB); ok && ndb.cold == nil {
 		exists, err := ldb.DB().Has(key, nil)
 		if err != nil {
 			return false, err
 		}
 		return exists, nil
 	}
-	value, err := ndb.db.Get(key)
+	value, err := ndb.get(key)
 	if err != nil {
 		return false, err
 	}
@@ -151,11 +205,43 @@ func (ndb *nodeDB) Has(hash []byte) (bool, error) {
 	return value != nil, nil
 }
 
+// GetEncodedNode returns the persisted node with the given hash in its canonical encoding, or nil
+// if it does not exist. Unlike GetNode(), it does not panic if the node is missing, e.g. because it
+// was pruned concurrently.
+func (ndb *nodeDB) GetEncodedNode(hash []byte) ([]byte, error) {
+	if len(hash) != hashSize {
+		return nil, errors.Errorf("invalid node hash length %v, expected %v", len(hash), hashSize)
+	}
+
+	ndb.mtx.Lock()
+	var node *Node
+	var err error
+	if elem, ok := ndb.nodeCache[string(hash)]; ok {
+		node = elem.Value.(*Node)
+	} else {
+		node, _, _, err = readNode(ndb.get, hash)
+	}
+	ndb.mtx.Unlock()
+	if err != nil || node == nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(node.encodedSize())
+	if err = node.writeBytes(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // SaveBranch saves the given node and all of its descendants.
 // NOTE: This function clears leftNode/rigthNode recursively and
 // calls _hash() on the given node.
 // TODO refactor, maybe use hashWithCount() but provide a callback.
 func (ndb *nodeDB) SaveBranch(node *Node) []byte {
+	if ndb.pageLevels() > 0 {
+		return ndb.savePagedBranch(node)
+	}
 	if node.persisted {
 		return node.hash
 	}
@@ -182,6 +268,9 @@ func (ndb *nodeDB) SaveBranch(node *Node) []byte {
 
 // resetBatch reset the db batch, keep low memory used
 func (ndb *nodeDB) resetBatch() {
+	if ndb.ownBatch != nil {
+		return // the batch is provided and written by the caller
+	}
 	var err error
 	if ndb.opts.Sync {
 		err = ndb.batch.WriteSync()
@@ -192,7 +281,12 @@ func (ndb *nodeDB) resetBatch() {
 		panic(err)
 	}
 	ndb.batch.Close()
-	ndb.batch = ndb.db.NewBatch()
+	ndb.batch = ndb.newBatch()
+	if ndb.cold != nil {
+		if err = ndb.cold.committed(); err != nil {
+			panic(err)
+		}
+	}
 }
 
 // DeleteVersion deletes a tree version from disk.
@@ -211,6 +305,18 @@ func (ndb *nodeDB) DeleteVersion(version int64, checkLatestVersion bool) error {
 
 // DeleteVersionsFrom permanently deletes all tree versions from the given version upwards.
 func (ndb *nodeDB) DeleteVersionsFrom(version int64) error {
+	return ndb.traverseVersionsFrom(version, func(key []byte) error {
+		if bytes.HasPrefix(key, nodeKeyFormat.Key()) {
+			ndb.uncacheNode(key[1:])
+			return ndb.deleteNodeKey(key)
+		}
+		return ndb.batch.Delete(key)
+	})
+}
+
+// traverseVersionsFrom calls fn with the key of every node, orphan and root entry which must be
+// deleted to delete all versions from the given version onwards. A node key is passed at most once.
+func (ndb *nodeDB) traverseVersionsFrom(version int64, fn func(key []byte) error) error {
 	latest := ndb.getLatestVersion()
 	if latest < version {
 		return nil
@@ -231,7 +337,11 @@ func (ndb *nodeDB) DeleteVersionsFrom(version int64) error {
 
 	// First, delete all active nodes in the current (latest) version whose node version is after
 	// the given version.
-	err = ndb.deleteNodesFrom(version, root)
+	seen := map[string]bool{}
+	err = ndb.traverseNodesFrom(version, root, func(hash []byte) error {
+		seen[string(hash)] = true
+		return fn(ndb.nodeKey(hash))
+	})
 	if err != nil {
 		return err
 	}
@@ -240,32 +350,35 @@ func (ndb *nodeDB) DeleteVersionsFrom(version int64) error {
 	// - Delete orphan entries *and referred nodes* with fromVersion >= version
 	// - Delete orphan entries with toVersion >= version-1 (since orphans at latest are not orphans)
 	ndb.traverseOrphans(func(key, hash []byte) {
+		if err != nil {
+			return
+		}
 		var fromVersion, toVersion int64
 		orphanKeyFormat.Scan(key, &toVersion, &fromVersion)
 
 		if fromVersion >= version {
-			if err = ndb.batch.Delete(key); err != nil {
-				panic(err)
+			if err = fn(key); err != nil {
+				return
 			}
-			if err = ndb.batch.Delete(ndb.nodeKey(hash)); err != nil {
-				panic(err)
+			if !seen[string(hash)] {
+				seen[string(hash)] = true
+				err = fn(ndb.nodeKey(hash))
 			}
-			ndb.uncacheNode(hash)
 		} else if toVersion >= version-1 {
-			if err := ndb.batch.Delete(key); err != nil {
-				panic(err)
-			}
+			err = fn(key)
 		}
 	})
+	if err != nil {
+		return err
+	}
 
 	// Finally, delete the version root entries
 	ndb.traverseRange(rootKeyFormat.Key(version), rootKeyFormat.Key(int64(math.MaxInt64)), func(k, v []byte) {
-		if err := ndb.batch.Delete(k); err != nil {
-			panic(err)
+		if err == nil {
+			err = fn(k)
 		}
 	})
-
-	return nil
+	return err
 }
 
 // DeleteVersionsRange deletes versions from an interval (not inclusive).
@@ -295,6 +408,7 @@ func (ndb *nodeDB) DeleteVersionsRange(fromVersion, toVersion int64) error {
 
 	// If the predecessor is earlier than the beginning of the lifetime, we can delete the orphan.
 	// Otherwise, we shorten its lifetime, by moving its endpoint to the predecessor version.
+	pruner := ndb.newPagePruner()
 	for version := fromVersion; version < toVersion; version++ {
 		ndb.traverseOrphansVersion(version, func(key, hash []byte) {
 			var from, to int64
@@ -303,15 +417,18 @@ func (ndb *nodeDB) DeleteVersionsRange(fromVersion, toVersion int64) error {
 				panic(err)
 			}
 			if from > predecessor {
-				if err := ndb.batch.Delete(ndb.nodeKey(hash)); err != nil {
+				pruner.deleteNode(ndb, hash)
+				if err := ndb.deleteNodeKey(ndb.nodeKey(hash)); err != nil {
 					panic(err)
 				}
 				ndb.uncacheNode(hash)
+				ndb.deletedNodes++
 			} else {
 				ndb.saveOrphan(hash, from, predecessor)
 			}
 		})
 	}
+	pruner.relocate(ndb)
 
 	// Delete the version root entries
 	ndb.traverseRange(rootKeyFormat.Key(fromVersion), rootKeyFormat.Key(toVersion), func(k, v []byte) {
@@ -323,31 +440,28 @@ func (ndb *nodeDB) DeleteVersionsRange(fromVersion, toVersion int64) error {
 	return nil
 }
 
-// deleteNodesFrom deletes the given node and any descendants that have versions after the given
-// (inclusive). It is mainly used via LoadVersionForOverwriting, to delete the current version.
-func (ndb *nodeDB) deleteNodesFrom(version int64, hash []byte) error {
+// traverseNodesFrom calls fn with the hash of the given node and any descendants that have versions
+// after the given (inclusive). It is mainly used via LoadVersionForOverwriting, to delete the
+// current version.
+func (ndb *nodeDB) traverseNodesFrom(version int64, hash []byte, fn func(hash []byte) error) error {
 	if len(hash) == 0 {
 		return nil
 	}
 
 	node := ndb.GetNode(hash)
 	if node.leftHash != nil {
-		if err := ndb.deleteNodesFrom(version, node.leftHash); err != nil {
+		if err := ndb.traverseNodesFrom(version, node.leftHash, fn); err != nil {
 			return err
 		}
 	}
 	if node.rightHash != nil {
-		if err := ndb.deleteNodesFrom(version, node.rightHash); err != nil {
+		if err := ndb.traverseNodesFrom(version, node.rightHash, fn); err != nil {
 			return err
 		}
 	}
 
 	if node.version >= version {
-		if err := ndb.batch.Delete(ndb.nodeKey(hash)); err != nil {
-			return err
-		}
-
-		ndb.uncacheNode(hash)
+		return fn(hash)
 	}
 
 	return nil
@@ -376,6 +490,9 @@ func (ndb *nodeDB) saveOrphan(hash []byte, fromVersion, toVersion int64) {
 	if err := ndb.batch.Set(key, hash); err != nil {
 		panic(err)
 	}
+	if ndb.cold != nil {
+		ndb.cold.orphanWritten(key)
+	}
 }
 
 // deleteOrphans deletes orphaned nodes from disk, and the associated orphan
@@ -386,6 +503,7 @@ func (ndb *nodeDB) deleteOrphans(version int64) {
 
 	// Traverse orphans with a lifetime ending at the version specified.
 	// TODO optimize.
+	pruner := ndb.newPagePruner()
 	ndb.traverseOrphansVersion(version, func(key, hash []byte) {
 		var fromVersion, toVersion int64
 
@@ -405,15 +523,26 @@ func (ndb *nodeDB) deleteOrphans(version int64) {
 		// moving its endpoint to the previous version.
 		if predecessor < fromVersion || fromVersion == toVersion {
 			debug("DELETE predecessor:%v fromVersion:%v toVersion:%v %X\n", predecessor, fromVersion, toVersion, hash)
-			if err := ndb.batch.Delete(ndb.nodeKey(hash)); err != nil {
+			pruner.deleteNode(ndb, hash)
+			if err := ndb.deleteNodeKey(ndb.nodeKey(hash)); err != nil {
 				panic(err)
 			}
 			ndb.uncacheNode(hash)
+			ndb.deletedNodes++
 		} else {
 			debug("MOVE predecessor:%v fromVersion:%v toVersion:%v %X\n", predecessor, fromVersion, toVersion, hash)
 			ndb.saveOrphan(hash, fromVersion, predecessor)
 		}
 	})
+	pruner.relocate(ndb)
+}
+
+// counters returns the number of nodes and node bytes saved, and the number of nodes deleted by
+// pruning.
+func (ndb *nodeDB) counters() (savedNodes, savedBytes, deletedNodes int64) {
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+	return ndb.savedNodes, ndb.savedBytes, ndb.deletedNodes
 }
 
 func (ndb *nodeDB) nodeKey(hash []byte) []byte {
@@ -469,6 +598,28 @@ func (ndb *nodeDB) getPreviousVersion(version int64) int64 {
 	return 0
 }
 
+// getFirstVersion returns the first saved version, or 0 if there are none.
+func (ndb *nodeDB) getFirstVersion() int64 {
+	itr, err := ndb.db.Iterator(
+		rootKeyFormat.Key(1),
+		rootKeyFormat.Key(int64(math.MaxInt64)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer itr.Close()
+
+	if itr.Valid() {
+		var version int64
+		rootKeyFormat.Scan(itr.Key(), &version)
+		return version
+	}
+	if err := itr.Error(); err != nil {
+		panic(err)
+	}
+	return 0
+}
+
 // deleteRoot deletes the root entry from disk, but not the node it points to.
 func (ndb *nodeDB) deleteRoot(version int64, checkLatestVersion bool) {
 	if checkLatestVersion && version == ndb.getLatestVersion() {
@@ -559,7 +710,10 @@ func (ndb *nodeDB) Commit() error {
 	}
 
 	ndb.batch.Close()
-	ndb.batch = ndb.db.NewBatch()
+	ndb.batch = ndb.newBatch()
+	if ndb.cold != nil {
+		return ndb.cold.committed()
+	}
 
 	return nil
 }
@@ -679,15 +833,47 @@ func (ndb *nodeDB) size() int {
 
 func (ndb *nodeDB) traverseNodes(fn func(hash []byte, node *Node)) {
 	nodes := []*Node{}
+	seen := map[string]bool{}
 
-	ndb.traversePrefix(nodeKeyFormat.Key(), func(key, value []byte) {
+	add := func(key, value []byte) {
+		if _, ok := pageRefRoot(value); ok {
+			return // the node is returned with its page
+		}
+		if isPage(value) {
+			page, err := decodePage(value)
+			if err != nil {
+				panic(fmt.Sprintf("Couldn't decode node page from database: %v", err))
+			}
+			for _, node := range page {
+				if !seen[string(node.hash)] {
+					seen[string(node.hash)] = true
+					nodes = append(nodes, node)
+				}
+			}
+			return
+		}
+		if seen[string(key[1:])] {
+			return
+		}
 		node, err := MakeNode(value)
 		if err != nil {
 			panic(fmt.Sprintf("Couldn't decode node from database: %v", err))
 		}
 		nodeKeyFormat.Scan(key, &node.hash)
+		seen[string(node.hash)] = true
 		nodes = append(nodes, node)
-	})
+	}
+	ndb.traversePrefix(nodeKeyFormat.Key(), add)
+	if ndb.cold != nil {
+		itr, err := dbm.IteratePrefix(ndb.cold.db, nodeKeyFormat.Key())
+		if err != nil {
+			panic(err)
+		}
+		for ; itr.Valid(); itr.Next() {
+			add(itr.Key(), itr.Value())
+		}
+		itr.Close()
+	}
 
 	sort.Slice(nodes, func(i, j int) bool {
 		return bytes.Compare(nodes[i].key, nodes[j].key) < 0
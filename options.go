@@ -1,5 +1,7 @@
 package iavl
 
+import "time"
+
 // Options define tree options.
 type Options struct {
 	// Sync synchronously flushes all writes to storage, using e.g. the fsync syscall.
@@ -10,6 +12,29 @@ type Options struct {
 	// this, an error is returned when loading the tree. Only used for the initial SaveVersion()
 	// call.
 	InitialVersion uint64
+
+	// Tracer receives spans around tree operations, such as the phases of SaveVersion(). Nil
+	// disables tracing, like NopTracer.
+	Tracer Tracer
+
+	// SlowNodeReadThreshold is the minimum duration of a node database read to be traced. Zero
+	// uses DefaultSlowNodeReadThreshold.
+	SlowNodeReadThreshold time.Duration
+
+	// Shadow mirrors all writes to a secondary database, and compares it with the primary database
+	// to validate e.g. a new backend. Nil disables shadow writes.
+	Shadow *ShadowOptions
+
+	// NodePageLevels packs up to this many levels of nodes saved together into a single database
+	// record, a node page, which is read and cached as a unit. This reduces the number of database
+	// reads per lookup roughly by this factor, at the cost of larger reads and writes. Pages do
+	// not affect hashes or proofs, and can be enabled or disabled for an existing database at any
+	// time, since nodes are read in either layout. Values below 2 disable pages.
+	NodePageLevels int
+
+	// Cold moves nodes which are only referenced by old versions to a secondary cold database,
+	// see ColdOptions. Nil disables cold storage.
+	Cold *ColdOptions
 }
 
 // DefaultOptions returns the default options for IAVL.
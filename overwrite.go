@@ -0,0 +1,312 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// Entries removed by SoftLoadVersionForOverwriting() are moved into the trash, keyed by the
+	// trash prefix followed by their original key.
+	trashKeyFormat = NewKeyFormat('t') // t<key>
+
+	// The version loaded by SoftLoadVersionForOverwriting() is recorded with its root hash, since
+	// the trash can only be restored on top of it.
+	trashTargetKeyFormat = NewKeyFormat('T', int64Size) // T<version>
+)
+
+// OverwriteReport describes the data removed by overwriting versions above a target version.
+type OverwriteReport struct {
+	TargetVersion int64   // the version loaded
+	Versions      []int64 // the versions removed, in ascending order
+	Nodes         int     // the number of nodes removed
+	Orphans       int     // the number of orphan entries removed
+	Bytes         int64   // the total size of the removed database entries, keys included
+}
+
+// String implements fmt.Stringer.
+func (r OverwriteReport) String() string {
+	return fmt.Sprintf("target=%v versions=%v nodes=%v orphans=%v bytes=%v",
+		r.TargetVersion, r.Versions, r.Nodes, r.Orphans, r.Bytes)
+}
+
+// DryRunLoadVersionForOverwriting reports the versions, nodes and bytes which would be removed by
+// LoadVersionForOverwriting(targetVersion), without modifying the tree or the database.
+func (tree *MutableTree) DryRunLoadVersionForOverwriting(targetVersion int64) (OverwriteReport, error) {
+	report := OverwriteReport{TargetVersion: targetVersion}
+	if ok, err := tree.ndb.HasRoot(targetVersion); err != nil {
+		return report, err
+	} else if !ok {
+		return report, errors.Wrapf(ErrVersionDoesNotExist, "version %v", targetVersion)
+	}
+	err := tree.ndb.traverseOverwritten(targetVersion+1, func(key, value []byte) error {
+		report.add(key, value)
+		return nil
+	})
+	return report, err
+}
+
+// add adds a removed database entry to the report.
+func (r *OverwriteReport) add(key, value []byte) {
+	switch {
+	case bytes.HasPrefix(key, nodeKeyFormat.Key()):
+		r.Nodes++
+	case bytes.HasPrefix(key, orphanKeyFormat.Key()):
+		r.Orphans++
+	case bytes.HasPrefix(key, rootKeyFormat.Key()):
+		var version int64
+		rootKeyFormat.Scan(key, &version)
+		r.Versions = append(r.Versions, version)
+	}
+	r.Bytes += int64(len(key) + len(value))
+}
+
+// SoftLoadVersionForOverwriting is like LoadVersionForOverwriting(), but moves the removed roots,
+// nodes and orphan entries into a trash area instead of deleting them. They can be restored with
+// RestoreOverwritten() as long as no new versions have been saved, or deleted with PurgeTrash().
+// The trash must be empty.
+func (tree *MutableTree) SoftLoadVersionForOverwriting(targetVersion int64) (OverwriteReport, error) {
+	report := OverwriteReport{TargetVersion: targetVersion}
+	if err := tree.checkPending(); err != nil {
+		return report, err
+	}
+	empty, err := tree.ndb.trashEmpty()
+	if err != nil {
+		return report, err
+	}
+	if !empty {
+		return report, errors.New("trash is not empty, restore or purge it first")
+	}
+	if err = tree.ndb.checkRebuild(); err != nil {
+		return report, err
+	}
+
+	latestVersion, err := tree.LoadVersion(targetVersion)
+	if err != nil {
+		return report, err
+	}
+	rootHash, err := tree.ndb.getRoot(targetVersion)
+	if err != nil {
+		return report, err
+	}
+	if err = tree.ndb.trashVersionsFrom(targetVersion+1, &report); err != nil {
+		return report, err
+	}
+	if len(report.Versions) > 0 {
+		if err = tree.ndb.batch.Set(trashTargetKeyFormat.Key(targetVersion), rootHash); err != nil {
+			return report, err
+		}
+	}
+	if err = tree.ndb.Commit(); err != nil {
+		return report, err
+	}
+	tree.ndb.resetLatestVersion(latestVersion)
+
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+	for v := range tree.versions {
+		if v > targetVersion {
+			delete(tree.versions, v)
+		}
+	}
+	return report, nil
+}
+
+// OverwrittenVersions returns the versions in the trash, in ascending order.
+func (tree *MutableTree) OverwrittenVersions() ([]int64, error) {
+	var versions []int64
+	prefix := trashKey(rootKeyFormat.Key())
+	tree.ndb.traversePrefix(prefix, func(key, value []byte) {
+		var version int64
+		rootKeyFormat.Scan(key[len(prefix)-1:], &version)
+		versions = append(versions, version)
+	})
+	return versions, nil
+}
+
+// RestoreOverwritten restores the versions moved into the trash by SoftLoadVersionForOverwriting(),
+// and loads the latest version. Any unsaved changes are discarded. It fails if versions have been
+// saved in place of the overwritten ones; these must be removed first, e.g. with
+// LoadVersionForOverwriting().
+func (tree *MutableTree) RestoreOverwritten() (int64, error) {
+	if err := tree.checkPending(); err != nil {
+		return 0, err
+	}
+	versions, err := tree.OverwrittenVersions()
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, errors.New("trash is empty")
+	}
+	var targetVersion int64
+	var targetHash []byte
+	tree.ndb.traversePrefix(trashTargetKeyFormat.Key(), func(key, value []byte) {
+		trashTargetKeyFormat.Scan(key, &targetVersion)
+		targetHash = value
+	})
+	if latest := tree.ndb.getLatestVersion(); latest != targetVersion {
+		return 0, errors.Errorf("versions %v-%v were overwritten at version %v, but latest version is %v",
+			versions[0], versions[len(versions)-1], targetVersion, latest)
+	}
+	if rootHash, err := tree.ndb.getRoot(targetVersion); err != nil {
+		return 0, err
+	} else if !bytes.Equal(rootHash, targetHash) {
+		return 0, errors.Errorf("version %v has changed since versions %v-%v were overwritten",
+			targetVersion, versions[0], versions[len(versions)-1])
+	}
+
+	if err = tree.ndb.restoreTrash(); err != nil {
+		return 0, err
+	}
+	if err = tree.ndb.Commit(); err != nil {
+		return 0, err
+	}
+	tree.ndb.resetLatestVersion(versions[len(versions)-1])
+	return tree.LoadVersion(0)
+}
+
+// PurgeTrash permanently deletes the versions moved into the trash by
+// SoftLoadVersionForOverwriting(). The trash is deleted in batches of maxBatchSize entries, and an
+// interrupted purge can no longer be restored, but can be completed by calling PurgeTrash() again.
+func (tree *MutableTree) PurgeTrash() error {
+	if err := tree.checkPending(); err != nil {
+		return err
+	}
+	ndb := tree.ndb
+	ndb.mtx.Lock()
+	err := ndb.deleteTrashTarget()
+	ndb.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	if err = ndb.Commit(); err != nil {
+		return err
+	}
+	return ndb.deleteTrash()
+}
+
+// traverseOverwritten calls fn with every database entry which must be removed to delete all
+// versions from the given version onwards.
+func (ndb *nodeDB) traverseOverwritten(version int64, fn func(key, value []byte) error) error {
+	// Collect the keys first, since the database may not be read while iterating.
+	var keys [][]byte
+	err := ndb.traverseVersionsFrom(version, func(key []byte) error {
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		value, err := ndb.get(key)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			return errors.Errorf("entry %X not found", key)
+		}
+		if err = fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trashVersionsFrom moves all versions from the given version onwards into the trash, adding the
+// moved entries to the report.
+func (ndb *nodeDB) trashVersionsFrom(version int64, report *OverwriteReport) error {
+	return ndb.traverseOverwritten(version, func(key, value []byte) error {
+		if err := ndb.batch.Set(trashKey(key), value); err != nil {
+			return err
+		}
+		if bytes.HasPrefix(key, nodeKeyFormat.Key()) {
+			ndb.uncacheNode(key[1:])
+			if err := ndb.deleteNodeKey(key); err != nil {
+				return err
+			}
+		} else if err := ndb.batch.Delete(key); err != nil {
+			return err
+		}
+		report.add(key, value)
+		return nil
+	})
+}
+
+// restoreTrash moves all entries in the trash back into place.
+func (ndb *nodeDB) restoreTrash() error {
+	err := ndb.traverseTrash(func(key, value []byte) error {
+		if err := ndb.batch.Set(key, value); err != nil {
+			return err
+		}
+		return ndb.batch.Delete(trashKey(key))
+	})
+	if err != nil {
+		return err
+	}
+	return ndb.deleteTrashTarget()
+}
+
+// deleteTrashTarget deletes the record of the version the trash was overwritten at.
+func (ndb *nodeDB) deleteTrashTarget() (err error) {
+	ndb.traversePrefix(trashTargetKeyFormat.Key(), func(key, value []byte) {
+		if err == nil {
+			err = ndb.batch.Delete(key)
+		}
+	})
+	return err
+}
+
+// deleteTrash deletes all entries in the trash, in batches of maxBatchSize entries.
+func (ndb *nodeDB) deleteTrash() error {
+	for {
+		// Collect the keys first, since the database may not be written while iterating.
+		var keys [][]byte
+		ndb.traversePrefix(trashKeyFormat.Key(), func(key, value []byte) {
+			if len(keys) < maxBatchSize {
+				keys = append(keys, append([]byte{}, key...))
+			}
+		})
+		if len(keys) == 0 {
+			return nil
+		}
+		ndb.mtx.Lock()
+		for _, key := range keys {
+			if err := ndb.batch.Delete(key); err != nil {
+				ndb.mtx.Unlock()
+				return err
+			}
+		}
+		ndb.mtx.Unlock()
+		if err := ndb.Commit(); err != nil {
+			return err
+		}
+	}
+}
+
+// traverseTrash calls fn with the original key and value of every entry in the trash, in key order.
+func (ndb *nodeDB) traverseTrash(fn func(key, value []byte) error) (err error) {
+	ndb.traversePrefix(trashKeyFormat.Key(), func(key, value []byte) {
+		if err == nil {
+			err = fn(key[1:], value)
+		}
+	})
+	return err
+}
+
+// trashEmpty returns whether the trash is empty.
+func (ndb *nodeDB) trashEmpty() (bool, error) {
+	itr, err := ndb.db.Iterator(trashKeyFormat.Key(), []byte{trashKeyFormat.prefix + 1})
+	if err != nil {
+		return false, err
+	}
+	defer itr.Close()
+	return !itr.Valid(), itr.Error()
+}
+
+// trashKey returns the trash key of a database entry.
+func trashKey(key []byte) []byte {
+	return append(trashKeyFormat.Key(), key...)
+}
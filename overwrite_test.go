@@ -0,0 +1,239 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// setupOverwriteTree creates a tree with 5 versions.
+func setupOverwriteTree(t *testing.T, memDB db.DB) *MutableTree {
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	for v := 1; v <= 5; v++ {
+		for i := 0; i < 20; i++ {
+			tree.Set([]byte(fmt.Sprintf("key-%02d", (i*v)%30)), []byte(fmt.Sprintf("value-%v-%v", v, i)))
+		}
+		tree.Remove([]byte(fmt.Sprintf("key-%02d", v)))
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+// dumpDB returns all entries in the database.
+func dumpDB(t *testing.T, memDB db.DB) map[string]string {
+	entries := map[string]string{}
+	itr, err := memDB.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		entries[string(itr.Key())] = string(itr.Value())
+	}
+	return entries
+}
+
+// countPrefix returns the number of entries with the given key prefix.
+func countPrefix(entries map[string]string, prefix byte) int {
+	count := 0
+	for key := range entries {
+		if key[0] == prefix {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDryRunLoadVersionForOverwriting(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupOverwriteTree(t, memDB)
+	before := dumpDB(t, memDB)
+
+	report, err := tree.DryRunLoadVersionForOverwriting(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, report.TargetVersion)
+	require.Equal(t, []int64{3, 4, 5}, report.Versions)
+	require.Equal(t, before, dumpDB(t, memDB))
+	require.EqualValues(t, 5, tree.Version())
+
+	// The report matches what is actually deleted.
+	_, err = tree.LoadVersionForOverwriting(2)
+	require.NoError(t, err)
+	after := dumpDB(t, memDB)
+	require.Equal(t, countPrefix(before, 'n')-countPrefix(after, 'n'), report.Nodes)
+	require.Equal(t, countPrefix(before, 'o')-countPrefix(after, 'o'), report.Orphans)
+	var removedBytes int64
+	for key, value := range before {
+		if _, ok := after[key]; !ok {
+			removedBytes += int64(len(key) + len(value))
+		}
+	}
+	require.Equal(t, removedBytes, report.Bytes)
+
+	report, err = tree.DryRunLoadVersionForOverwriting(2)
+	require.NoError(t, err)
+	require.Empty(t, report.Versions)
+	require.Zero(t, report.Bytes)
+
+	_, err = tree.DryRunLoadVersionForOverwriting(3)
+	require.Error(t, err)
+}
+
+func TestSoftLoadVersionForOverwriting(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupOverwriteTree(t, memDB)
+	before := dumpDB(t, memDB)
+	v2, err := tree.GetImmutable(2)
+	require.NoError(t, err)
+	hash2 := v2.Hash()
+
+	dryRun, err := tree.DryRunLoadVersionForOverwriting(2)
+	require.NoError(t, err)
+	report, err := tree.SoftLoadVersionForOverwriting(2)
+	require.NoError(t, err)
+	require.Equal(t, dryRun, report)
+	require.EqualValues(t, 2, tree.Version())
+	require.Equal(t, hash2, tree.Hash())
+	require.False(t, tree.VersionExists(3))
+	versions, err := tree.OverwrittenVersions()
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 4, 5}, versions)
+
+	// The trash must be restored or purged before overwriting again.
+	_, err = tree.SoftLoadVersionForOverwriting(1)
+	require.Error(t, err)
+
+	// Restoring returns the database to its exact previous state.
+	version, err := tree.RestoreOverwritten()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, version)
+	require.Equal(t, before, dumpDB(t, memDB))
+	v4, err := tree.GetImmutable(4)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, v4.Version())
+	_, err = tree.RestoreOverwritten()
+	require.Error(t, err)
+
+	// A reloaded tree sees the trash, and can save new versions on top of it.
+	_, err = tree.SoftLoadVersionForOverwriting(2)
+	require.NoError(t, err)
+	tree, err = NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	version, err = tree.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, version)
+	tree.Set([]byte("new"), []byte("value"))
+	_, version, err = tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, version)
+
+	// The trash cannot be restored over new versions, but can be purged.
+	_, err = tree.RestoreOverwritten()
+	require.Error(t, err)
+	require.NoError(t, tree.PurgeTrash())
+	versions, err = tree.OverwrittenVersions()
+	require.NoError(t, err)
+	require.Empty(t, versions)
+	after := dumpDB(t, memDB)
+	require.Zero(t, countPrefix(after, 't'))
+	require.Zero(t, countPrefix(after, 'T'))
+	_, err = tree.RestoreOverwritten()
+	require.Error(t, err)
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+}
+
+func TestSoftLoadVersionForOverwriting_ChangedTarget(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupOverwriteTree(t, memDB)
+	_, err := tree.SoftLoadVersionForOverwriting(4)
+	require.NoError(t, err)
+
+	// Rewriting the target version invalidates the trash.
+	_, err = tree.LoadVersionForOverwriting(3)
+	require.NoError(t, err)
+	tree.Set([]byte("other"), []byte("value"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	_, err = tree.RestoreOverwritten()
+	require.Error(t, err)
+	require.NoError(t, tree.PurgeTrash())
+}
+
+// countingBatchDB counts the batches written to the database.
+type countingBatchDB struct {
+	db.DB
+	writes *int
+}
+
+func (d countingBatchDB) NewBatch() db.Batch {
+	return countingBatch{d.DB.NewBatch(), d.writes}
+}
+
+type countingBatch struct {
+	db.Batch
+	writes *int
+}
+
+func (b countingBatch) Write() error {
+	*b.writes++
+	return b.Batch.Write()
+}
+
+func (b countingBatch) WriteSync() error {
+	*b.writes++
+	return b.Batch.WriteSync()
+}
+
+func TestPurgeTrash_Batches(t *testing.T) {
+	memDB := db.NewMemDB()
+	writes := 0
+	tree, err := NewMutableTree(countingBatchDB{memDB, &writes}, 0)
+	require.NoError(t, err)
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	for i := 0; i < maxBatchSize; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%05d", i)), []byte{1})
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	_, err = tree.SoftLoadVersionForOverwriting(1)
+	require.NoError(t, err)
+	require.Greater(t, countPrefix(dumpDB(t, memDB), 't'), maxBatchSize)
+
+	// Purging while a version is pending fails.
+	tree.Set([]byte("a"), []byte{1})
+	batch := memDB.NewBatch()
+	defer batch.Close()
+	_, _, err = tree.SaveVersionInto(batch)
+	require.NoError(t, err)
+	require.Error(t, tree.PurgeTrash())
+	require.NoError(t, tree.DiscardVersion())
+
+	// The trash is deleted in bounded batches.
+	writes = 0
+	require.NoError(t, tree.PurgeTrash())
+	require.Greater(t, writes, 2)
+	after := dumpDB(t, memDB)
+	require.Zero(t, countPrefix(after, 't'))
+	require.Zero(t, countPrefix(after, 'T'))
+}
+
+func TestPurgeTrash_Interrupted(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree := setupOverwriteTree(t, memDB)
+	_, err := tree.SoftLoadVersionForOverwriting(3)
+	require.NoError(t, err)
+
+	// Simulate a purge interrupted after deleting the target: the trash can no longer be
+	// restored, but the purge can be completed.
+	require.NoError(t, tree.ndb.deleteTrashTarget())
+	require.NoError(t, tree.ndb.Commit())
+	_, err = tree.RestoreOverwritten()
+	require.Error(t, err)
+	require.NoError(t, tree.PurgeTrash())
+	require.Zero(t, countPrefix(dumpDB(t, memDB), 't'))
+}
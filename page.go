@@ -0,0 +1,365 @@
+package iavl
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// Node pages pack a small subtree of nodes saved together into a single database record, to reduce
+// the number of database reads per lookup, see Options.NodePageLevels. A page is stored under the
+// node key of its root node, and every other node in the page stores a reference to the page root
+// under its own node key, such that any node can still be read by hash. All nodes in a page have
+// the same version.
+//
+// Pages do not affect node hashes or proofs, and databases may contain both pages and individual
+// nodes, e.g. when enabling pages for an existing database.
+var (
+	pageMagic    = []byte{0xff, 0xff, 'p', 'g'} // prefixes a page record
+	pageRefMagic = []byte{0xff, 0xff, 'p', 'r'} // prefixes a page reference, followed by the root hash
+
+	// The pages marker is set once a page has been written, since pruning must then check whether
+	// deleted nodes are page roots.
+	pagesKeyFormat = NewKeyFormat('p') // p
+)
+
+// encodePage encodes a page of nodes, with the page root first.
+func encodePage(nodes []*Node, isTrackable bool) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(pageMagic)
+	for _, node := range nodes {
+		var nodeBuf bytes.Buffer
+		nodeBuf.Grow(node.encodedSizeEx(isTrackable))
+		if err := node.writeBytesEx(&nodeBuf, isTrackable); err != nil {
+			return nil, err
+		}
+		if err := encodeBytes(&buf, node.hash); err != nil {
+			return nil, err
+		}
+		if err := encodeBytes(&buf, nodeBuf.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// isPage returns true if a node record contains a page.
+func isPage(buf []byte) bool {
+	return bytes.HasPrefix(buf, pageMagic)
+}
+
+// decodePage decodes the nodes of a page, with the page root first. The nodes have their hashes
+// set.
+func decodePage(buf []byte) ([]*Node, error) {
+	if !isPage(buf) {
+		return nil, errors.New("not a node page")
+	}
+	buf = buf[len(pageMagic):]
+	var nodes []*Node
+	for len(buf) > 0 {
+		hash, n, err := decodeBytes(buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding page node hash")
+		}
+		buf = buf[n:]
+		nodeBytes, n, err := decodeBytes(buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding page node")
+		}
+		buf = buf[n:]
+		node, err := MakeNode(nodeBytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decoding page node %X", hash)
+		}
+		node.hash = hash
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("empty node page")
+	}
+	return nodes, nil
+}
+
+// pageRef returns a page reference to the given page root.
+func pageRef(rootHash []byte) []byte {
+	return append(append(make([]byte, 0, len(pageRefMagic)+len(rootHash)), pageRefMagic...), rootHash...)
+}
+
+// pageRefRoot returns the page root hash of a page reference, if the node record is one.
+func pageRefRoot(buf []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(buf, pageRefMagic) {
+		return nil, false
+	}
+	return buf[len(pageRefMagic):], true
+}
+
+// readNode reads the node with the given hash using get, which reads a database key, following
+// page references. It returns the node along with all nodes of its page, if it is stored in a
+// page, and the number of bytes read. The node is nil if it does not exist. Returned nodes have
+// their hashes set.
+func readNode(get func(key []byte) ([]byte, error), hash []byte) (node *Node, page []*Node, size int, err error) {
+	buf, err := get(nodeKeyFormat.KeyBytes(hash))
+	if err != nil || buf == nil {
+		return nil, nil, len(buf), err
+	}
+	size = len(buf)
+	if root, ok := pageRefRoot(buf); ok {
+		buf, err = get(nodeKeyFormat.KeyBytes(root))
+		if err != nil {
+			return nil, nil, size, err
+		}
+		if buf == nil {
+			return nil, nil, size, errors.Errorf("page %X of node %X is missing", root, hash)
+		}
+		size += len(buf)
+	}
+
+	if !isPage(buf) {
+		node, err = MakeNode(buf)
+		if err != nil {
+			return nil, nil, size, err
+		}
+		node.hash = hash
+		return node, nil, size, nil
+	}
+	page, err = decodePage(buf)
+	if err != nil {
+		return nil, nil, size, err
+	}
+	for _, n := range page {
+		if bytes.Equal(n.hash, hash) {
+			return n, page, size, nil
+		}
+	}
+	return nil, nil, size, errors.Errorf("node %X is missing from its page", hash)
+}
+
+// pageDepth returns the number of levels below the node in its unsaved page, i.e. the height of
+// the subtree of unsaved descendants of the node.
+func (node *Node) pageDepth() int {
+	depth := 0
+	for _, child := range []*Node{node.leftNode, node.rightNode} {
+		if child != nil && !child.persisted {
+			if d := child.pageDepth() + 1; d > depth {
+				depth = d
+			}
+		}
+	}
+	return depth
+}
+
+// pageNodes appends the node and its unsaved descendants to nodes, with the node first.
+func (node *Node) pageNodes(nodes []*Node) []*Node {
+	nodes = append(nodes, node)
+	for _, child := range []*Node{node.leftNode, node.rightNode} {
+		if child != nil && !child.persisted {
+			nodes = child.pageNodes(nodes)
+		}
+	}
+	return nodes
+}
+
+// pageLevels returns the maximum number of levels in a node page, or 0 if pages are disabled.
+func (ndb *nodeDB) pageLevels() int {
+	if ndb.opts.NodePageLevels < 2 {
+		return 0
+	}
+	return ndb.opts.NodePageLevels
+}
+
+// flushChildPages writes the unsaved pages of the node's children using write, unless they can be
+// extended by the node, i.e. they are not full and have the same version. Nodes must be built
+// bottom-up, with the final page written once the root is built.
+func (ndb *nodeDB) flushChildPages(node *Node, write func(page []*Node) error) error {
+	for _, child := range []*Node{node.leftNode, node.rightNode} {
+		if child != nil && !child.persisted &&
+			(child.version != node.version || child.pageDepth()+1 >= ndb.pageLevels()) {
+			if err := write(child.pageNodes(nil)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writePage writes a page of nodes to the batch, and marks them as persisted. It returns the
+// number of bytes written. The caller must hold the mutex.
+func (ndb *nodeDB) writePage(batch dbm.Batch, nodes []*Node) (int, error) {
+	var buf []byte
+	var err error
+	isTrackable := ndb.db.IsTrackable()
+	root := nodes[0]
+	if len(nodes) == 1 {
+		// A single node is stored as a regular node record.
+		var nodeBuf bytes.Buffer
+		nodeBuf.Grow(root.encodedSizeEx(isTrackable))
+		err = root.writeBytesEx(&nodeBuf, isTrackable)
+		buf = nodeBuf.Bytes()
+	} else {
+		buf, err = encodePage(nodes, isTrackable)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err = batch.Set(ndb.nodeKey(root.hash), buf); err != nil {
+		return 0, err
+	}
+	size := len(buf)
+
+	if len(nodes) > 1 {
+		ref := pageRef(root.hash)
+		for _, node := range nodes[1:] {
+			if err = batch.Set(ndb.nodeKey(node.hash), ref); err != nil {
+				return 0, err
+			}
+			size += len(ref)
+		}
+		if !ndb.hasPages() {
+			// The marker is only known to be set once the batch is written, so it is set on every
+			// page until then.
+			if err = batch.Set(pagesKeyFormat.Key(), []byte{}); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for _, node := range nodes {
+		node.persisted = true
+	}
+	return size, nil
+}
+
+// savePage saves a page of nodes, like SaveNode does for a single node. It releases the children
+// of the saved nodes.
+func (ndb *nodeDB) savePage(nodes []*Node) error {
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+
+	for _, node := range nodes {
+		if node.hash == nil {
+			panic("Expected to find node.hash, but none found.")
+		}
+		if node.persisted {
+			panic("Shouldn't be calling save on an already persisted node.")
+		}
+	}
+	size, err := ndb.writePage(ndb.batch, nodes)
+	if err != nil {
+		return err
+	}
+	ndb.savedNodes += int64(len(nodes))
+	ndb.savedBytes += int64(size)
+	for _, node := range nodes {
+		node.leftNode = nil
+		node.rightNode = nil
+		ndb.cacheNode(node)
+		ndb.pinNode(node)
+	}
+	return nil
+}
+
+// savePagedBranch saves the given node and all of its descendants in pages, like SaveBranch.
+func (ndb *nodeDB) savePagedBranch(node *Node) []byte {
+	var build func(node *Node)
+	build = func(node *Node) {
+		if node.leftNode != nil {
+			if !node.leftNode.persisted {
+				build(node.leftNode)
+			}
+			node.leftHash = node.leftNode.hash
+		}
+		if node.rightNode != nil {
+			if !node.rightNode.persisted {
+				build(node.rightNode)
+			}
+			node.rightHash = node.rightNode.hash
+		}
+		node._hash()
+		err := ndb.flushChildPages(node, ndb.savePage)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if node.persisted {
+		return node.hash
+	}
+	build(node)
+	if err := ndb.savePage(node.pageNodes(nil)); err != nil {
+		panic(err)
+	}
+	if node.version <= genesisVersion {
+		ndb.resetBatch()
+	}
+	return node.hash
+}
+
+// hasPages returns true if the database contains node pages. The caller must hold the mutex.
+func (ndb *nodeDB) hasPages() bool {
+	if !ndb.pages {
+		has, err := ndb.db.Has(pagesKeyFormat.Key())
+		if err != nil {
+			panic(err)
+		}
+		ndb.pages = has
+	}
+	return ndb.pages
+}
+
+// pagePruner keeps track of nodes deleted by pruning, and relocates the remaining nodes of pages
+// whose root is deleted into individual node records. Nodes in a page live at least as long as
+// the page root, since they are its descendants.
+type pagePruner struct {
+	deleted map[string]bool
+	pages   [][]byte // deleted page records
+}
+
+// newPagePruner creates a page pruner, or returns nil if the database has no pages.
+func (ndb *nodeDB) newPagePruner() *pagePruner {
+	if !ndb.hasPages() {
+		return nil
+	}
+	return &pagePruner{deleted: map[string]bool{}}
+}
+
+// deleteNode records a node deleted by pruning. It must be called before the deletion is written.
+func (p *pagePruner) deleteNode(ndb *nodeDB, hash []byte) {
+	if p == nil {
+		return
+	}
+	p.deleted[string(hash)] = true
+	buf, err := ndb.get(ndb.nodeKey(hash))
+	if err != nil {
+		panic(err)
+	}
+	if isPage(buf) {
+		p.pages = append(p.pages, buf)
+	}
+}
+
+// relocate writes the remaining nodes of deleted pages as individual node records.
+func (p *pagePruner) relocate(ndb *nodeDB) {
+	if p == nil {
+		return
+	}
+	isTrackable := ndb.db.IsTrackable()
+	for _, buf := range p.pages {
+		nodes, err := decodePage(buf)
+		if err != nil {
+			panic(err)
+		}
+		for _, node := range nodes[1:] {
+			if p.deleted[string(node.hash)] {
+				continue
+			}
+			var nodeBuf bytes.Buffer
+			nodeBuf.Grow(node.encodedSizeEx(isTrackable))
+			if err = node.writeBytesEx(&nodeBuf, isTrackable); err != nil {
+				panic(err)
+			}
+			if err = ndb.batch.Set(ndb.nodeKey(node.hash), nodeBuf.Bytes()); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// countingDB is a database which counts reads.
+type countingDB struct {
+	db.DB
+	gets int
+}
+
+func (d *countingDB) Get(key []byte) ([]byte, error) {
+	d.gets++
+	return d.DB.Get(key)
+}
+
+// nodeHashes returns the sorted hashes of all nodes in the database, whether in pages or not.
+func nodeHashes(ndb *nodeDB) []string {
+	hashes := []string{}
+	for _, node := range ndb.nodes() {
+		hashes = append(hashes, fmt.Sprintf("%X", node.hash))
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+// checkPages checks that all page references in the database point to pages containing the node.
+func checkPages(t *testing.T, memDB db.DB) (pages int) {
+	itr, err := db.IteratePrefix(memDB, nodeKeyFormat.Key())
+	require.NoError(t, err)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		if isPage(itr.Value()) {
+			pages++
+		}
+		root, ok := pageRefRoot(itr.Value())
+		if !ok {
+			continue
+		}
+		buf, err := memDB.Get(nodeKeyFormat.KeyBytes(root))
+		require.NoError(t, err)
+		require.True(t, isPage(buf), "page %X of node %X missing", root, itr.Key()[1:])
+		nodes, err := decodePage(buf)
+		require.NoError(t, err)
+		found := false
+		for _, node := range nodes {
+			found = found || bytes.Equal(node.hash, itr.Key()[1:])
+		}
+		require.True(t, found, "node %X not in page %X", itr.Key()[1:], root)
+	}
+	return pages
+}
+
+func TestNodePages(t *testing.T) {
+	plainDB, pagedDB := db.NewMemDB(), db.NewMemDB()
+	plain, err := NewMutableTree(plainDB, 0)
+	require.NoError(t, err)
+	paged, err := NewMutableTreeWithOpts(pagedDB, 0, &Options{NodePageLevels: 4})
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	for v := 1; v <= 20; v++ {
+		for i := 0; i < 100; i++ {
+			key := []byte(fmt.Sprintf("key-%04d", r.Intn(1000)))
+			if r.Intn(4) == 0 {
+				plain.Remove(key)
+				paged.Remove(key)
+			} else {
+				value := []byte(fmt.Sprintf("value-%v-%v", v, i))
+				plain.Set(key, value)
+				paged.Set(key, value)
+			}
+		}
+		plainHash, _, err := plain.SaveVersion()
+		require.NoError(t, err)
+		pagedHash, _, err := paged.SaveVersion()
+		require.NoError(t, err)
+		require.Equal(t, plainHash, pagedHash)
+	}
+	require.Greater(t, checkPages(t, pagedDB), 0)
+	require.Equal(t, nodeHashes(plain.ndb), nodeHashes(paged.ndb))
+
+	// Proofs are unchanged.
+	_, plainProof, err := plain.GetWithProof([]byte("key-0500"))
+	require.NoError(t, err)
+	_, pagedProof, err := paged.GetWithProof([]byte("key-0500"))
+	require.NoError(t, err)
+	require.Equal(t, plainProof, pagedProof)
+
+	// Pruning relocates the remaining nodes of pruned pages.
+	require.NoError(t, plain.DeleteVersion(3))
+	require.NoError(t, paged.DeleteVersion(3))
+	require.NoError(t, plain.DeleteVersionsRange(5, 15))
+	require.NoError(t, paged.DeleteVersionsRange(5, 15))
+	require.NoError(t, plain.DeleteVersions(1, 2, 4))
+	require.NoError(t, paged.DeleteVersions(1, 2, 4))
+	checkPages(t, pagedDB)
+	require.Equal(t, nodeHashes(plain.ndb), nodeHashes(paged.ndb))
+
+	// Cold reads of a reloaded tree need fewer database reads, and return the same data.
+	counting := func(memDB db.DB, opts *Options) (*MutableTree, *countingDB) {
+		countDB := &countingDB{DB: memDB}
+		tree, err := NewMutableTreeWithOpts(countDB, 10000, opts)
+		require.NoError(t, err)
+		_, err = tree.Load()
+		require.NoError(t, err)
+		return tree, countDB
+	}
+	plain, plainCount := counting(plainDB, nil)
+	paged, pagedCount := counting(pagedDB, &Options{NodePageLevels: 4})
+	plainCount.gets, pagedCount.gets = 0, 0
+	for _, version := range plain.AvailableVersions() {
+		plainTree, err := plain.GetImmutable(int64(version))
+		require.NoError(t, err)
+		pagedTree, err := paged.GetImmutable(int64(version))
+		require.NoError(t, err)
+		var plainItems, pagedItems []string
+		plainTree.Iterate(func(key, value []byte) bool {
+			plainItems = append(plainItems, string(key)+"="+string(value))
+			return false
+		})
+		pagedTree.Iterate(func(key, value []byte) bool {
+			pagedItems = append(pagedItems, string(key)+"="+string(value))
+			return false
+		})
+		require.Equal(t, plainItems, pagedItems)
+	}
+	require.Less(t, pagedCount.gets*2, plainCount.gets)
+}
+
+func TestNodePages_Toggle(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte("plain"))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	// Pages can be enabled for an existing database, and disabled again.
+	for v, levels := range []int{3, 0, 5} {
+		tree, err = NewMutableTreeWithOpts(memDB, 0, &Options{NodePageLevels: levels})
+		require.NoError(t, err)
+		_, err = tree.Load()
+		require.NoError(t, err)
+		for i := v; i < 100; i += 7 {
+			tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte(fmt.Sprintf("value-%v", v)))
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+		require.NoError(t, tree.DeleteVersion(tree.Version()-1))
+		checkPages(t, memDB)
+	}
+
+	tree, err = NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, 100, tree.Size())
+	round := tree.auditRound(rand.New(rand.NewSource(1)), 100)
+	require.EqualValues(t, 100, round.Samples)
+	require.Empty(t, round.Inconsistencies)
+}
+
+func TestNodePages_ExportImport(t *testing.T) {
+	source, err := NewMutableTreeWithOpts(db.NewMemDB(), 0, &Options{NodePageLevels: 4})
+	require.NoError(t, err)
+	for v := 1; v <= 3; v++ {
+		for i := 0; i < 500; i += v {
+			source.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte(fmt.Sprintf("value-%v", v)))
+		}
+		_, _, err = source.SaveVersion()
+		require.NoError(t, err)
+	}
+	exported := []*ExportNode{}
+	exporter := source.Export()
+	for {
+		node, err := exporter.Next()
+		if err == ExportDone {
+			break
+		}
+		require.NoError(t, err)
+		exported = append(exported, node)
+	}
+	exporter.Close()
+
+	for _, levels := range []int{0, 4} {
+		targetDB := db.NewMemDB()
+		target, err := NewMutableTreeWithOpts(targetDB, 0, &Options{NodePageLevels: levels})
+		require.NoError(t, err)
+		importer, err := target.Import(source.Version())
+		require.NoError(t, err)
+		for _, node := range exported {
+			require.NoError(t, importer.Add(node))
+		}
+		require.NoError(t, importer.Commit())
+		require.Equal(t, source.Hash(), target.Hash())
+		if levels > 0 {
+			require.Greater(t, checkPages(t, targetDB), 0)
+		} else {
+			require.Zero(t, checkPages(t, targetDB))
+		}
+		source.Iterate(func(key, value []byte) bool {
+			_, targetValue := target.Get(key)
+			require.Equal(t, value, targetValue)
+			return false
+		})
+	}
+}
+
+func TestNodePages_Rebuild(t *testing.T) {
+	plain, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	pagedDB := db.NewMemDB()
+	paged, err := NewMutableTreeWithOpts(pagedDB, 0, &Options{NodePageLevels: 3})
+	require.NoError(t, err)
+	for _, tree := range []*MutableTree{plain, paged} {
+		for i := 0; i < 300; i++ {
+			tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte("value"))
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	plainResult, err := plain.Rebuild()
+	require.NoError(t, err)
+	pagedResult, err := paged.Rebuild()
+	require.NoError(t, err)
+	require.Equal(t, plainResult, pagedResult)
+	require.NoError(t, paged.DeleteVersion(1))
+	require.Greater(t, checkPages(t, pagedDB), 0)
+
+	reloaded, err := NewMutableTree(pagedDB, 0)
+	require.NoError(t, err)
+	_, err = reloaded.Load()
+	require.NoError(t, err)
+	require.Equal(t, pagedResult.Hash, reloaded.Hash())
+	require.EqualValues(t, 300, reloaded.Size())
+}
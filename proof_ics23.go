@@ -1,10 +1,13 @@
 package iavl
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 
 	ics23 "github.com/confio/ics23/go"
+	"github.com/pkg/errors"
 )
 
 /*
@@ -24,6 +27,110 @@ func (t *ImmutableTree) GetMembershipProof(key []byte) (*ics23.CommitmentProof,
 	return proof, nil
 }
 
+// ValueHashSpec is the ICS23 proof spec of proofs from GetValueHashMembershipProof(). It is
+// ics23.IavlSpec with a prehashed value, i.e. the value of the proof is the SHA256 hash of the actual
+// value, and both specs produce the same root hash. Unlike for ics23.IavlSpec, ics23 does not check
+// that the proof ops are IAVL nodes, so proofs must be verified with VerifyValueHashMembership().
+var ValueHashSpec = &ics23.ProofSpec{
+	LeafSpec: &ics23.LeafOp{
+		Prefix:       []byte{0},
+		Hash:         ics23.HashOp_SHA256,
+		PrehashValue: ics23.HashOp_NO_HASH,
+		Length:       ics23.LengthOp_VAR_PROTO,
+	},
+	InnerSpec: ics23.IavlSpec.InnerSpec,
+}
+
+/*
+GetValueHashMembershipProof will produce a CommitmentProof that the given key exists in the iavl tree,
+with a value with the given SHA256 hash, without disclosing the value. The proof must be verified with
+VerifyValueHashMembership(), which checks the IAVL structure of the proof in addition to
+ics23.VerifyMembership() using ValueHashSpec and the value hash.
+If the key doesn't exist in the tree, this will return an error.
+*/
+func (t *ImmutableTree) GetValueHashMembershipProof(key []byte) (*ics23.CommitmentProof, error) {
+	exist, err := createExistenceProof(t, key)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(exist.Value)
+	exist.Value = h[:]
+	exist.Leaf.PrehashValue = ics23.HashOp_NO_HASH
+	proof := &ics23.CommitmentProof{
+		Proof: &ics23.CommitmentProof_Exist{
+			Exist: exist,
+		},
+	}
+	return proof, nil
+}
+
+// VerifyValueHashMembership verifies a proof from GetValueHashMembershipProof(), i.e. that the key
+// exists under the given root hash with a value with the given SHA256 hash.
+func VerifyValueHashMembership(root []byte, proof *ics23.CommitmentProof, key, valueHash []byte) bool {
+	if len(valueHash) != sha256.Size {
+		return false
+	}
+	// ics23 only checks the structure of IAVL ops for ics23.IavlSpec, so check it explicitly.
+	if exist := proof.GetExist(); exist == nil || validateIavlOps(exist) != nil {
+		return false
+	}
+	return ics23.VerifyMembership(ValueHashSpec, root, proof, key, valueHash)
+}
+
+// validateIavlOps checks that the ops of an existence proof are IAVL nodes, as produced by
+// convertLeafOp() and convertInnerOps(). Otherwise, bytes of the key or child hashes can be moved
+// into the prefixes, proving different keys or values under the same root hash.
+func validateIavlOps(exist *ics23.ExistenceProof) error {
+	if exist.Leaf == nil {
+		return errors.New("missing leaf op")
+	}
+	r := bytes.NewReader(exist.Leaf.Prefix)
+	height, size, version, err := readNodePrefix(r)
+	if err != nil {
+		return errors.Wrap(err, "leaf op")
+	}
+	if height != 0 || size != 1 || version < 0 || r.Len() != 0 {
+		return errors.New("invalid leaf op prefix")
+	}
+
+	for i, inner := range exist.Path {
+		r := bytes.NewReader(inner.Prefix)
+		height, size, version, err := readNodePrefix(r)
+		if err != nil {
+			return errors.Wrapf(err, "inner op %v", i)
+		}
+		if height <= int64(i) || size < 2 || version < 0 {
+			return errors.Errorf("invalid inner op %v prefix", i)
+		}
+		// The child hash is either the left one, followed by the right one in the suffix, or the
+		// right one, preceded by the left one in the prefix. Hashes are length-prefixed.
+		rest := inner.Prefix[len(inner.Prefix)-r.Len():]
+		switch {
+		case len(rest) == 1 && rest[0] == sha256.Size &&
+			len(inner.Suffix) == 1+sha256.Size && inner.Suffix[0] == sha256.Size:
+		case len(rest) == 2+sha256.Size && rest[0] == sha256.Size && rest[1+sha256.Size] == sha256.Size &&
+			len(inner.Suffix) == 0:
+		default:
+			return errors.Errorf("invalid inner op %v", i)
+		}
+	}
+	return nil
+}
+
+// readNodePrefix reads the height, size and version of a node from an IAVL op prefix.
+func readNodePrefix(r *bytes.Reader) (height, size, version int64, err error) {
+	if height, err = binary.ReadVarint(r); err != nil {
+		return 0, 0, 0, err
+	}
+	if size, err = binary.ReadVarint(r); err != nil {
+		return 0, 0, 0, err
+	}
+	if version, err = binary.ReadVarint(r); err != nil {
+		return 0, 0, 0, err
+	}
+	return height, size, version, nil
+}
+
 /*
 GetNonMembershipProof will produce a CommitmentProof that the given key doesn't exist in the iavl tree.
 If the key exists in the tree, this will return an error.
@@ -2,6 +2,7 @@ package iavl
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -59,6 +60,97 @@ func TestGetMembership(t *testing.T) {
 	}
 }
 
+func TestGetValueHashMembership(t *testing.T) {
+	tree, allkeys, err := BuildTree(200)
+	require.NoError(t, err)
+	root := tree.Hash()
+
+	for _, loc := range []Where{Left, Middle, Right} {
+		key := GetKey(allkeys, loc)
+		_, value := tree.Get(key)
+		h := sha256.Sum256(value)
+		valueHash := h[:]
+
+		proof, err := tree.GetValueHashMembershipProof(key)
+		require.NoError(t, err)
+		require.Equal(t, valueHash, proof.GetExist().Value)
+		require.True(t, VerifyValueHashMembership(root, proof, key, valueHash))
+		require.True(t, ics23.VerifyMembership(ValueHashSpec, root, proof, key, valueHash))
+
+		// The value hash can't be swapped for the value, or verified with the regular spec.
+		require.False(t, VerifyValueHashMembership(root, proof, key, value))
+		require.False(t, ics23.VerifyMembership(ics23.IavlSpec, root, proof, key, value))
+		require.False(t, ics23.VerifyMembership(ics23.IavlSpec, root, proof, key, valueHash))
+		wrongHash := append([]byte{}, valueHash...)
+		wrongHash[0] ^= 1
+		require.False(t, VerifyValueHashMembership(root, proof, key, wrongHash))
+
+		// Regular proofs can't be verified with the value hash spec either.
+		regular, err := tree.GetMembershipProof(key)
+		require.NoError(t, err)
+		require.False(t, VerifyValueHashMembership(root, regular, key, valueHash))
+	}
+
+	_, err = tree.GetValueHashMembershipProof(GetNonKey(allkeys, Middle))
+	require.Error(t, err)
+}
+
+func TestVerifyValueHashMembership_Forged(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		tree.Set([]byte{byte('a' + i)}, []byte{byte(i)})
+	}
+	// The first key byte is a valid length prefix of the rest of the key.
+	key := []byte{2, 'a', 'b'}
+	tree.Set(key, []byte("value"))
+	root, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	valueHash := sha256.Sum256([]byte("value"))
+
+	forge := func(fn func(exist *ics23.ExistenceProof)) *ics23.CommitmentProof {
+		proof, err := tree.GetValueHashMembershipProof(key)
+		require.NoError(t, err)
+		fn(proof.GetExist())
+		return proof
+	}
+
+	// Moving the length prefix of the key into the leaf prefix proves a different key under the
+	// same root, which ics23 accepts for ValueHashSpec.
+	forged := forge(func(exist *ics23.ExistenceProof) {
+		exist.Leaf.Prefix = append(exist.Leaf.Prefix, byte(len(key)))
+		exist.Key = key[1:]
+	})
+	require.True(t, ics23.VerifyMembership(ValueHashSpec, root, forged, key[1:], valueHash[:]))
+	require.False(t, VerifyValueHashMembership(root, forged, key[1:], valueHash[:]))
+
+	// Inner op prefixes which are not IAVL nodes are rejected, even if they hash to the root.
+	for name, fn := range map[string]func(inner *ics23.InnerOp){
+		"extra byte": func(inner *ics23.InnerOp) {
+			inner.Prefix = append(inner.Prefix[:len(inner.Prefix):len(inner.Prefix)], 0)
+			inner.Suffix = nil
+		},
+		"zero size": func(inner *ics23.InnerOp) {
+			inner.Prefix = append([]byte{inner.Prefix[0], 0}, inner.Prefix[2:]...)
+		},
+		"negative size": func(inner *ics23.InnerOp) {
+			inner.Prefix = append([]byte{inner.Prefix[0], 1}, inner.Prefix[2:]...)
+		},
+		"moved suffix": func(inner *ics23.InnerOp) {
+			inner.Prefix = append(inner.Prefix[:len(inner.Prefix):len(inner.Prefix)], inner.Suffix...)
+			inner.Suffix = nil
+		},
+	} {
+		forged := forge(func(exist *ics23.ExistenceProof) { fn(exist.Path[0]) })
+		forgedRoot, err := forged.GetExist().Calculate()
+		require.NoError(t, err, name)
+		require.True(t, ics23.VerifyMembership(ValueHashSpec, forgedRoot, forged, key, valueHash[:]), name)
+		require.False(t, VerifyValueHashMembership(forgedRoot, forged, key, valueHash[:]), name)
+	}
+
+	require.True(t, VerifyValueHashMembership(root, forge(func(*ics23.ExistenceProof) {}), key, valueHash[:]))
+}
+
 func TestGetNonMembership(t *testing.T) {
 	cases := map[string]struct {
 		size int
@@ -116,6 +116,30 @@ func (proof *RangeProof) VerifyItem(key, value []byte) error {
 	return nil
 }
 
+// VerifyItemValueHash verifies that a key has a value with the given SHA256 hash, without
+// needing the value itself, e.g. for proofs from GetWithValueHashProof().
+// Does not assume that the proof itself is valid, call Verify() first.
+func (proof *RangeProof) VerifyItemValueHash(key, valueHash []byte) error {
+	if proof == nil {
+		return errors.Wrap(ErrInvalidProof, "proof is nil")
+	}
+	if !proof.rootVerified {
+		return errors.New("must call Verify(root) first")
+	}
+	leaves := proof.Leaves
+	i := sort.Search(len(leaves), func(i int) bool {
+		return bytes.Compare(key, leaves[i].Key) <= 0
+	})
+	if i >= len(leaves) || !bytes.Equal(leaves[i].Key, key) {
+		return errors.Wrap(ErrInvalidProof, "leaf key not found in proof")
+	}
+	if !bytes.Equal(leaves[i].ValueHash, valueHash) {
+		return errors.Wrap(ErrInvalidProof, "leaf value hash not same")
+	}
+
+	return nil
+}
+
 // Verify that proof is valid absence proof for key.
 // Does not assume that the proof itself is valid.
 // For that, use Verify(root).
@@ -532,6 +556,19 @@ func (t *ImmutableTree) GetWithProof(key []byte) (value []byte, proof *RangeProo
 	return nil, proof, nil
 }
 
+// GetWithValueHashProof gets the SHA256 hash of the value under the key if it exists, or returns
+// nil. A proof of existence or absence is returned alongside the value hash. Since proofs only
+// contain value hashes, the value is not disclosed to the verifier, who can verify the value hash
+// with VerifyItemValueHash().
+func (t *ImmutableTree) GetWithValueHashProof(key []byte) (valueHash []byte, proof *RangeProof, err error) {
+	value, proof, err := t.GetWithProof(key)
+	if err != nil || value == nil {
+		return nil, proof, err
+	}
+	h := sha256.Sum256(value)
+	return h[:], proof, nil
+}
+
 // GetRangeWithProof gets key/value pairs within the specified range and limit.
 func (t *ImmutableTree) GetRangeWithProof(startKey []byte, endKey []byte, limit int) (keys, values [][]byte, proof *RangeProof, err error) {
 	proof, keys, values, err = t.getRangeProof(startKey, endKey, limit)
@@ -552,6 +589,20 @@ func (tree *MutableTree) GetVersionedWithProof(key []byte, version int64) ([]byt
 	return nil, nil, errors.Wrap(ErrVersionDoesNotExist, "")
 }
 
+// GetVersionedWithValueHashProof gets the SHA256 hash of the value under the key at the
+// specified version if it exists, or returns nil. See GetWithValueHashProof().
+func (tree *MutableTree) GetVersionedWithValueHashProof(key []byte, version int64) ([]byte, *RangeProof, error) {
+	if tree.VersionExists(version) {
+		t, err := tree.GetImmutable(version)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return t.GetWithValueHashProof(key)
+	}
+	return nil, nil, errors.Wrap(ErrVersionDoesNotExist, "")
+}
+
 // GetVersionedRangeWithProof gets key/value pairs within the specified range
 // and limit.
 func (tree *MutableTree) GetVersionedRangeWithProof(startKey, endKey []byte, limit int, version int64) (
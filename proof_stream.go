@@ -0,0 +1,264 @@
+package iavl
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/pkg/errors"
+
+	iavlproto "github.com/cosmos/iavl/proto"
+)
+
+// RangeProofItem is a single leaf of a streamed range proof. The path of the first item is the
+// path from the root to the leaf, like RangeProof.LeftPath. The path of every following item
+// leads from the right sibling of an inner node on a previous path down to the leaf, like the
+// entries of RangeProof.InnerNodes.
+//
+// Range proofs are streamed with ImmutableTree.StreamRangeWithProof and verified with
+// RangeProofVerifier, which only need memory proportional to the tree height rather than to the
+// size of the range.
+type RangeProofItem struct {
+	Path PathToLeaf    `json:"path"`
+	Leaf ProofLeafNode `json:"leaf"`
+}
+
+// ToProto converts the item to a Protobuf representation.
+func (item RangeProofItem) ToProto() *iavlproto.RangeProofItem {
+	pb := &iavlproto.RangeProofItem{
+		Path: &iavlproto.PathToLeaf{Inners: make([]*iavlproto.ProofInnerNode, 0, len(item.Path))},
+		Leaf: item.Leaf.toProto(),
+	}
+	for _, inner := range item.Path {
+		pb.Path.Inners = append(pb.Path.Inners, inner.toProto())
+	}
+	return pb
+}
+
+// RangeProofItemFromProto converts a Protobuf RangeProofItem to a RangeProofItem.
+func RangeProofItemFromProto(pbItem *iavlproto.RangeProofItem) (RangeProofItem, error) {
+	item := RangeProofItem{}
+	if pbItem == nil {
+		return item, errors.New("range proof item cannot be nil")
+	}
+	if pbItem.Path != nil {
+		for _, pbInner := range pbItem.Path.Inners {
+			inner, err := proofInnerNodeFromProto(pbInner)
+			if err != nil {
+				return item, err
+			}
+			item.Path = append(item.Path, inner)
+		}
+	}
+	leaf, err := proofLeafNodeFromProto(pbItem.Leaf)
+	if err != nil {
+		return item, err
+	}
+	item.Leaf = leaf
+	return item, nil
+}
+
+// StreamRangeWithProof streams the key/value pairs in the range [start, end) along with a range
+// proof, calling fn with each proof item in ascending key order. The value is the value of the
+// leaf, and inRange is false for the leaf preceding start or the first leaf at or after end, which
+// are included in the proof to show that the range is complete. Nil start or end keys are
+// unbounded. Returning an error from fn stops the stream and returns the error.
+//
+// Unlike GetRangeWithProof, the proof is never held in memory, so ranges of any size can be
+// proven. The items can be verified with a RangeProofVerifier.
+func (t *ImmutableTree) StreamRangeWithProof(start, end []byte,
+	fn func(item RangeProofItem, value []byte, inRange bool) error) error {
+
+	if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+		return errors.Wrap(ErrInvalidInputs, "start key must be before end key")
+	}
+	if t.root == nil {
+		return nil
+	}
+	t.root.hashWithCount() // Ensure that all hashes are calculated.
+
+	// The first leaf is the leaf at or before start, or the first leaf if there is none.
+	path, node, _ := t.root.PathToLeaf(t, start)
+
+	// pending contains the inner nodes on the path to the current leaf whose right subtrees
+	// have not been visited yet, ordered from the root.
+	var pending []*Node
+	for n := t.root; n.height > 0; {
+		if bytes.Compare(start, n.key) < 0 {
+			pending = append(pending, n)
+			n = n.getLeftNode(t)
+		} else {
+			n = n.getRightNode(t)
+		}
+	}
+
+	for {
+		inRange := (start == nil || bytes.Compare(start, node.key) <= 0) &&
+			(end == nil || bytes.Compare(node.key, end) < 0)
+		h := sha256.Sum256(node.value)
+		item := RangeProofItem{
+			Path: path,
+			Leaf: ProofLeafNode{Key: node.key, ValueHash: h[:], Version: node.version},
+		}
+		if err := fn(item, node.value, inRange); err != nil {
+			return err
+		}
+		if end != nil && bytes.Compare(node.key, end) >= 0 || len(pending) == 0 {
+			return nil
+		}
+
+		// Descend to the leftmost leaf of the next right subtree.
+		parent := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		path = nil
+		for node = parent.getRightNode(t); node.height > 0; node = node.getLeftNode(t) {
+			path = append(path, ProofInnerNode{
+				Height:  node.height,
+				Size:    node.size,
+				Version: node.version,
+				Left:    nil,
+				Right:   node.rightHash,
+			})
+			pending = append(pending, node)
+		}
+	}
+}
+
+// StreamVersionedRangeWithProof streams the key/value pairs in the range [start, end) at the
+// specified version along with a range proof, see ImmutableTree.StreamRangeWithProof.
+func (tree *MutableTree) StreamVersionedRangeWithProof(start, end []byte, version int64,
+	fn func(item RangeProofItem, value []byte, inRange bool) error) error {
+
+	if !tree.VersionExists(version) {
+		return errors.Wrap(ErrVersionDoesNotExist, "")
+	}
+	t, err := tree.GetImmutable(version)
+	if err != nil {
+		return err
+	}
+	return t.StreamRangeWithProof(start, end, fn)
+}
+
+// rangeProofFrame is a path of a streamed range proof whose right subtrees have not all been
+// verified yet.
+type rangeProofFrame struct {
+	path      PathToLeaf // the remaining path, without the inner nodes already visited
+	rightmost bool       // whether the path starts at a rightmost node of the tree
+}
+
+// RangeProofVerifier verifies a streamed range proof of the key/value pairs in [start, end) one
+// item at a time, as produced by ImmutableTree.StreamRangeWithProof. It only holds the paths of the
+// current leaf and its ancestors, so its memory use is bounded by the tree height.
+//
+// Each item is checked against the root hash as it is added, so the values of added in-range
+// items can be trusted once Add succeeds. However, the range is only known to be complete once
+// Finish succeeds.
+type RangeProofVerifier struct {
+	root  []byte
+	start []byte
+	end   []byte
+
+	frames        []rangeProofFrame
+	next          []byte // the hash of the next item's subtree, or nil if the tree has no more leaves
+	nextRightmost bool   // whether the next item's subtree is a rightmost node of the tree
+	items         int64
+	lastKey       []byte
+	startCovered  bool // whether the first item is at or before the start key, or the first leaf
+	treeEnd       bool // whether the last item is the last leaf of the tree
+	pastEnd       bool // whether an item at or after the end key was added
+}
+
+// NewRangeProofVerifier creates a verifier for a streamed range proof of the key/value pairs in
+// [start, end) of a tree with the given root hash. Nil start or end keys are unbounded.
+func NewRangeProofVerifier(root, start, end []byte) *RangeProofVerifier {
+	return &RangeProofVerifier{root: root, start: start, end: end}
+}
+
+// Items returns the number of items added, including items outside of the range.
+func (v *RangeProofVerifier) Items() int64 {
+	return v.items
+}
+
+// Add verifies the next item of the proof. The value must be given for items in the range, and is
+// ignored otherwise.
+func (v *RangeProofVerifier) Add(item RangeProofItem, value []byte) error {
+	key := item.Leaf.Key
+	switch {
+	case v.pastEnd:
+		return errors.Wrap(ErrInvalidProof, "item after range end")
+	case v.items > 0 && bytes.Compare(key, v.lastKey) <= 0:
+		return errors.Wrapf(ErrInvalidProof, "key %X is not after previous key %X", key, v.lastKey)
+	case v.items > 0 && v.start != nil && bytes.Compare(key, v.start) < 0:
+		return errors.Wrapf(ErrInvalidProof, "key %X is before range start", key)
+	case v.items > 0 && v.next == nil:
+		return errors.Wrap(ErrInvalidProof, "left over leaves -- malformed proof")
+	case v.items > 0 && !item.Path.isLeftmost():
+		// The item must be the leftmost leaf of the subtree, or leaves would be skipped.
+		return errors.Wrapf(ErrInvalidProof, "item with key %X skips leaves", key)
+	}
+
+	inRange := (v.start == nil || bytes.Compare(v.start, key) <= 0) &&
+		(v.end == nil || bytes.Compare(key, v.end) < 0)
+	if inRange {
+		h := sha256.Sum256(value)
+		if !bytes.Equal(h[:], item.Leaf.ValueHash) {
+			return errors.Wrapf(ErrInvalidProof, "leaf value hash for key %X does not match value", key)
+		}
+	}
+
+	hash := pathWithLeaf{Path: item.Path, Leaf: item.Leaf}.computeRootHash()
+	rightmost := true
+	if v.items == 0 {
+		if !bytes.Equal(hash, v.root) {
+			return errors.Wrapf(ErrInvalidRoot, "root hash %X doesn't match, got %X", v.root, hash)
+		}
+		v.startCovered = v.start != nil && bytes.Compare(key, v.start) <= 0 || item.Path.isLeftmost()
+	} else {
+		if !bytes.Equal(hash, v.next) {
+			return errors.Wrapf(ErrInvalidRoot, "intermediate root hash %X doesn't match, got %X", v.next, hash)
+		}
+		rightmost = v.nextRightmost
+	}
+	v.items++
+	v.lastKey = key
+	v.treeEnd = rightmost && item.Path.isRightmost()
+	v.pastEnd = v.end != nil && bytes.Compare(key, v.end) >= 0
+
+	// Find the next unvisited right subtree, dropping the leaf-most inner nodes from the paths
+	// until one with a right hash is found.
+	v.frames = append(v.frames, rangeProofFrame{path: item.Path, rightmost: rightmost})
+	v.next = nil
+	for len(v.frames) > 0 && v.next == nil {
+		frame := &v.frames[len(v.frames)-1]
+		for len(frame.path) > 0 && v.next == nil {
+			last := frame.path[len(frame.path)-1]
+			frame.path = frame.path[:len(frame.path)-1]
+			if len(last.Right) > 0 {
+				v.next = last.Right
+				v.nextRightmost = frame.rightmost && frame.path.isRightmost()
+			}
+		}
+		if len(frame.path) == 0 {
+			v.frames = v.frames[:len(v.frames)-1]
+		}
+	}
+	return nil
+}
+
+// Finish checks that the added items cover the entire range, and must be called once all items
+// have been added.
+func (v *RangeProofVerifier) Finish() error {
+	if v.items == 0 {
+		// Only an empty tree has no leaves.
+		if h := sha256.Sum256(nil); !bytes.Equal(v.root, h[:]) {
+			return errors.Wrap(ErrInvalidProof, "no leaves")
+		}
+		return nil
+	}
+	if !v.startCovered {
+		return errors.Wrap(ErrInvalidProof, "proof does not cover range start")
+	}
+	if !v.pastEnd && !v.treeEnd {
+		return errors.Wrap(ErrInvalidProof, "proof does not cover range end")
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// streamedItem is a streamed range proof item with its value.
+type streamedItem struct {
+	item    RangeProofItem
+	value   []byte
+	inRange bool
+}
+
+// streamRange streams a range proof into a slice.
+func streamRange(t *testing.T, tree *ImmutableTree, start, end []byte) []streamedItem {
+	var items []streamedItem
+	err := tree.StreamRangeWithProof(start, end, func(item RangeProofItem, value []byte, inRange bool) error {
+		items = append(items, streamedItem{item, value, inRange})
+		return nil
+	})
+	require.NoError(t, err)
+	return items
+}
+
+// verifyStream verifies streamed range proof items.
+func verifyStream(root, start, end []byte, items []streamedItem) error {
+	verifier := NewRangeProofVerifier(root, start, end)
+	for _, item := range items {
+		if err := verifier.Add(item.item, item.value); err != nil {
+			return err
+		}
+	}
+	return verifier.Finish()
+}
+
+func TestStreamRangeWithProof(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	for i := 0; i < 200; i += 2 {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte(fmt.Sprintf("value-%v", i)))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	root := tree.Hash()
+
+	testcases := []struct {
+		start, end string
+		keys       int
+	}{
+		{"", "", 100},
+		{"key-000", "key-010", 5},
+		{"key-001", "key-010", 4},
+		{"key-001", "key-011", 5},
+		{"key-150", "", 25},
+		{"", "key-050", 25},
+		{"a", "b", 0},
+		{"x", "", 0},
+		{"key-051", "key-052", 0},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(fmt.Sprintf("%q-%q", tc.start, tc.end), func(t *testing.T) {
+			var start, end []byte
+			if tc.start != "" {
+				start = []byte(tc.start)
+			}
+			if tc.end != "" {
+				end = []byte(tc.end)
+			}
+			items := streamRange(t, tree.ImmutableTree, start, end)
+			require.NoError(t, verifyStream(root, start, end, items))
+
+			// The streamed items make up the same proof as GetRangeWithProof.
+			keys, values, proof, err := tree.GetRangeWithProof(start, end, 0)
+			require.NoError(t, err)
+			require.Len(t, keys, tc.keys)
+			var streamedKeys, streamedValues [][]byte
+			streamed := &RangeProof{LeftPath: items[0].item.Path}
+			for i, item := range items {
+				if i > 0 {
+					streamed.InnerNodes = append(streamed.InnerNodes, item.item.Path)
+				}
+				streamed.Leaves = append(streamed.Leaves, item.item.Leaf)
+				if item.inRange {
+					streamedKeys = append(streamedKeys, item.item.Leaf.Key)
+					streamedValues = append(streamedValues, item.value)
+				}
+			}
+			require.Equal(t, keys, streamedKeys)
+			require.Equal(t, values, streamedValues)
+			require.Equal(t, proof.LeftPath, streamed.LeftPath)
+			require.Equal(t, proof.Leaves, streamed.Leaves[:len(proof.Leaves)])
+			require.NoError(t, streamed.Verify(root))
+		})
+	}
+}
+
+func TestStreamRangeWithProof_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	randKey := func() []byte {
+		key := make([]byte, 1+r.Intn(3))
+		for i := range key {
+			key[i] = byte('a' + r.Intn(3))
+		}
+		return key
+	}
+	for i := 0; i < 30; i++ {
+		tree.Set(randKey(), []byte{byte(i)})
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	root := tree.Hash()
+
+	for i := 0; i < 200; i++ {
+		start, end := randKey(), randKey()
+		if r.Intn(5) == 0 {
+			start = nil
+		}
+		if r.Intn(5) == 0 {
+			end = nil
+		}
+		if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+			continue
+		}
+		items := streamRange(t, tree.ImmutableTree, start, end)
+		require.NoError(t, verifyStream(root, start, end, items))
+
+		var keys, streamedKeys [][]byte
+		tree.IterateRange(start, end, true, func(key, value []byte) bool {
+			keys = append(keys, key)
+			return false
+		})
+		for _, item := range items {
+			if item.inRange {
+				streamedKeys = append(streamedKeys, item.item.Leaf.Key)
+			}
+		}
+		require.Equal(t, keys, streamedKeys, "range %q-%q", start, end)
+	}
+}
+
+func TestRangeProofVerifier(t *testing.T) {
+	key := func(i int) []byte {
+		return []byte(fmt.Sprintf("%04d", i))
+	}
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		tree.Set(key(i), []byte(fmt.Sprintf("value-%v", i)))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	root := tree.Hash()
+	start, end := key(100), key(900)
+	items := streamRange(t, tree.ImmutableTree, start, end)
+	require.Len(t, items, 801)
+
+	// The verifier state is bounded by the tree height.
+	verifier := NewRangeProofVerifier(root, start, end)
+	for _, item := range items {
+		require.NoError(t, verifier.Add(item.item, item.value))
+		require.LessOrEqual(t, len(verifier.frames), int(tree.Height()))
+	}
+	require.NoError(t, verifier.Finish())
+	require.EqualValues(t, 801, verifier.Items())
+
+	tamper := func(fn func(items []streamedItem) []streamedItem) []streamedItem {
+		tampered := make([]streamedItem, len(items))
+		copy(tampered, items)
+		return fn(tampered)
+	}
+	testcases := map[string][]streamedItem{
+		"wrong value": tamper(func(items []streamedItem) []streamedItem {
+			items[400].value = []byte("foo")
+			return items
+		}),
+		"missing item": tamper(func(items []streamedItem) []streamedItem {
+			return append(items[:400], items[401:]...)
+		}),
+		"swapped items": tamper(func(items []streamedItem) []streamedItem {
+			items[400], items[401] = items[401], items[400]
+			return items
+		}),
+		"duplicate item": tamper(func(items []streamedItem) []streamedItem {
+			return append(items[:401], items[400:]...)
+		}),
+		"truncated": tamper(func(items []streamedItem) []streamedItem {
+			return items[:800]
+		}),
+		"missing start": tamper(func(items []streamedItem) []streamedItem {
+			return items[1:]
+		}),
+		"extra item": append(items, streamRange(t, tree.ImmutableTree, key(901), key(902))[0]),
+		"wrong leaf version": tamper(func(items []streamedItem) []streamedItem {
+			items[300].item.Leaf.Version++
+			return items
+		}),
+		"skipped leaves": tamper(func(items []streamedItem) []streamedItem {
+			// Replaces an item with the path to the next leaf in its subtree.
+			item := items[512]
+			require.NotEmpty(t, item.item.Path)
+			next := streamRange(t, tree.ImmutableTree, item.item.Leaf.Key, nil)[1]
+			path := append(PathToLeaf{}, item.item.Path...)
+			last := &path[len(path)-1]
+			last.Left = (pathWithLeaf{Leaf: item.item.Leaf}).computeRootHash()
+			last.Right = nil
+			items[512] = streamedItem{RangeProofItem{Path: path, Leaf: next.item.Leaf}, next.value, true}
+			return append(items[:513], items[514:]...)
+		}),
+		"empty": {},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, verifyStream(root, start, end, tc))
+		})
+	}
+	require.Error(t, verifyStream([]byte("foo"), start, end, items))
+	require.Error(t, verifyStream(root, key(50), end, items))
+	require.Error(t, verifyStream(root, start, key(950), items))
+	require.NoError(t, verifyStream(root, start, key(899), items[:800]))
+
+	// An empty tree has no items.
+	empty, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	require.Empty(t, streamRange(t, empty.ImmutableTree, nil, nil))
+	require.NoError(t, verifyStream(empty.Hash(), nil, nil, nil))
+}
@@ -3,6 +3,7 @@ package iavl
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"testing"
 
 	proto "github.com/gogo/protobuf/proto"
@@ -48,6 +49,46 @@ func TestTreeGetWithProof(t *testing.T) {
 	require.NoError(err, "%+v", err)
 }
 
+func TestTreeGetWithValueHashProof(t *testing.T) {
+	tree, err := getTestTree(0)
+	require.NoError(t, err)
+	require := require.New(t)
+	for _, ikey := range []byte{0x11, 0x32, 0x50, 0x72, 0x99} {
+		tree.Set([]byte{ikey}, []byte(cmn.RandStr(8)))
+	}
+	_, version, err := tree.SaveVersion()
+	require.NoError(err)
+	root := tree.Hash()
+
+	key := []byte{0x32}
+	_, value := tree.Get(key)
+	valueHash, proof, err := tree.GetWithValueHashProof(key)
+	require.NoError(err)
+	h := sha256.Sum256(value)
+	require.Equal(h[:], valueHash)
+	err = proof.VerifyItemValueHash(key, valueHash)
+	require.Error(err) // Verifying item before calling Verify(root)
+	require.NoError(proof.Verify(root))
+	require.NoError(proof.VerifyItemValueHash(key, valueHash))
+	require.NoError(proof.VerifyItem(key, value))
+	require.Error(proof.VerifyItemValueHash(key, value))
+	require.Error(proof.VerifyItemValueHash([]byte{0x50}, valueHash))
+
+	// The proof doesn't contain the value.
+	pbProof, err := proto.Marshal(proof.ToProto())
+	require.NoError(err)
+	require.False(bytes.Contains(pbProof, value))
+
+	valueHash, proof, err = tree.GetVersionedWithValueHashProof([]byte{0x1}, version)
+	require.NoError(err)
+	require.Nil(valueHash)
+	require.NoError(proof.Verify(root))
+	require.NoError(proof.VerifyAbsence([]byte{0x1}))
+
+	_, _, err = tree.GetVersionedWithValueHashProof(key, version+1)
+	require.Error(err)
+}
+
 func TestTreeKeyExistsProof(t *testing.T) {
 	tree, err := getTestTree(0)
 	require.NoError(t, err)
@@ -212,6 +212,58 @@ func (m *GetByIndexRequest) GetIndex() int64 {
 	return 0
 }
 
+type GetByIndexVersionedRequest struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Index   int64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *GetByIndexVersionedRequest) Reset()         { *m = GetByIndexVersionedRequest{} }
+func (m *GetByIndexVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*GetByIndexVersionedRequest) ProtoMessage()    {}
+func (*GetByIndexVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{4}
+}
+func (m *GetByIndexVersionedRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetByIndexVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetByIndexVersionedRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetByIndexVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByIndexVersionedRequest.Merge(m, src)
+}
+func (m *GetByIndexVersionedRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetByIndexVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByIndexVersionedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetByIndexVersionedRequest proto.InternalMessageInfo
+
+func (m *GetByIndexVersionedRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetByIndexVersionedRequest) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
 type GetVersionedRequest struct {
 	Version int64  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 	Key     []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
@@ -221,7 +273,7 @@ func (m *GetVersionedRequest) Reset()         { *m = GetVersionedRequest{} }
 func (m *GetVersionedRequest) String() string { return proto.CompactTextString(m) }
 func (*GetVersionedRequest) ProtoMessage()    {}
 func (*GetVersionedRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{4}
+	return fileDescriptor_5cad6b4fafc2c047, []int{5}
 }
 func (m *GetVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -273,7 +325,7 @@ func (m *SetRequest) Reset()         { *m = SetRequest{} }
 func (m *SetRequest) String() string { return proto.CompactTextString(m) }
 func (*SetRequest) ProtoMessage()    {}
 func (*SetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{5}
+	return fileDescriptor_5cad6b4fafc2c047, []int{6}
 }
 func (m *SetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -324,7 +376,7 @@ func (m *RemoveRequest) Reset()         { *m = RemoveRequest{} }
 func (m *RemoveRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveRequest) ProtoMessage()    {}
 func (*RemoveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{6}
+	return fileDescriptor_5cad6b4fafc2c047, []int{7}
 }
 func (m *RemoveRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -368,7 +420,7 @@ func (m *DeleteVersionRequest) Reset()         { *m = DeleteVersionRequest{} }
 func (m *DeleteVersionRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteVersionRequest) ProtoMessage()    {}
 func (*DeleteVersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{7}
+	return fileDescriptor_5cad6b4fafc2c047, []int{8}
 }
 func (m *DeleteVersionRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -412,7 +464,7 @@ func (m *VersionExistsRequest) Reset()         { *m = VersionExistsRequest{} }
 func (m *VersionExistsRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionExistsRequest) ProtoMessage()    {}
 func (*VersionExistsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{8}
+	return fileDescriptor_5cad6b4fafc2c047, []int{9}
 }
 func (m *VersionExistsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -457,7 +509,7 @@ func (m *VerifyRequest) Reset()         { *m = VerifyRequest{} }
 func (m *VerifyRequest) String() string { return proto.CompactTextString(m) }
 func (*VerifyRequest) ProtoMessage()    {}
 func (*VerifyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{9}
+	return fileDescriptor_5cad6b4fafc2c047, []int{10}
 }
 func (m *VerifyRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,6 +552,58 @@ func (m *VerifyRequest) GetProof() *RangeProof {
 	return nil
 }
 
+type VerifyVersionedRequest struct {
+	Version int64       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Proof   *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *VerifyVersionedRequest) Reset()         { *m = VerifyVersionedRequest{} }
+func (m *VerifyVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyVersionedRequest) ProtoMessage()    {}
+func (*VerifyVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{11}
+}
+func (m *VerifyVersionedRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyVersionedRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerifyVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyVersionedRequest.Merge(m, src)
+}
+func (m *VerifyVersionedRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyVersionedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyVersionedRequest proto.InternalMessageInfo
+
+func (m *VerifyVersionedRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *VerifyVersionedRequest) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 type VerifyItemRequest struct {
 	RootHash []byte      `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
 	Proof    *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
@@ -511,7 +615,7 @@ func (m *VerifyItemRequest) Reset()         { *m = VerifyItemRequest{} }
 func (m *VerifyItemRequest) String() string { return proto.CompactTextString(m) }
 func (*VerifyItemRequest) ProtoMessage()    {}
 func (*VerifyItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{10}
+	return fileDescriptor_5cad6b4fafc2c047, []int{12}
 }
 func (m *VerifyItemRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -568,24 +672,25 @@ func (m *VerifyItemRequest) GetValue() []byte {
 	return nil
 }
 
-type VerifyAbsenceRequest struct {
-	RootHash []byte      `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
-	Proof    *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
-	Key      []byte      `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+type VerifyItemVersionedRequest struct {
+	Version int64       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Proof   *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Key     []byte      `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value   []byte      `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (m *VerifyAbsenceRequest) Reset()         { *m = VerifyAbsenceRequest{} }
-func (m *VerifyAbsenceRequest) String() string { return proto.CompactTextString(m) }
-func (*VerifyAbsenceRequest) ProtoMessage()    {}
-func (*VerifyAbsenceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{11}
+func (m *VerifyItemVersionedRequest) Reset()         { *m = VerifyItemVersionedRequest{} }
+func (m *VerifyItemVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyItemVersionedRequest) ProtoMessage()    {}
+func (*VerifyItemVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{13}
 }
-func (m *VerifyAbsenceRequest) XXX_Unmarshal(b []byte) error {
+func (m *VerifyItemVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *VerifyAbsenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyItemVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_VerifyAbsenceRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyItemVersionedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -595,55 +700,65 @@ func (m *VerifyAbsenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *VerifyAbsenceRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VerifyAbsenceRequest.Merge(m, src)
+func (m *VerifyItemVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyItemVersionedRequest.Merge(m, src)
 }
-func (m *VerifyAbsenceRequest) XXX_Size() int {
+func (m *VerifyItemVersionedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *VerifyAbsenceRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_VerifyAbsenceRequest.DiscardUnknown(m)
+func (m *VerifyItemVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyItemVersionedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VerifyAbsenceRequest proto.InternalMessageInfo
+var xxx_messageInfo_VerifyItemVersionedRequest proto.InternalMessageInfo
 
-func (m *VerifyAbsenceRequest) GetRootHash() []byte {
+func (m *VerifyItemVersionedRequest) GetVersion() int64 {
 	if m != nil {
-		return m.RootHash
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-func (m *VerifyAbsenceRequest) GetProof() *RangeProof {
+func (m *VerifyItemVersionedRequest) GetProof() *RangeProof {
 	if m != nil {
 		return m.Proof
 	}
 	return nil
 }
 
-func (m *VerifyAbsenceRequest) GetKey() []byte {
+func (m *VerifyItemVersionedRequest) GetKey() []byte {
 	if m != nil {
 		return m.Key
 	}
 	return nil
 }
 
-type LoadVersionRequest struct {
-	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+func (m *VerifyItemVersionedRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
 
-func (m *LoadVersionRequest) Reset()         { *m = LoadVersionRequest{} }
-func (m *LoadVersionRequest) String() string { return proto.CompactTextString(m) }
-func (*LoadVersionRequest) ProtoMessage()    {}
-func (*LoadVersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{12}
+type VerifyItemValueHashRequest struct {
+	RootHash  []byte      `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Proof     *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Key       []byte      `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	ValueHash []byte      `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
 }
-func (m *LoadVersionRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *VerifyItemValueHashRequest) Reset()         { *m = VerifyItemValueHashRequest{} }
+func (m *VerifyItemValueHashRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyItemValueHashRequest) ProtoMessage()    {}
+func (*VerifyItemValueHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{14}
+}
+func (m *VerifyItemValueHashRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LoadVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyItemValueHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LoadVersionRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyItemValueHashRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -653,41 +768,65 @@ func (m *LoadVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LoadVersionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LoadVersionRequest.Merge(m, src)
+func (m *VerifyItemValueHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyItemValueHashRequest.Merge(m, src)
 }
-func (m *LoadVersionRequest) XXX_Size() int {
+func (m *VerifyItemValueHashRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LoadVersionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LoadVersionRequest.DiscardUnknown(m)
+func (m *VerifyItemValueHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyItemValueHashRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LoadVersionRequest proto.InternalMessageInfo
+var xxx_messageInfo_VerifyItemValueHashRequest proto.InternalMessageInfo
 
-func (m *LoadVersionRequest) GetVersion() int64 {
+func (m *VerifyItemValueHashRequest) GetRootHash() []byte {
 	if m != nil {
-		return m.Version
+		return m.RootHash
 	}
-	return 0
+	return nil
 }
 
-type LoadVersionForOverwritingRequest struct {
-	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+func (m *VerifyItemValueHashRequest) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
 }
 
-func (m *LoadVersionForOverwritingRequest) Reset()         { *m = LoadVersionForOverwritingRequest{} }
-func (m *LoadVersionForOverwritingRequest) String() string { return proto.CompactTextString(m) }
-func (*LoadVersionForOverwritingRequest) ProtoMessage()    {}
-func (*LoadVersionForOverwritingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{13}
+func (m *VerifyItemValueHashRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
 }
-func (m *LoadVersionForOverwritingRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *VerifyItemValueHashRequest) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+type VerifyItemValueHashVersionedRequest struct {
+	Version   int64       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Proof     *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Key       []byte      `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	ValueHash []byte      `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+}
+
+func (m *VerifyItemValueHashVersionedRequest) Reset()         { *m = VerifyItemValueHashVersionedRequest{} }
+func (m *VerifyItemValueHashVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyItemValueHashVersionedRequest) ProtoMessage()    {}
+func (*VerifyItemValueHashVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{15}
+}
+func (m *VerifyItemValueHashVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LoadVersionForOverwritingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyItemValueHashVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LoadVersionForOverwritingRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyItemValueHashVersionedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -697,43 +836,64 @@ func (m *LoadVersionForOverwritingRequest) XXX_Marshal(b []byte, deterministic b
 		return b[:n], nil
 	}
 }
-func (m *LoadVersionForOverwritingRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LoadVersionForOverwritingRequest.Merge(m, src)
+func (m *VerifyItemValueHashVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyItemValueHashVersionedRequest.Merge(m, src)
 }
-func (m *LoadVersionForOverwritingRequest) XXX_Size() int {
+func (m *VerifyItemValueHashVersionedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LoadVersionForOverwritingRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LoadVersionForOverwritingRequest.DiscardUnknown(m)
+func (m *VerifyItemValueHashVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyItemValueHashVersionedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LoadVersionForOverwritingRequest proto.InternalMessageInfo
+var xxx_messageInfo_VerifyItemValueHashVersionedRequest proto.InternalMessageInfo
 
-func (m *LoadVersionForOverwritingRequest) GetVersion() int64 {
+func (m *VerifyItemValueHashVersionedRequest) GetVersion() int64 {
 	if m != nil {
 		return m.Version
 	}
 	return 0
 }
 
-type ListRequest struct {
-	FromKey    []byte `protobuf:"bytes,1,opt,name=from_key,json=fromKey,proto3" json:"from_key,omitempty"`
-	ToKey      []byte `protobuf:"bytes,2,opt,name=to_key,json=toKey,proto3" json:"to_key,omitempty"`
-	Descending bool   `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
+func (m *VerifyItemValueHashVersionedRequest) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
 }
 
-func (m *ListRequest) Reset()         { *m = ListRequest{} }
-func (m *ListRequest) String() string { return proto.CompactTextString(m) }
-func (*ListRequest) ProtoMessage()    {}
-func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{14}
+func (m *VerifyItemValueHashVersionedRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
 }
-func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *VerifyItemValueHashVersionedRequest) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+type VerifyAbsenceRequest struct {
+	RootHash []byte      `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Proof    *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Key      []byte      `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *VerifyAbsenceRequest) Reset()         { *m = VerifyAbsenceRequest{} }
+func (m *VerifyAbsenceRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyAbsenceRequest) ProtoMessage()    {}
+func (*VerifyAbsenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{16}
+}
+func (m *VerifyAbsenceRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyAbsenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyAbsenceRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -743,55 +903,57 @@ func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ListRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListRequest.Merge(m, src)
+func (m *VerifyAbsenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyAbsenceRequest.Merge(m, src)
 }
-func (m *ListRequest) XXX_Size() int {
+func (m *VerifyAbsenceRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+func (m *VerifyAbsenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyAbsenceRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+var xxx_messageInfo_VerifyAbsenceRequest proto.InternalMessageInfo
 
-func (m *ListRequest) GetFromKey() []byte {
+func (m *VerifyAbsenceRequest) GetRootHash() []byte {
 	if m != nil {
-		return m.FromKey
+		return m.RootHash
 	}
 	return nil
 }
 
-func (m *ListRequest) GetToKey() []byte {
+func (m *VerifyAbsenceRequest) GetProof() *RangeProof {
 	if m != nil {
-		return m.ToKey
+		return m.Proof
 	}
 	return nil
 }
 
-func (m *ListRequest) GetDescending() bool {
+func (m *VerifyAbsenceRequest) GetKey() []byte {
 	if m != nil {
-		return m.Descending
+		return m.Key
 	}
-	return false
+	return nil
 }
 
-type HasResponse struct {
-	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+type VerifyAbsenceVersionedRequest struct {
+	Version int64       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Proof   *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	Key     []byte      `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (m *HasResponse) Reset()         { *m = HasResponse{} }
-func (m *HasResponse) String() string { return proto.CompactTextString(m) }
-func (*HasResponse) ProtoMessage()    {}
-func (*HasResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{15}
+func (m *VerifyAbsenceVersionedRequest) Reset()         { *m = VerifyAbsenceVersionedRequest{} }
+func (m *VerifyAbsenceVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyAbsenceVersionedRequest) ProtoMessage()    {}
+func (*VerifyAbsenceVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{17}
 }
-func (m *HasResponse) XXX_Unmarshal(b []byte) error {
+func (m *VerifyAbsenceVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *HasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyAbsenceVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_HasResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyAbsenceVersionedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -801,43 +963,55 @@ func (m *HasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *HasResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HasResponse.Merge(m, src)
+func (m *VerifyAbsenceVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyAbsenceVersionedRequest.Merge(m, src)
 }
-func (m *HasResponse) XXX_Size() int {
+func (m *VerifyAbsenceVersionedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *HasResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_HasResponse.DiscardUnknown(m)
+func (m *VerifyAbsenceVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyAbsenceVersionedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HasResponse proto.InternalMessageInfo
+var xxx_messageInfo_VerifyAbsenceVersionedRequest proto.InternalMessageInfo
 
-func (m *HasResponse) GetResult() bool {
+func (m *VerifyAbsenceVersionedRequest) GetVersion() int64 {
 	if m != nil {
-		return m.Result
+		return m.Version
 	}
-	return false
+	return 0
 }
 
-type GetResponse struct {
-	Index    int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Value    []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	NotFound bool   `protobuf:"varint,3,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+func (m *VerifyAbsenceVersionedRequest) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
 }
 
-func (m *GetResponse) Reset()         { *m = GetResponse{} }
-func (m *GetResponse) String() string { return proto.CompactTextString(m) }
-func (*GetResponse) ProtoMessage()    {}
-func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{16}
+func (m *VerifyAbsenceVersionedRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
 }
-func (m *GetResponse) XXX_Unmarshal(b []byte) error {
+
+type LoadVersionRequest struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *LoadVersionRequest) Reset()         { *m = LoadVersionRequest{} }
+func (m *LoadVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadVersionRequest) ProtoMessage()    {}
+func (*LoadVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{18}
+}
+func (m *LoadVersionRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LoadVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LoadVersionRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -847,56 +1021,41 @@ func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *GetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetResponse.Merge(m, src)
+func (m *LoadVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadVersionRequest.Merge(m, src)
 }
-func (m *GetResponse) XXX_Size() int {
+func (m *LoadVersionRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+func (m *LoadVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadVersionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+var xxx_messageInfo_LoadVersionRequest proto.InternalMessageInfo
 
-func (m *GetResponse) GetIndex() int64 {
+func (m *LoadVersionRequest) GetVersion() int64 {
 	if m != nil {
-		return m.Index
+		return m.Version
 	}
 	return 0
 }
 
-func (m *GetResponse) GetValue() []byte {
-	if m != nil {
-		return m.Value
-	}
-	return nil
-}
-
-func (m *GetResponse) GetNotFound() bool {
-	if m != nil {
-		return m.NotFound
-	}
-	return false
-}
-
-type GetByIndexResponse struct {
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type LoadVersionForOverwritingRequest struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (m *GetByIndexResponse) Reset()         { *m = GetByIndexResponse{} }
-func (m *GetByIndexResponse) String() string { return proto.CompactTextString(m) }
-func (*GetByIndexResponse) ProtoMessage()    {}
-func (*GetByIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{17}
+func (m *LoadVersionForOverwritingRequest) Reset()         { *m = LoadVersionForOverwritingRequest{} }
+func (m *LoadVersionForOverwritingRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadVersionForOverwritingRequest) ProtoMessage()    {}
+func (*LoadVersionForOverwritingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{19}
 }
-func (m *GetByIndexResponse) XXX_Unmarshal(b []byte) error {
+func (m *LoadVersionForOverwritingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetByIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LoadVersionForOverwritingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetByIndexResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LoadVersionForOverwritingRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -906,48 +1065,48 @@ func (m *GetByIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetByIndexResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetByIndexResponse.Merge(m, src)
+func (m *LoadVersionForOverwritingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadVersionForOverwritingRequest.Merge(m, src)
 }
-func (m *GetByIndexResponse) XXX_Size() int {
+func (m *LoadVersionForOverwritingRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetByIndexResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetByIndexResponse.DiscardUnknown(m)
+func (m *LoadVersionForOverwritingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadVersionForOverwritingRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetByIndexResponse proto.InternalMessageInfo
-
-func (m *GetByIndexResponse) GetKey() []byte {
-	if m != nil {
-		return m.Key
-	}
-	return nil
-}
+var xxx_messageInfo_LoadVersionForOverwritingRequest proto.InternalMessageInfo
 
-func (m *GetByIndexResponse) GetValue() []byte {
+func (m *LoadVersionForOverwritingRequest) GetVersion() int64 {
 	if m != nil {
-		return m.Value
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-type SetResponse struct {
-	Updated bool `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+type ListRequest struct {
+	FromKey    []byte `protobuf:"bytes,1,opt,name=from_key,json=fromKey,proto3" json:"from_key,omitempty"`
+	ToKey      []byte `protobuf:"bytes,2,opt,name=to_key,json=toKey,proto3" json:"to_key,omitempty"`
+	Descending bool   `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
+	// include_versions includes the version of each leaf in the results.
+	IncludeVersions bool `protobuf:"varint,4,opt,name=include_versions,json=includeVersions,proto3" json:"include_versions,omitempty"`
+	// include_proof streams a range proof of the results, see ListResponse.proof.
+	// Not supported for descending lists.
+	IncludeProof bool `protobuf:"varint,5,opt,name=include_proof,json=includeProof,proto3" json:"include_proof,omitempty"`
 }
 
-func (m *SetResponse) Reset()         { *m = SetResponse{} }
-func (m *SetResponse) String() string { return proto.CompactTextString(m) }
-func (*SetResponse) ProtoMessage()    {}
-func (*SetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{18}
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{20}
 }
-func (m *SetResponse) XXX_Unmarshal(b []byte) error {
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -957,42 +1116,77 @@ func (m *SetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *SetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetResponse.Merge(m, src)
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
 }
-func (m *SetResponse) XXX_Size() int {
+func (m *ListRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetResponse.DiscardUnknown(m)
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
 
-func (m *SetResponse) GetUpdated() bool {
+func (m *ListRequest) GetFromKey() []byte {
 	if m != nil {
-		return m.Updated
+		return m.FromKey
+	}
+	return nil
+}
+
+func (m *ListRequest) GetToKey() []byte {
+	if m != nil {
+		return m.ToKey
+	}
+	return nil
+}
+
+func (m *ListRequest) GetDescending() bool {
+	if m != nil {
+		return m.Descending
 	}
 	return false
 }
 
-type RemoveResponse struct {
-	Value   []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Removed bool   `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+func (m *ListRequest) GetIncludeVersions() bool {
+	if m != nil {
+		return m.IncludeVersions
+	}
+	return false
 }
 
-func (m *RemoveResponse) Reset()         { *m = RemoveResponse{} }
-func (m *RemoveResponse) String() string { return proto.CompactTextString(m) }
-func (*RemoveResponse) ProtoMessage()    {}
-func (*RemoveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{19}
+func (m *ListRequest) GetIncludeProof() bool {
+	if m != nil {
+		return m.IncludeProof
+	}
+	return false
 }
-func (m *RemoveResponse) XXX_Unmarshal(b []byte) error {
+
+type ListVersionedRequest struct {
+	Version    int64  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	FromKey    []byte `protobuf:"bytes,2,opt,name=from_key,json=fromKey,proto3" json:"from_key,omitempty"`
+	ToKey      []byte `protobuf:"bytes,3,opt,name=to_key,json=toKey,proto3" json:"to_key,omitempty"`
+	Descending bool   `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// include_versions includes the version of each leaf in the results.
+	IncludeVersions bool `protobuf:"varint,5,opt,name=include_versions,json=includeVersions,proto3" json:"include_versions,omitempty"`
+	// include_proof streams a range proof of the results, see ListResponse.proof.
+	// Not supported for descending lists.
+	IncludeProof bool `protobuf:"varint,6,opt,name=include_proof,json=includeProof,proto3" json:"include_proof,omitempty"`
+}
+
+func (m *ListVersionedRequest) Reset()         { *m = ListVersionedRequest{} }
+func (m *ListVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVersionedRequest) ProtoMessage()    {}
+func (*ListVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{21}
+}
+func (m *ListVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RemoveResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListVersionedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1002,49 +1196,76 @@ func (m *RemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *RemoveResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveResponse.Merge(m, src)
+func (m *ListVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVersionedRequest.Merge(m, src)
 }
-func (m *RemoveResponse) XXX_Size() int {
+func (m *ListVersionedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *RemoveResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveResponse.DiscardUnknown(m)
+func (m *ListVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVersionedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RemoveResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListVersionedRequest proto.InternalMessageInfo
 
-func (m *RemoveResponse) GetValue() []byte {
+func (m *ListVersionedRequest) GetVersion() int64 {
 	if m != nil {
-		return m.Value
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ListVersionedRequest) GetFromKey() []byte {
+	if m != nil {
+		return m.FromKey
 	}
 	return nil
 }
 
-func (m *RemoveResponse) GetRemoved() bool {
+func (m *ListVersionedRequest) GetToKey() []byte {
 	if m != nil {
-		return m.Removed
+		return m.ToKey
+	}
+	return nil
+}
+
+func (m *ListVersionedRequest) GetDescending() bool {
+	if m != nil {
+		return m.Descending
 	}
 	return false
 }
 
-type SaveVersionResponse struct {
-	RootHash []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
-	Version  int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+func (m *ListVersionedRequest) GetIncludeVersions() bool {
+	if m != nil {
+		return m.IncludeVersions
+	}
+	return false
 }
 
-func (m *SaveVersionResponse) Reset()         { *m = SaveVersionResponse{} }
-func (m *SaveVersionResponse) String() string { return proto.CompactTextString(m) }
-func (*SaveVersionResponse) ProtoMessage()    {}
-func (*SaveVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{20}
+func (m *ListVersionedRequest) GetIncludeProof() bool {
+	if m != nil {
+		return m.IncludeProof
+	}
+	return false
 }
-func (m *SaveVersionResponse) XXX_Unmarshal(b []byte) error {
+
+type HashVersionedRequest struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *HashVersionedRequest) Reset()         { *m = HashVersionedRequest{} }
+func (m *HashVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*HashVersionedRequest) ProtoMessage()    {}
+func (*HashVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{22}
+}
+func (m *HashVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SaveVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *HashVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SaveVersionResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_HashVersionedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1054,49 +1275,41 @@ func (m *SaveVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *SaveVersionResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SaveVersionResponse.Merge(m, src)
+func (m *HashVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashVersionedRequest.Merge(m, src)
 }
-func (m *SaveVersionResponse) XXX_Size() int {
+func (m *HashVersionedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SaveVersionResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SaveVersionResponse.DiscardUnknown(m)
+func (m *HashVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashVersionedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SaveVersionResponse proto.InternalMessageInfo
-
-func (m *SaveVersionResponse) GetRootHash() []byte {
-	if m != nil {
-		return m.RootHash
-	}
-	return nil
-}
+var xxx_messageInfo_HashVersionedRequest proto.InternalMessageInfo
 
-func (m *SaveVersionResponse) GetVersion() int64 {
+func (m *HashVersionedRequest) GetVersion() int64 {
 	if m != nil {
 		return m.Version
 	}
 	return 0
 }
 
-type DeleteVersionResponse struct {
-	RootHash []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
-	Version  int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+type SizeVersionedRequest struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (m *DeleteVersionResponse) Reset()         { *m = DeleteVersionResponse{} }
-func (m *DeleteVersionResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteVersionResponse) ProtoMessage()    {}
-func (*DeleteVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{21}
+func (m *SizeVersionedRequest) Reset()         { *m = SizeVersionedRequest{} }
+func (m *SizeVersionedRequest) String() string { return proto.CompactTextString(m) }
+func (*SizeVersionedRequest) ProtoMessage()    {}
+func (*SizeVersionedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{23}
 }
-func (m *DeleteVersionResponse) XXX_Unmarshal(b []byte) error {
+func (m *SizeVersionedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SizeVersionedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteVersionResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SizeVersionedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1106,48 +1319,41 @@ func (m *DeleteVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *DeleteVersionResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteVersionResponse.Merge(m, src)
+func (m *SizeVersionedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SizeVersionedRequest.Merge(m, src)
 }
-func (m *DeleteVersionResponse) XXX_Size() int {
+func (m *SizeVersionedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteVersionResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteVersionResponse.DiscardUnknown(m)
+func (m *SizeVersionedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SizeVersionedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteVersionResponse proto.InternalMessageInfo
-
-func (m *DeleteVersionResponse) GetRootHash() []byte {
-	if m != nil {
-		return m.RootHash
-	}
-	return nil
-}
+var xxx_messageInfo_SizeVersionedRequest proto.InternalMessageInfo
 
-func (m *DeleteVersionResponse) GetVersion() int64 {
+func (m *SizeVersionedRequest) GetVersion() int64 {
 	if m != nil {
 		return m.Version
 	}
 	return 0
 }
 
-type VersionResponse struct {
-	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+type GetNodeRequest struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
-func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
-func (*VersionResponse) ProtoMessage()    {}
-func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{22}
+func (m *GetNodeRequest) Reset()         { *m = GetNodeRequest{} }
+func (m *GetNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeRequest) ProtoMessage()    {}
+func (*GetNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{24}
 }
-func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
+func (m *GetNodeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *VersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_VersionResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetNodeRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1157,41 +1363,43 @@ func (m *VersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *VersionResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VersionResponse.Merge(m, src)
+func (m *GetNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeRequest.Merge(m, src)
 }
-func (m *VersionResponse) XXX_Size() int {
+func (m *GetNodeRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *VersionResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_VersionResponse.DiscardUnknown(m)
+func (m *GetNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VersionResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetNodeRequest proto.InternalMessageInfo
 
-func (m *VersionResponse) GetVersion() int64 {
+func (m *GetNodeRequest) GetHash() []byte {
 	if m != nil {
-		return m.Version
+		return m.Hash
 	}
-	return 0
+	return nil
 }
 
-type HashResponse struct {
-	RootHash []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+type SampleWithProofsRequest struct {
+	Version int64  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Seed    []byte `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	N       int64  `protobuf:"varint,3,opt,name=n,proto3" json:"n,omitempty"`
 }
 
-func (m *HashResponse) Reset()         { *m = HashResponse{} }
-func (m *HashResponse) String() string { return proto.CompactTextString(m) }
-func (*HashResponse) ProtoMessage()    {}
-func (*HashResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{23}
+func (m *SampleWithProofsRequest) Reset()         { *m = SampleWithProofsRequest{} }
+func (m *SampleWithProofsRequest) String() string { return proto.CompactTextString(m) }
+func (*SampleWithProofsRequest) ProtoMessage()    {}
+func (*SampleWithProofsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{25}
 }
-func (m *HashResponse) XXX_Unmarshal(b []byte) error {
+func (m *SampleWithProofsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *HashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SampleWithProofsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_HashResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SampleWithProofsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1201,41 +1409,55 @@ func (m *HashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *HashResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HashResponse.Merge(m, src)
+func (m *SampleWithProofsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SampleWithProofsRequest.Merge(m, src)
 }
-func (m *HashResponse) XXX_Size() int {
+func (m *SampleWithProofsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *HashResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_HashResponse.DiscardUnknown(m)
+func (m *SampleWithProofsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SampleWithProofsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HashResponse proto.InternalMessageInfo
+var xxx_messageInfo_SampleWithProofsRequest proto.InternalMessageInfo
 
-func (m *HashResponse) GetRootHash() []byte {
+func (m *SampleWithProofsRequest) GetVersion() int64 {
 	if m != nil {
-		return m.RootHash
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SampleWithProofsRequest) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
 	}
 	return nil
 }
 
-type VersionExistsResponse struct {
+func (m *SampleWithProofsRequest) GetN() int64 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+type HasResponse struct {
 	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 }
 
-func (m *VersionExistsResponse) Reset()         { *m = VersionExistsResponse{} }
-func (m *VersionExistsResponse) String() string { return proto.CompactTextString(m) }
-func (*VersionExistsResponse) ProtoMessage()    {}
-func (*VersionExistsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{24}
+func (m *HasResponse) Reset()         { *m = HasResponse{} }
+func (m *HasResponse) String() string { return proto.CompactTextString(m) }
+func (*HasResponse) ProtoMessage()    {}
+func (*HasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{26}
 }
-func (m *VersionExistsResponse) XXX_Unmarshal(b []byte) error {
+func (m *HasResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *VersionExistsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *HasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_VersionExistsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_HasResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1245,42 +1467,43 @@ func (m *VersionExistsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *VersionExistsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VersionExistsResponse.Merge(m, src)
+func (m *HasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasResponse.Merge(m, src)
 }
-func (m *VersionExistsResponse) XXX_Size() int {
+func (m *HasResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *VersionExistsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_VersionExistsResponse.DiscardUnknown(m)
+func (m *HasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VersionExistsResponse proto.InternalMessageInfo
+var xxx_messageInfo_HasResponse proto.InternalMessageInfo
 
-func (m *VersionExistsResponse) GetResult() bool {
+func (m *HasResponse) GetResult() bool {
 	if m != nil {
 		return m.Result
 	}
 	return false
 }
 
-type GetWithProofResponse struct {
-	Value []byte      `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Proof *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+type GetResponse struct {
+	Index    int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Value    []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	NotFound bool   `protobuf:"varint,3,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
 }
 
-func (m *GetWithProofResponse) Reset()         { *m = GetWithProofResponse{} }
-func (m *GetWithProofResponse) String() string { return proto.CompactTextString(m) }
-func (*GetWithProofResponse) ProtoMessage()    {}
-func (*GetWithProofResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{25}
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetResponse) ProtoMessage()    {}
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{27}
 }
-func (m *GetWithProofResponse) XXX_Unmarshal(b []byte) error {
+func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetWithProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetWithProofResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1290,48 +1513,56 @@ func (m *GetWithProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *GetWithProofResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetWithProofResponse.Merge(m, src)
+func (m *GetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetResponse.Merge(m, src)
 }
-func (m *GetWithProofResponse) XXX_Size() int {
+func (m *GetResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetWithProofResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetWithProofResponse.DiscardUnknown(m)
+func (m *GetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetWithProofResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetResponse proto.InternalMessageInfo
 
-func (m *GetWithProofResponse) GetValue() []byte {
+func (m *GetResponse) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *GetResponse) GetValue() []byte {
 	if m != nil {
 		return m.Value
 	}
 	return nil
 }
 
-func (m *GetWithProofResponse) GetProof() *RangeProof {
+func (m *GetResponse) GetNotFound() bool {
 	if m != nil {
-		return m.Proof
+		return m.NotFound
 	}
-	return nil
+	return false
 }
 
-type GetAvailableVersionsResponse struct {
-	Versions []int64 `protobuf:"varint,1,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+type GetByIndexResponse struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (m *GetAvailableVersionsResponse) Reset()         { *m = GetAvailableVersionsResponse{} }
-func (m *GetAvailableVersionsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetAvailableVersionsResponse) ProtoMessage()    {}
-func (*GetAvailableVersionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{26}
+func (m *GetByIndexResponse) Reset()         { *m = GetByIndexResponse{} }
+func (m *GetByIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*GetByIndexResponse) ProtoMessage()    {}
+func (*GetByIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{28}
 }
-func (m *GetAvailableVersionsResponse) XXX_Unmarshal(b []byte) error {
+func (m *GetByIndexResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetAvailableVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetByIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetAvailableVersionsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetByIndexResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1341,41 +1572,48 @@ func (m *GetAvailableVersionsResponse) XXX_Marshal(b []byte, deterministic bool)
 		return b[:n], nil
 	}
 }
-func (m *GetAvailableVersionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAvailableVersionsResponse.Merge(m, src)
+func (m *GetByIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetByIndexResponse.Merge(m, src)
 }
-func (m *GetAvailableVersionsResponse) XXX_Size() int {
+func (m *GetByIndexResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetAvailableVersionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAvailableVersionsResponse.DiscardUnknown(m)
+func (m *GetByIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetByIndexResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAvailableVersionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetByIndexResponse proto.InternalMessageInfo
 
-func (m *GetAvailableVersionsResponse) GetVersions() []int64 {
+func (m *GetByIndexResponse) GetKey() []byte {
 	if m != nil {
-		return m.Versions
+		return m.Key
 	}
 	return nil
 }
 
-type SizeResponse struct {
-	Size_ int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+func (m *GetByIndexResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
 
-func (m *SizeResponse) Reset()         { *m = SizeResponse{} }
-func (m *SizeResponse) String() string { return proto.CompactTextString(m) }
-func (*SizeResponse) ProtoMessage()    {}
-func (*SizeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{27}
+type SetResponse struct {
+	Updated bool `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
 }
-func (m *SizeResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *SetResponse) Reset()         { *m = SetResponse{} }
+func (m *SetResponse) String() string { return proto.CompactTextString(m) }
+func (*SetResponse) ProtoMessage()    {}
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{29}
+}
+func (m *SetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SizeResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1385,42 +1623,42 @@ func (m *SizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *SizeResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SizeResponse.Merge(m, src)
+func (m *SetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetResponse.Merge(m, src)
 }
-func (m *SizeResponse) XXX_Size() int {
+func (m *SetResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SizeResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SizeResponse.DiscardUnknown(m)
+func (m *SetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SizeResponse proto.InternalMessageInfo
+var xxx_messageInfo_SetResponse proto.InternalMessageInfo
 
-func (m *SizeResponse) GetSize_() int64 {
+func (m *SetResponse) GetUpdated() bool {
 	if m != nil {
-		return m.Size_
+		return m.Updated
 	}
-	return 0
+	return false
 }
 
-type ListResponse struct {
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type RemoveResponse struct {
+	Value   []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Removed bool   `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
 }
 
-func (m *ListResponse) Reset()         { *m = ListResponse{} }
-func (m *ListResponse) String() string { return proto.CompactTextString(m) }
-func (*ListResponse) ProtoMessage()    {}
-func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5cad6b4fafc2c047, []int{28}
+func (m *RemoveResponse) Reset()         { *m = RemoveResponse{} }
+func (m *RemoveResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveResponse) ProtoMessage()    {}
+func (*RemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{30}
 }
-func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+func (m *RemoveResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RemoveResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1430,2190 +1668,2535 @@ func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListResponse.Merge(m, src)
+func (m *RemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveResponse.Merge(m, src)
 }
-func (m *ListResponse) XXX_Size() int {
+func (m *RemoveResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+func (m *RemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+var xxx_messageInfo_RemoveResponse proto.InternalMessageInfo
 
-func (m *ListResponse) GetKey() []byte {
+func (m *RemoveResponse) GetValue() []byte {
 	if m != nil {
-		return m.Key
+		return m.Value
 	}
 	return nil
 }
 
-func (m *ListResponse) GetValue() []byte {
+func (m *RemoveResponse) GetRemoved() bool {
 	if m != nil {
-		return m.Value
+		return m.Removed
 	}
-	return nil
+	return false
 }
 
-func init() {
-	proto.RegisterType((*HasRequest)(nil), "iavl.HasRequest")
-	proto.RegisterType((*HasVersionedRequest)(nil), "iavl.HasVersionedRequest")
-	proto.RegisterType((*GetRequest)(nil), "iavl.GetRequest")
-	proto.RegisterType((*GetByIndexRequest)(nil), "iavl.GetByIndexRequest")
-	proto.RegisterType((*GetVersionedRequest)(nil), "iavl.GetVersionedRequest")
-	proto.RegisterType((*SetRequest)(nil), "iavl.SetRequest")
-	proto.RegisterType((*RemoveRequest)(nil), "iavl.RemoveRequest")
-	proto.RegisterType((*DeleteVersionRequest)(nil), "iavl.DeleteVersionRequest")
-	proto.RegisterType((*VersionExistsRequest)(nil), "iavl.VersionExistsRequest")
-	proto.RegisterType((*VerifyRequest)(nil), "iavl.VerifyRequest")
-	proto.RegisterType((*VerifyItemRequest)(nil), "iavl.VerifyItemRequest")
-	proto.RegisterType((*VerifyAbsenceRequest)(nil), "iavl.VerifyAbsenceRequest")
-	proto.RegisterType((*LoadVersionRequest)(nil), "iavl.LoadVersionRequest")
-	proto.RegisterType((*LoadVersionForOverwritingRequest)(nil), "iavl.LoadVersionForOverwritingRequest")
-	proto.RegisterType((*ListRequest)(nil), "iavl.ListRequest")
-	proto.RegisterType((*HasResponse)(nil), "iavl.HasResponse")
-	proto.RegisterType((*GetResponse)(nil), "iavl.GetResponse")
-	proto.RegisterType((*GetByIndexResponse)(nil), "iavl.GetByIndexResponse")
-	proto.RegisterType((*SetResponse)(nil), "iavl.SetResponse")
-	proto.RegisterType((*RemoveResponse)(nil), "iavl.RemoveResponse")
-	proto.RegisterType((*SaveVersionResponse)(nil), "iavl.SaveVersionResponse")
-	proto.RegisterType((*DeleteVersionResponse)(nil), "iavl.DeleteVersionResponse")
-	proto.RegisterType((*VersionResponse)(nil), "iavl.VersionResponse")
-	proto.RegisterType((*HashResponse)(nil), "iavl.HashResponse")
-	proto.RegisterType((*VersionExistsResponse)(nil), "iavl.VersionExistsResponse")
-	proto.RegisterType((*GetWithProofResponse)(nil), "iavl.GetWithProofResponse")
-	proto.RegisterType((*GetAvailableVersionsResponse)(nil), "iavl.GetAvailableVersionsResponse")
-	proto.RegisterType((*SizeResponse)(nil), "iavl.SizeResponse")
-	proto.RegisterType((*ListResponse)(nil), "iavl.ListResponse")
+type SaveVersionResponse struct {
+	RootHash []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Version  int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func init() { proto.RegisterFile("iavl/iavl_api.proto", fileDescriptor_5cad6b4fafc2c047) }
-
-var fileDescriptor_5cad6b4fafc2c047 = []byte{
-	// 1292 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x96, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xc0, 0xeb, 0xda, 0x89, 0xdd, 0x67, 0xa7, 0x75, 0xc6, 0x71, 0xe2, 0x38, 0xad, 0x09, 0x83,
-	0x1a, 0x02, 0x91, 0xec, 0x52, 0x10, 0x87, 0xb6, 0x42, 0xa4, 0x4a, 0xeb, 0x84, 0x86, 0x3f, 0xb2,
-	0x4b, 0x82, 0x10, 0x68, 0x35, 0xce, 0x8e, 0xed, 0x55, 0x9c, 0x1d, 0xb3, 0x3b, 0x76, 0xeb, 0x22,
-	0x10, 0xe2, 0xc4, 0x11, 0x89, 0x2f, 0xc5, 0xb1, 0x12, 0x17, 0x8e, 0x28, 0xe1, 0x2b, 0x70, 0x47,
-	0x33, 0x3b, 0xe3, 0xdd, 0xb5, 0x77, 0xed, 0x54, 0x85, 0x4b, 0xe2, 0x99, 0x9d, 0xf9, 0xbd, 0xff,
-	0x6f, 0x1e, 0x14, 0x2c, 0x32, 0xec, 0xd5, 0xc4, 0x1f, 0x83, 0xf4, 0xad, 0x6a, 0xdf, 0x61, 0x9c,
-	0xa1, 0x94, 0x58, 0x97, 0x6f, 0x76, 0x18, 0xeb, 0xf4, 0x68, 0x8d, 0xf4, 0xad, 0x1a, 0xb1, 0x6d,
-	0xc6, 0x09, 0xb7, 0x98, 0xed, 0x7a, 0x67, 0xca, 0x1b, 0xea, 0xab, 0x5c, 0xb5, 0x06, 0xed, 0x1a,
-	0x3d, 0xeb, 0xf3, 0x91, 0xfa, 0x98, 0x97, 0xd4, 0xbe, 0xc3, 0x58, 0xdb, 0xdb, 0xc1, 0x15, 0x80,
-	0x7d, 0xe2, 0x36, 0xe8, 0x77, 0x03, 0xea, 0x72, 0x94, 0x87, 0xe4, 0x29, 0x1d, 0x95, 0x12, 0x9b,
-	0x89, 0xed, 0x5c, 0x43, 0xfc, 0xc4, 0xbb, 0x50, 0xd8, 0x27, 0xee, 0x11, 0x75, 0x5c, 0x8b, 0xd9,
-	0xd4, 0xd4, 0x07, 0x4b, 0x90, 0x1e, 0x7a, 0x7b, 0xf2, 0x70, 0xb2, 0xa1, 0x97, 0x1a, 0x71, 0xd5,
-	0x47, 0x54, 0x00, 0xea, 0x94, 0xc7, 0x8b, 0x78, 0x07, 0x96, 0xeb, 0x94, 0x3f, 0x1c, 0x1d, 0xd8,
-	0x26, 0x7d, 0xae, 0x8f, 0xad, 0xc0, 0x82, 0x25, 0xd6, 0x0a, 0xef, 0x2d, 0x84, 0x36, 0x75, 0xca,
-	0x5f, 0x4b, 0x9b, 0x0f, 0x00, 0x9a, 0x33, 0xb4, 0x11, 0x82, 0x87, 0xa4, 0x37, 0xa0, 0xea, 0x8e,
-	0xb7, 0xc0, 0x6f, 0xc2, 0x52, 0x83, 0x9e, 0xb1, 0x21, 0x8d, 0x37, 0xe3, 0x0e, 0xac, 0xec, 0xd1,
-	0x1e, 0xe5, 0x54, 0xa9, 0x37, 0x57, 0x39, 0x71, 0x43, 0x9d, 0x7d, 0xf4, 0xdc, 0x72, 0xb9, 0x3b,
-	0xff, 0xc6, 0x53, 0x58, 0x3a, 0xa2, 0x8e, 0xd5, 0x1e, 0xe9, 0xa3, 0x1b, 0x70, 0xcd, 0x61, 0x8c,
-	0x1b, 0x5d, 0xe2, 0x76, 0x95, 0x32, 0x19, 0xb1, 0xb1, 0x4f, 0xdc, 0x2e, 0xda, 0x82, 0x05, 0x19,
-	0x6a, 0x69, 0x4a, 0xf6, 0x6e, 0xbe, 0x2a, 0xa2, 0x5f, 0x6d, 0x10, 0xbb, 0x43, 0xbf, 0x10, 0xfb,
-	0x0d, 0xef, 0x33, 0xfe, 0x29, 0x01, 0xcb, 0x1e, 0xf6, 0x80, 0xd3, 0xb3, 0xff, 0x12, 0xad, 0xdd,
-	0x94, 0x8c, 0xf0, 0x6f, 0x2a, 0xe8, 0xdf, 0x33, 0xe9, 0x0a, 0xab, 0x3d, 0xda, 0x6d, 0xb9, 0xd4,
-	0x3e, 0xa1, 0xff, 0xaf, 0x12, 0xb8, 0x0a, 0xe8, 0x90, 0x11, 0xf3, 0xd2, 0x91, 0x7a, 0x00, 0x9b,
-	0x81, 0xf3, 0x8f, 0x99, 0xf3, 0xf9, 0x90, 0x3a, 0xcf, 0x1c, 0x8b, 0x5b, 0x76, 0x67, 0xfe, 0x6d,
-	0x03, 0xb2, 0x87, 0x96, 0x3b, 0xce, 0xb9, 0x75, 0xc8, 0xb4, 0x1d, 0x76, 0x66, 0xf8, 0xf9, 0x93,
-	0x16, 0xeb, 0x27, 0x74, 0x84, 0x8a, 0xb0, 0xc8, 0x99, 0xe1, 0x67, 0xec, 0x02, 0x67, 0x62, 0xbb,
-	0x02, 0x60, 0x52, 0xf7, 0x84, 0xda, 0xa6, 0x65, 0x77, 0xa4, 0x1d, 0x99, 0x46, 0x60, 0x07, 0xdf,
-	0x86, 0xac, 0x2c, 0x62, 0xb7, 0xcf, 0x6c, 0x97, 0xa2, 0x55, 0x58, 0x74, 0xa8, 0x3b, 0xe8, 0x71,
-	0x89, 0xcf, 0x34, 0xd4, 0x0a, 0x1f, 0x41, 0x56, 0x16, 0xa2, 0x3a, 0x16, 0x59, 0x62, 0xd1, 0xf9,
-	0x2f, 0xe2, 0x60, 0x33, 0x6e, 0xb4, 0xd9, 0xc0, 0x36, 0x95, 0x02, 0x19, 0x9b, 0xf1, 0xc7, 0x62,
-	0x8d, 0x1f, 0x00, 0x0a, 0x16, 0xb0, 0xc2, 0x5f, 0xb6, 0xb4, 0xde, 0x86, 0x6c, 0x33, 0xa0, 0x55,
-	0x09, 0xd2, 0x83, 0xbe, 0x49, 0x38, 0x35, 0x95, 0xf6, 0x7a, 0x89, 0x3f, 0x86, 0xeb, 0xba, 0x06,
-	0x7d, 0x0b, 0x3c, 0x60, 0x22, 0xa8, 0x6b, 0x09, 0xd2, 0x8e, 0x3c, 0x67, 0x4a, 0x41, 0x99, 0x86,
-	0x5e, 0xe2, 0x43, 0x28, 0x34, 0xc9, 0xd0, 0x2f, 0x50, 0x85, 0x99, 0x99, 0x64, 0x81, 0xb0, 0x5e,
-	0x0d, 0x87, 0xf5, 0x33, 0x28, 0x4e, 0x14, 0xfc, 0xeb, 0xf1, 0x76, 0xe0, 0xc6, 0x24, 0x29, 0x3e,
-	0xa7, 0x76, 0x20, 0x27, 0x70, 0x97, 0x92, 0x89, 0x6b, 0x50, 0x9c, 0x68, 0x34, 0x73, 0x32, 0xe5,
-	0x29, 0xac, 0xd4, 0x29, 0x3f, 0xb6, 0x78, 0xd7, 0x2b, 0xa4, 0xd9, 0x0e, 0xbf, 0x6c, 0x9f, 0xb9,
-	0x07, 0x37, 0xeb, 0x94, 0xef, 0x0e, 0x89, 0xd5, 0x23, 0xad, 0x9e, 0x76, 0x9b, 0xaf, 0x4d, 0x19,
-	0x32, 0xca, 0x3c, 0xb7, 0x94, 0xd8, 0x4c, 0x6e, 0x27, 0x1b, 0xe3, 0x35, 0xc6, 0x90, 0x6b, 0x5a,
-	0x2f, 0xfc, 0xd0, 0x23, 0x48, 0xb9, 0xd6, 0x0b, 0xaa, 0xdc, 0x22, 0x7f, 0xe3, 0x0f, 0x21, 0xe7,
-	0xd5, 0xd9, 0xab, 0x65, 0xe0, 0xdd, 0x7f, 0xf2, 0x90, 0x3d, 0xd8, 0x3d, 0x3a, 0x6c, 0x52, 0x67,
-	0x68, 0x9d, 0x50, 0x74, 0x1f, 0x92, 0xfb, 0xc4, 0x45, 0xca, 0x0e, 0xff, 0x79, 0x2c, 0x2f, 0x07,
-	0x76, 0x3c, 0x19, 0xf8, 0xc6, 0xcf, 0x7f, 0xfc, 0xfd, 0xdb, 0xd5, 0x6b, 0x28, 0x5d, 0x1b, 0xbe,
-	0x57, 0xeb, 0x12, 0x17, 0x1d, 0xcb, 0xc0, 0x8c, 0x9f, 0x28, 0xb4, 0x3e, 0xbe, 0x33, 0xf9, 0x6c,
-	0x45, 0xe1, 0xd6, 0x25, 0xae, 0x80, 0x96, 0x15, 0xce, 0x18, 0x8e, 0x41, 0xf7, 0x21, 0x59, 0xa7,
-	0x5c, 0x6b, 0xe5, 0xbf, 0xa8, 0x1a, 0x13, 0x28, 0xed, 0xb0, 0x56, 0x1d, 0xca, 0xd1, 0xb1, 0x7c,
-	0x83, 0x55, 0x89, 0xa2, 0xb5, 0xf1, 0x8d, 0xf0, 0xab, 0x5b, 0x2e, 0x4d, 0x7f, 0x50, 0xc4, 0x55,
-	0x49, 0xcc, 0xa3, 0xeb, 0x8a, 0xd8, 0x1a, 0x79, 0xed, 0xe2, 0x2b, 0xc8, 0x05, 0x33, 0x25, 0x42,
-	0xbd, 0xf2, 0x78, 0x67, 0x2a, 0x9f, 0x70, 0x59, 0x52, 0x57, 0x10, 0x52, 0x54, 0xe3, 0x99, 0xc5,
-	0xbb, 0x86, 0xd7, 0xb5, 0x89, 0x24, 0x4f, 0x39, 0x32, 0xe2, 0xfd, 0x8f, 0xf2, 0xc0, 0x5b, 0x92,
-	0x7c, 0x0b, 0x6d, 0x08, 0xf2, 0xf7, 0xca, 0x8b, 0x3f, 0x48, 0x19, 0xbe, 0x4b, 0x7f, 0x84, 0x62,
-	0x10, 0xe7, 0x5b, 0x31, 0x43, 0xd6, 0x2c, 0x73, 0xaa, 0x52, 0xe8, 0x36, 0xda, 0x9a, 0x21, 0x34,
-	0x68, 0xe2, 0x47, 0x90, 0x6c, 0xfa, 0x21, 0x6d, 0x4e, 0x85, 0x34, 0xd0, 0x17, 0x31, 0x92, 0xec,
-	0x1c, 0x96, 0x21, 0x75, 0x29, 0xbf, 0x97, 0x78, 0x17, 0x7d, 0x02, 0x8b, 0x5e, 0x47, 0x44, 0x05,
-	0x55, 0x73, 0xc1, 0x19, 0xa5, 0xbc, 0x12, 0xde, 0x54, 0xa0, 0xa2, 0x04, 0xdd, 0xc0, 0x20, 0x40,
-	0x5e, 0x67, 0x14, 0xac, 0x6f, 0x21, 0x1b, 0xe8, 0x8d, 0x68, 0xb5, 0xea, 0xcd, 0x91, 0x55, 0x3d,
-	0x47, 0x56, 0x1f, 0x89, 0x39, 0xb2, 0xac, 0x3c, 0x13, 0xd1, 0x46, 0xf1, 0x86, 0x04, 0x17, 0x71,
-	0x5e, 0x6a, 0x48, 0x86, 0x54, 0x1b, 0x2d, 0xf0, 0x1d, 0x58, 0x0a, 0x35, 0x4b, 0xa4, 0xfc, 0x18,
-	0x35, 0x32, 0x95, 0x37, 0x22, 0xbf, 0x29, 0x31, 0xb7, 0xa4, 0x98, 0x35, 0x2c, 0x73, 0xc6, 0x94,
-	0x47, 0x82, 0x82, 0x3e, 0x85, 0xf4, 0x3c, 0x1b, 0x8a, 0x1e, 0x7e, 0x12, 0x5c, 0x90, 0xe0, 0x25,
-	0x94, 0x15, 0x60, 0x3d, 0x40, 0xee, 0x41, 0x4a, 0xb6, 0xed, 0x38, 0x16, 0x1a, 0xd7, 0xf0, 0xb8,
-	0x17, 0xe3, 0xbc, 0x04, 0x01, 0xca, 0xa8, 0x22, 0xee, 0x22, 0x53, 0xce, 0x6d, 0x7e, 0x03, 0xd6,
-	0xd6, 0x47, 0x8d, 0x7f, 0xda, 0xfa, 0xc8, 0x8e, 0x1d, 0xae, 0x18, 0xa5, 0xa4, 0x41, 0x3d, 0xe8,
-	0x97, 0xb0, 0xe8, 0x0d, 0x51, 0x3a, 0x1d, 0x42, 0xb3, 0x62, 0x39, 0xc6, 0x04, 0x5c, 0x91, 0xc8,
-	0x12, 0x5a, 0x95, 0x09, 0x21, 0xba, 0xb6, 0x97, 0x9e, 0x02, 0x2f, 0x60, 0x2d, 0x00, 0x7f, 0x3a,
-	0xd4, 0xbd, 0x63, 0x6a, 0x5e, 0x8c, 0xc5, 0x87, 0x2a, 0x71, 0x1a, 0x6f, 0x58, 0x82, 0x7a, 0xaa,
-	0x07, 0x5b, 0x35, 0xff, 0x05, 0x1c, 0x34, 0x35, 0x14, 0xc6, 0x4a, 0xda, 0x92, 0x92, 0x36, 0x51,
-	0x25, 0x46, 0x12, 0x51, 0xec, 0x26, 0x64, 0x1a, 0xac, 0xd7, 0x6b, 0x91, 0x93, 0xd3, 0xd8, 0xb8,
-	0xc6, 0xc9, 0x58, 0x93, 0x32, 0x96, 0x71, 0x4e, 0xca, 0x50, 0x14, 0x91, 0x77, 0x8e, 0x7c, 0x32,
-	0xa7, 0x1e, 0xb7, 0x58, 0x01, 0x78, 0xdc, 0x47, 0x62, 0x1f, 0xc4, 0x70, 0x64, 0x88, 0x3e, 0xa6,
-	0xb3, 0xdd, 0x45, 0x4f, 0x20, 0x25, 0xc6, 0xd2, 0x57, 0x36, 0x42, 0x65, 0x3a, 0x96, 0x09, 0xda,
-	0x63, 0xc4, 0x14, 0x06, 0x7c, 0x03, 0xd9, 0xc0, 0x8c, 0x8b, 0xd4, 0x53, 0x30, 0x3d, 0x26, 0xc7,
-	0x52, 0x43, 0xf5, 0x2f, 0xa8, 0xc1, 0xb2, 0xfc, 0x25, 0x01, 0xeb, 0xb1, 0x23, 0x34, 0xda, 0x9a,
-	0x12, 0x16, 0x39, 0x63, 0xc7, 0x8a, 0xde, 0x91, 0xa2, 0x6f, 0xe3, 0xcd, 0x49, 0xd1, 0x46, 0x9b,
-	0x39, 0x06, 0xf3, 0x41, 0x42, 0x95, 0x3d, 0x48, 0x89, 0x51, 0x62, 0x5e, 0x49, 0x07, 0xc7, 0x8d,
-	0x70, 0x49, 0x8b, 0x61, 0x03, 0xed, 0x42, 0x4a, 0x0c, 0x1b, 0x48, 0xb5, 0xea, 0xc0, 0x80, 0xaf,
-	0x01, 0xc1, 0x59, 0x24, 0x0c, 0xe8, 0x59, 0x2e, 0xbf, 0x93, 0x78, 0xf8, 0xc6, 0xef, 0xe7, 0x95,
-	0xc4, 0xcb, 0xf3, 0x4a, 0xe2, 0xaf, 0xf3, 0x4a, 0xe2, 0xd7, 0x8b, 0xca, 0x95, 0x97, 0x17, 0x95,
-	0x2b, 0x7f, 0x5e, 0x54, 0xae, 0x7c, 0xbd, 0xe0, 0xa9, 0xb4, 0x28, 0xff, 0xbd, 0xff, 0x6f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x24, 0x22, 0x00, 0x6e, 0x0d, 0x10, 0x00, 0x00,
+func (m *SaveVersionResponse) Reset()         { *m = SaveVersionResponse{} }
+func (m *SaveVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*SaveVersionResponse) ProtoMessage()    {}
+func (*SaveVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{31}
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// IAVLServiceClient is the client API for IAVLService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IAVLServiceClient interface {
-	// Has returns a result containing a boolean on whether or not the IAVL tree
-	// has a given key at a specific tree version.
-	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error)
-	HasVersioned(ctx context.Context, in *HasVersionedRequest, opts ...grpc.CallOption) (*HasResponse, error)
-	// Get returns a result containing the index and value for a given
-	// key based on the current state (version) of the tree.
-	// If the key does not exist, Get returns the index of the next value.
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	// GetByIndex returns a result containing the key and value for a given
-	// index based on the current state (version) of the tree.
-	GetByIndex(ctx context.Context, in *GetByIndexRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error)
-	// GetWithProof returns a result containing the IAVL tree version and value for
-	// a given key based on the current state (version) of the tree including a
-	// verifiable Merkle proof.
-	GetWithProof(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error)
-	// GetVersioned returns a result containing the IAVL tree version and value
-	// for a given key at a specific tree version.
-	GetVersioned(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	// GetVersionedWithProof returns a result containing the IAVL tree version and
-	// value for a given key at a specific tree version including a verifiable Merkle
-	// proof.
-	GetVersionedWithProof(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error)
-	// Set returns a result after inserting a key/value pair into the IAVL tree
-	// based on the current state (version) of the tree.
-	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
-	// Remove returns a result after removing a key/value pair from the IAVL tree
-	// based on the current state (version) of the tree.
-	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error)
-	// SaveVersion saves a new IAVL tree version to the DB based on the current
-	// state (version) of the tree. It returns a result containing the hash and
-	// new version number.
-	SaveVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SaveVersionResponse, error)
-	// DeleteVersion deletes an IAVL tree version from the DB. The version can then
-	// no longer be accessed. It returns a result containing the version and root
-	// hash of the versioned tree that was deleted.
-	DeleteVersion(ctx context.Context, in *DeleteVersionRequest, opts ...grpc.CallOption) (*DeleteVersionResponse, error)
-	// Version returns the IAVL tree version based on the current state.
-	Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionResponse, error)
-	// Hash returns the IAVL tree root hash based on the current state.
-	Hash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HashResponse, error)
-	// VersionExists returns a result containing a boolean on whether or not a given
-	// version exists in the IAVL tree.
-	VersionExists(ctx context.Context, in *VersionExistsRequest, opts ...grpc.CallOption) (*VersionExistsResponse, error)
-	// Verify verifies an IAVL range proof returning an error if the proof is
-	// invalid.
-	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// VerifyItem verifies if a given key/value pair in an IAVL range proof returning
-	// an error if the proof or key is invalid.
-	VerifyItem(ctx context.Context, in *VerifyItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// VerifyAbsence verifies the absence of a given key in an IAVL range proof
-	// returning an error if the proof or key is invalid.
-	VerifyAbsence(ctx context.Context, in *VerifyAbsenceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Rollback resets the working tree to the latest saved version, discarding
-	// any unsaved modifications.
-	Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Returns the committed versions
-	GetAvailableVersions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAvailableVersionsResponse, error)
-	// Load the most recent version
-	Load(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Load a specific version
-	LoadVersion(ctx context.Context, in *LoadVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Load a specific version and delete all the more recent versions
-	LoadVersionForOverwriting(ctx context.Context, in *LoadVersionForOverwritingRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Get the number of leaves in the tree
-	Size(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SizeResponse, error)
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (IAVLService_ListClient, error)
+func (m *SaveVersionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-type iAVLServiceClient struct {
-	cc *grpc.ClientConn
+func (m *SaveVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SaveVersionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func NewIAVLServiceClient(cc *grpc.ClientConn) IAVLServiceClient {
-	return &iAVLServiceClient{cc}
+func (m *SaveVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveVersionResponse.Merge(m, src)
+}
+func (m *SaveVersionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SaveVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveVersionResponse.DiscardUnknown(m)
 }
 
-func (c *iAVLServiceClient) Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error) {
-	out := new(HasResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Has", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SaveVersionResponse proto.InternalMessageInfo
+
+func (m *SaveVersionResponse) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iAVLServiceClient) HasVersioned(ctx context.Context, in *HasVersionedRequest, opts ...grpc.CallOption) (*HasResponse, error) {
-	out := new(HasResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/HasVersioned", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SaveVersionResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	return out, nil
+	return 0
 }
 
-func (c *iAVLServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DeleteVersionResponse struct {
+	RootHash []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Version  int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (c *iAVLServiceClient) GetByIndex(ctx context.Context, in *GetByIndexRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error) {
-	out := new(GetByIndexResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetByIndex", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteVersionResponse) Reset()         { *m = DeleteVersionResponse{} }
+func (m *DeleteVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteVersionResponse) ProtoMessage()    {}
+func (*DeleteVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{32}
+}
+func (m *DeleteVersionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteVersionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *DeleteVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVersionResponse.Merge(m, src)
+}
+func (m *DeleteVersionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVersionResponse.DiscardUnknown(m)
 }
 
-func (c *iAVLServiceClient) GetWithProof(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error) {
-	out := new(GetWithProofResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetWithProof", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeleteVersionResponse proto.InternalMessageInfo
+
+func (m *DeleteVersionResponse) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iAVLServiceClient) GetVersioned(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetVersioned", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteVersionResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	return out, nil
+	return 0
 }
 
-func (c *iAVLServiceClient) GetVersionedWithProof(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error) {
-	out := new(GetWithProofResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetVersionedWithProof", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type VersionResponse struct {
+	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (c *iAVLServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
-	out := new(SetResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Set", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
+func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
+func (*VersionResponse) ProtoMessage()    {}
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{33}
 }
-
-func (c *iAVLServiceClient) Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error) {
-	out := new(RemoveResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Remove", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *iAVLServiceClient) SaveVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SaveVersionResponse, error) {
-	out := new(SaveVersionResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/SaveVersion", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *VersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
 }
-
-func (c *iAVLServiceClient) DeleteVersion(ctx context.Context, in *DeleteVersionRequest, opts ...grpc.CallOption) (*DeleteVersionResponse, error) {
-	out := new(DeleteVersionResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/DeleteVersion", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *VersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionResponse.Merge(m, src)
+}
+func (m *VersionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionResponse.DiscardUnknown(m)
 }
 
-func (c *iAVLServiceClient) Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
-	out := new(VersionResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Version", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_VersionResponse proto.InternalMessageInfo
+
+func (m *VersionResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	return out, nil
+	return 0
 }
 
-func (c *iAVLServiceClient) Hash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HashResponse, error) {
-	out := new(HashResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Hash", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type HashResponse struct {
+	RootHash []byte `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
 }
 
-func (c *iAVLServiceClient) VersionExists(ctx context.Context, in *VersionExistsRequest, opts ...grpc.CallOption) (*VersionExistsResponse, error) {
-	out := new(VersionExistsResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VersionExists", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *HashResponse) Reset()         { *m = HashResponse{} }
+func (m *HashResponse) String() string { return proto.CompactTextString(m) }
+func (*HashResponse) ProtoMessage()    {}
+func (*HashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{34}
+}
+func (m *HashResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HashResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *HashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashResponse.Merge(m, src)
+}
+func (m *HashResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *HashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashResponse.DiscardUnknown(m)
 }
 
-func (c *iAVLServiceClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Verify", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_HashResponse proto.InternalMessageInfo
+
+func (m *HashResponse) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iAVLServiceClient) VerifyItem(ctx context.Context, in *VerifyItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyItem", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type VersionExistsResponse struct {
+	Result bool `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 }
 
-func (c *iAVLServiceClient) VerifyAbsence(ctx context.Context, in *VerifyAbsenceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyAbsence", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *VersionExistsResponse) Reset()         { *m = VersionExistsResponse{} }
+func (m *VersionExistsResponse) String() string { return proto.CompactTextString(m) }
+func (*VersionExistsResponse) ProtoMessage()    {}
+func (*VersionExistsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{35}
+}
+func (m *VersionExistsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersionExistsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersionExistsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *VersionExistsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionExistsResponse.Merge(m, src)
+}
+func (m *VersionExistsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VersionExistsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionExistsResponse.DiscardUnknown(m)
 }
 
-func (c *iAVLServiceClient) Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Rollback", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_VersionExistsResponse proto.InternalMessageInfo
+
+func (m *VersionExistsResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
 	}
-	return out, nil
+	return false
 }
 
-func (c *iAVLServiceClient) GetAvailableVersions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAvailableVersionsResponse, error) {
-	out := new(GetAvailableVersionsResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetAvailableVersions", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetWithProofResponse struct {
+	Value []byte      `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Proof *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
 }
 
-func (c *iAVLServiceClient) Load(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Load", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetWithProofResponse) Reset()         { *m = GetWithProofResponse{} }
+func (m *GetWithProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWithProofResponse) ProtoMessage()    {}
+func (*GetWithProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{36}
+}
+func (m *GetWithProofResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetWithProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetWithProofResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *GetWithProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWithProofResponse.Merge(m, src)
+}
+func (m *GetWithProofResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetWithProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWithProofResponse.DiscardUnknown(m)
 }
 
-func (c *iAVLServiceClient) LoadVersion(ctx context.Context, in *LoadVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/LoadVersion", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetWithProofResponse proto.InternalMessageInfo
+
+func (m *GetWithProofResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iAVLServiceClient) LoadVersionForOverwriting(ctx context.Context, in *LoadVersionForOverwritingRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/LoadVersionForOverwriting", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetWithProofResponse) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iAVLServiceClient) Size(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SizeResponse, error) {
-	out := new(SizeResponse)
-	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Size", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetWithValueHashProofResponse struct {
+	ValueHash []byte      `protobuf:"bytes,1,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Proof     *RangeProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	// ics23_proof is the equivalent protobuf-encoded ICS23 CommitmentProof,
+	// verifiable with the value hash using iavl.VerifyValueHashMembership().
+	Ics23Proof []byte `protobuf:"bytes,3,opt,name=ics23_proof,json=ics23Proof,proto3" json:"ics23_proof,omitempty"`
 }
 
-func (c *iAVLServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (IAVLService_ListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_IAVLService_serviceDesc.Streams[0], "/iavl.IAVLService/List", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &iAVLServiceListClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *GetWithValueHashProofResponse) Reset()         { *m = GetWithValueHashProofResponse{} }
+func (m *GetWithValueHashProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWithValueHashProofResponse) ProtoMessage()    {}
+func (*GetWithValueHashProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{37}
+}
+func (m *GetWithValueHashProofResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetWithValueHashProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetWithValueHashProofResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
 }
-
-type IAVLService_ListClient interface {
-	Recv() (*ListResponse, error)
-	grpc.ClientStream
+func (m *GetWithValueHashProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWithValueHashProofResponse.Merge(m, src)
+}
+func (m *GetWithValueHashProofResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetWithValueHashProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWithValueHashProofResponse.DiscardUnknown(m)
 }
 
-type iAVLServiceListClient struct {
-	grpc.ClientStream
+var xxx_messageInfo_GetWithValueHashProofResponse proto.InternalMessageInfo
+
+func (m *GetWithValueHashProofResponse) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
 }
 
-func (x *iAVLServiceListClient) Recv() (*ListResponse, error) {
-	m := new(ListResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *GetWithValueHashProofResponse) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
 	}
-	return m, nil
+	return nil
 }
 
-// IAVLServiceServer is the server API for IAVLService service.
-type IAVLServiceServer interface {
-	// Has returns a result containing a boolean on whether or not the IAVL tree
-	// has a given key at a specific tree version.
-	Has(context.Context, *HasRequest) (*HasResponse, error)
-	HasVersioned(context.Context, *HasVersionedRequest) (*HasResponse, error)
-	// Get returns a result containing the index and value for a given
-	// key based on the current state (version) of the tree.
-	// If the key does not exist, Get returns the index of the next value.
-	Get(context.Context, *GetRequest) (*GetResponse, error)
-	// GetByIndex returns a result containing the key and value for a given
-	// index based on the current state (version) of the tree.
-	GetByIndex(context.Context, *GetByIndexRequest) (*GetByIndexResponse, error)
-	// GetWithProof returns a result containing the IAVL tree version and value for
-	// a given key based on the current state (version) of the tree including a
-	// verifiable Merkle proof.
-	GetWithProof(context.Context, *GetRequest) (*GetWithProofResponse, error)
-	// GetVersioned returns a result containing the IAVL tree version and value
-	// for a given key at a specific tree version.
-	GetVersioned(context.Context, *GetVersionedRequest) (*GetResponse, error)
-	// GetVersionedWithProof returns a result containing the IAVL tree version and
-	// value for a given key at a specific tree version including a verifiable Merkle
-	// proof.
-	GetVersionedWithProof(context.Context, *GetVersionedRequest) (*GetWithProofResponse, error)
-	// Set returns a result after inserting a key/value pair into the IAVL tree
-	// based on the current state (version) of the tree.
-	Set(context.Context, *SetRequest) (*SetResponse, error)
-	// Remove returns a result after removing a key/value pair from the IAVL tree
-	// based on the current state (version) of the tree.
-	Remove(context.Context, *RemoveRequest) (*RemoveResponse, error)
-	// SaveVersion saves a new IAVL tree version to the DB based on the current
-	// state (version) of the tree. It returns a result containing the hash and
-	// new version number.
-	SaveVersion(context.Context, *empty.Empty) (*SaveVersionResponse, error)
-	// DeleteVersion deletes an IAVL tree version from the DB. The version can then
-	// no longer be accessed. It returns a result containing the version and root
-	// hash of the versioned tree that was deleted.
-	DeleteVersion(context.Context, *DeleteVersionRequest) (*DeleteVersionResponse, error)
-	// Version returns the IAVL tree version based on the current state.
-	Version(context.Context, *empty.Empty) (*VersionResponse, error)
-	// Hash returns the IAVL tree root hash based on the current state.
-	Hash(context.Context, *empty.Empty) (*HashResponse, error)
-	// VersionExists returns a result containing a boolean on whether or not a given
-	// version exists in the IAVL tree.
-	VersionExists(context.Context, *VersionExistsRequest) (*VersionExistsResponse, error)
-	// Verify verifies an IAVL range proof returning an error if the proof is
-	// invalid.
-	Verify(context.Context, *VerifyRequest) (*empty.Empty, error)
-	// VerifyItem verifies if a given key/value pair in an IAVL range proof returning
-	// an error if the proof or key is invalid.
-	VerifyItem(context.Context, *VerifyItemRequest) (*empty.Empty, error)
-	// VerifyAbsence verifies the absence of a given key in an IAVL range proof
-	// returning an error if the proof or key is invalid.
-	VerifyAbsence(context.Context, *VerifyAbsenceRequest) (*empty.Empty, error)
-	// Rollback resets the working tree to the latest saved version, discarding
-	// any unsaved modifications.
-	Rollback(context.Context, *empty.Empty) (*empty.Empty, error)
-	// Returns the committed versions
-	GetAvailableVersions(context.Context, *empty.Empty) (*GetAvailableVersionsResponse, error)
-	// Load the most recent version
-	Load(context.Context, *empty.Empty) (*empty.Empty, error)
-	// Load a specific version
-	LoadVersion(context.Context, *LoadVersionRequest) (*empty.Empty, error)
-	// Load a specific version and delete all the more recent versions
-	LoadVersionForOverwriting(context.Context, *LoadVersionForOverwritingRequest) (*empty.Empty, error)
-	// Get the number of leaves in the tree
-	Size(context.Context, *empty.Empty) (*SizeResponse, error)
-	List(*ListRequest, IAVLService_ListServer) error
+func (m *GetWithValueHashProofResponse) GetIcs23Proof() []byte {
+	if m != nil {
+		return m.Ics23Proof
+	}
+	return nil
 }
 
-// UnimplementedIAVLServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedIAVLServiceServer struct {
+type GetAvailableVersionsResponse struct {
+	Versions []int64 `protobuf:"varint,1,rep,packed,name=versions,proto3" json:"versions,omitempty"`
 }
 
-func (*UnimplementedIAVLServiceServer) Has(ctx context.Context, req *HasRequest) (*HasResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Has not implemented")
+func (m *GetAvailableVersionsResponse) Reset()         { *m = GetAvailableVersionsResponse{} }
+func (m *GetAvailableVersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAvailableVersionsResponse) ProtoMessage()    {}
+func (*GetAvailableVersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{38}
 }
-func (*UnimplementedIAVLServiceServer) HasVersioned(ctx context.Context, req *HasVersionedRequest) (*HasResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HasVersioned not implemented")
+func (m *GetAvailableVersionsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedIAVLServiceServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+func (m *GetAvailableVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAvailableVersionsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedIAVLServiceServer) GetByIndex(ctx context.Context, req *GetByIndexRequest) (*GetByIndexResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetByIndex not implemented")
+func (m *GetAvailableVersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAvailableVersionsResponse.Merge(m, src)
 }
-func (*UnimplementedIAVLServiceServer) GetWithProof(ctx context.Context, req *GetRequest) (*GetWithProofResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetWithProof not implemented")
+func (m *GetAvailableVersionsResponse) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedIAVLServiceServer) GetVersioned(ctx context.Context, req *GetVersionedRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVersioned not implemented")
+func (m *GetAvailableVersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAvailableVersionsResponse.DiscardUnknown(m)
 }
-func (*UnimplementedIAVLServiceServer) GetVersionedWithProof(ctx context.Context, req *GetVersionedRequest) (*GetWithProofResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVersionedWithProof not implemented")
+
+var xxx_messageInfo_GetAvailableVersionsResponse proto.InternalMessageInfo
+
+func (m *GetAvailableVersionsResponse) GetVersions() []int64 {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
 }
-func (*UnimplementedIAVLServiceServer) Set(ctx context.Context, req *SetRequest) (*SetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+
+type SizeResponse struct {
+	Size_ int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
-func (*UnimplementedIAVLServiceServer) Remove(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+
+func (m *SizeResponse) Reset()         { *m = SizeResponse{} }
+func (m *SizeResponse) String() string { return proto.CompactTextString(m) }
+func (*SizeResponse) ProtoMessage()    {}
+func (*SizeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{39}
 }
-func (*UnimplementedIAVLServiceServer) SaveVersion(ctx context.Context, req *empty.Empty) (*SaveVersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SaveVersion not implemented")
+func (m *SizeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedIAVLServiceServer) DeleteVersion(ctx context.Context, req *DeleteVersionRequest) (*DeleteVersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteVersion not implemented")
+func (m *SizeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SizeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedIAVLServiceServer) Version(ctx context.Context, req *empty.Empty) (*VersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+func (m *SizeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SizeResponse.Merge(m, src)
 }
-func (*UnimplementedIAVLServiceServer) Hash(ctx context.Context, req *empty.Empty) (*HashResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Hash not implemented")
+func (m *SizeResponse) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedIAVLServiceServer) VersionExists(ctx context.Context, req *VersionExistsRequest) (*VersionExistsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VersionExists not implemented")
+func (m *SizeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SizeResponse.DiscardUnknown(m)
 }
-func (*UnimplementedIAVLServiceServer) Verify(ctx context.Context, req *VerifyRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+
+var xxx_messageInfo_SizeResponse proto.InternalMessageInfo
+
+func (m *SizeResponse) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
 }
-func (*UnimplementedIAVLServiceServer) VerifyItem(ctx context.Context, req *VerifyItemRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifyItem not implemented")
+
+type ListResponse struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// version is the version of the leaf, if requested.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// proof is the range proof item of the leaf, if requested. The items of all
+	// responses form a streamed range proof which can be verified with
+	// iavl.RangeProofVerifier.
+	Proof *RangeProofItem `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
+	// boundary is true if the proof item is for a leaf outside of the range,
+	// which proves the completeness of the range. Key and value are then unset.
+	Boundary bool `protobuf:"varint,5,opt,name=boundary,proto3" json:"boundary,omitempty"`
 }
-func (*UnimplementedIAVLServiceServer) VerifyAbsence(ctx context.Context, req *VerifyAbsenceRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifyAbsence not implemented")
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{40}
 }
-func (*UnimplementedIAVLServiceServer) Rollback(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedIAVLServiceServer) GetAvailableVersions(ctx context.Context, req *empty.Empty) (*GetAvailableVersionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableVersions not implemented")
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedIAVLServiceServer) Load(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Load not implemented")
+func (m *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(m, src)
 }
-func (*UnimplementedIAVLServiceServer) LoadVersion(ctx context.Context, req *LoadVersionRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoadVersion not implemented")
+func (m *ListResponse) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedIAVLServiceServer) LoadVersionForOverwriting(ctx context.Context, req *LoadVersionForOverwritingRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoadVersionForOverwriting not implemented")
-}
-func (*UnimplementedIAVLServiceServer) Size(ctx context.Context, req *empty.Empty) (*SizeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Size not implemented")
-}
-func (*UnimplementedIAVLServiceServer) List(req *ListRequest, srv IAVLService_ListServer) error {
-	return status.Errorf(codes.Unimplemented, "method List not implemented")
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
 }
 
-func RegisterIAVLServiceServer(s *grpc.Server, srv IAVLServiceServer) {
-	s.RegisterService(&_IAVLService_serviceDesc, srv)
-}
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
 
-func _IAVLService_Has_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HasRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Has(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Has",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Has(ctx, req.(*HasRequest))
+func (m *ListResponse) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_HasVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HasVersionedRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).HasVersioned(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/HasVersioned",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).HasVersioned(ctx, req.(*HasVersionedRequest))
+func (m *ListResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Get(ctx, req.(*GetRequest))
+func (m *ListResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _IAVLService_GetByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetByIndexRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).GetByIndex(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/GetByIndex",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).GetByIndex(ctx, req.(*GetByIndexRequest))
+func (m *ListResponse) GetProof() *RangeProofItem {
+	if m != nil {
+		return m.Proof
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_GetWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).GetWithProof(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/GetWithProof",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).GetWithProof(ctx, req.(*GetRequest))
+func (m *ListResponse) GetBoundary() bool {
+	if m != nil {
+		return m.Boundary
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _IAVLService_GetVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVersionedRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).GetVersioned(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/GetVersioned",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).GetVersioned(ctx, req.(*GetVersionedRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+// RangeProofItem is a Protobuf representation of iavl.RangeProofItem.
+type RangeProofItem struct {
+	Path *PathToLeaf    `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Leaf *ProofLeafNode `protobuf:"bytes,2,opt,name=leaf,proto3" json:"leaf,omitempty"`
 }
 
-func _IAVLService_GetVersionedWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVersionedRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).GetVersionedWithProof(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/GetVersionedWithProof",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).GetVersionedWithProof(ctx, req.(*GetVersionedRequest))
+func (m *RangeProofItem) Reset()         { *m = RangeProofItem{} }
+func (m *RangeProofItem) String() string { return proto.CompactTextString(m) }
+func (*RangeProofItem) ProtoMessage()    {}
+func (*RangeProofItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{41}
+}
+func (m *RangeProofItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RangeProofItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RangeProofItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *RangeProofItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeProofItem.Merge(m, src)
+}
+func (m *RangeProofItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *RangeProofItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeProofItem.DiscardUnknown(m)
 }
 
-func _IAVLService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Set(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Set",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Set(ctx, req.(*SetRequest))
+var xxx_messageInfo_RangeProofItem proto.InternalMessageInfo
+
+func (m *RangeProofItem) GetPath() *PathToLeaf {
+	if m != nil {
+		return m.Path
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Remove(ctx, in)
+func (m *RangeProofItem) GetLeaf() *ProofLeafNode {
+	if m != nil {
+		return m.Leaf
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Remove",
+	return nil
+}
+
+type GetNodeResponse struct {
+	Node []byte `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (m *GetNodeResponse) Reset()         { *m = GetNodeResponse{} }
+func (m *GetNodeResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeResponse) ProtoMessage()    {}
+func (*GetNodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{42}
+}
+func (m *GetNodeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetNodeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Remove(ctx, req.(*RemoveRequest))
+}
+func (m *GetNodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeResponse.Merge(m, src)
+}
+func (m *GetNodeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetNodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeResponse proto.InternalMessageInfo
+
+func (m *GetNodeResponse) GetNode() []byte {
+	if m != nil {
+		return m.Node
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_SaveVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+type KeySample struct {
+	Index int64       `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Key   []byte      `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte      `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Proof *RangeProof `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *KeySample) Reset()         { *m = KeySample{} }
+func (m *KeySample) String() string { return proto.CompactTextString(m) }
+func (*KeySample) ProtoMessage()    {}
+func (*KeySample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{43}
+}
+func (m *KeySample) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeySample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeySample.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).SaveVersion(ctx, in)
+}
+func (m *KeySample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeySample.Merge(m, src)
+}
+func (m *KeySample) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeySample) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeySample.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeySample proto.InternalMessageInfo
+
+func (m *KeySample) GetIndex() int64 {
+	if m != nil {
+		return m.Index
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/SaveVersion",
+	return 0
+}
+
+func (m *KeySample) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).SaveVersion(ctx, req.(*empty.Empty))
+	return nil
+}
+
+func (m *KeySample) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_DeleteVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteVersionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *KeySample) GetProof() *RangeProof {
+	if m != nil {
+		return m.Proof
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).DeleteVersion(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/DeleteVersion",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).DeleteVersion(ctx, req.(*DeleteVersionRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Version(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Version",
+type SampleWithProofsResponse struct {
+	Samples []*KeySample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+}
+
+func (m *SampleWithProofsResponse) Reset()         { *m = SampleWithProofsResponse{} }
+func (m *SampleWithProofsResponse) String() string { return proto.CompactTextString(m) }
+func (*SampleWithProofsResponse) ProtoMessage()    {}
+func (*SampleWithProofsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5cad6b4fafc2c047, []int{44}
+}
+func (m *SampleWithProofsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SampleWithProofsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SampleWithProofsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Version(ctx, req.(*empty.Empty))
+}
+func (m *SampleWithProofsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SampleWithProofsResponse.Merge(m, src)
+}
+func (m *SampleWithProofsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SampleWithProofsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SampleWithProofsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SampleWithProofsResponse proto.InternalMessageInfo
+
+func (m *SampleWithProofsResponse) GetSamples() []*KeySample {
+	if m != nil {
+		return m.Samples
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _IAVLService_Hash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
+func init() {
+	proto.RegisterType((*HasRequest)(nil), "iavl.HasRequest")
+	proto.RegisterType((*HasVersionedRequest)(nil), "iavl.HasVersionedRequest")
+	proto.RegisterType((*GetRequest)(nil), "iavl.GetRequest")
+	proto.RegisterType((*GetByIndexRequest)(nil), "iavl.GetByIndexRequest")
+	proto.RegisterType((*GetByIndexVersionedRequest)(nil), "iavl.GetByIndexVersionedRequest")
+	proto.RegisterType((*GetVersionedRequest)(nil), "iavl.GetVersionedRequest")
+	proto.RegisterType((*SetRequest)(nil), "iavl.SetRequest")
+	proto.RegisterType((*RemoveRequest)(nil), "iavl.RemoveRequest")
+	proto.RegisterType((*DeleteVersionRequest)(nil), "iavl.DeleteVersionRequest")
+	proto.RegisterType((*VersionExistsRequest)(nil), "iavl.VersionExistsRequest")
+	proto.RegisterType((*VerifyRequest)(nil), "iavl.VerifyRequest")
+	proto.RegisterType((*VerifyVersionedRequest)(nil), "iavl.VerifyVersionedRequest")
+	proto.RegisterType((*VerifyItemRequest)(nil), "iavl.VerifyItemRequest")
+	proto.RegisterType((*VerifyItemVersionedRequest)(nil), "iavl.VerifyItemVersionedRequest")
+	proto.RegisterType((*VerifyItemValueHashRequest)(nil), "iavl.VerifyItemValueHashRequest")
+	proto.RegisterType((*VerifyItemValueHashVersionedRequest)(nil), "iavl.VerifyItemValueHashVersionedRequest")
+	proto.RegisterType((*VerifyAbsenceRequest)(nil), "iavl.VerifyAbsenceRequest")
+	proto.RegisterType((*VerifyAbsenceVersionedRequest)(nil), "iavl.VerifyAbsenceVersionedRequest")
+	proto.RegisterType((*LoadVersionRequest)(nil), "iavl.LoadVersionRequest")
+	proto.RegisterType((*LoadVersionForOverwritingRequest)(nil), "iavl.LoadVersionForOverwritingRequest")
+	proto.RegisterType((*ListRequest)(nil), "iavl.ListRequest")
+	proto.RegisterType((*ListVersionedRequest)(nil), "iavl.ListVersionedRequest")
+	proto.RegisterType((*HashVersionedRequest)(nil), "iavl.HashVersionedRequest")
+	proto.RegisterType((*SizeVersionedRequest)(nil), "iavl.SizeVersionedRequest")
+	proto.RegisterType((*GetNodeRequest)(nil), "iavl.GetNodeRequest")
+	proto.RegisterType((*SampleWithProofsRequest)(nil), "iavl.SampleWithProofsRequest")
+	proto.RegisterType((*HasResponse)(nil), "iavl.HasResponse")
+	proto.RegisterType((*GetResponse)(nil), "iavl.GetResponse")
+	proto.RegisterType((*GetByIndexResponse)(nil), "iavl.GetByIndexResponse")
+	proto.RegisterType((*SetResponse)(nil), "iavl.SetResponse")
+	proto.RegisterType((*RemoveResponse)(nil), "iavl.RemoveResponse")
+	proto.RegisterType((*SaveVersionResponse)(nil), "iavl.SaveVersionResponse")
+	proto.RegisterType((*DeleteVersionResponse)(nil), "iavl.DeleteVersionResponse")
+	proto.RegisterType((*VersionResponse)(nil), "iavl.VersionResponse")
+	proto.RegisterType((*HashResponse)(nil), "iavl.HashResponse")
+	proto.RegisterType((*VersionExistsResponse)(nil), "iavl.VersionExistsResponse")
+	proto.RegisterType((*GetWithProofResponse)(nil), "iavl.GetWithProofResponse")
+	proto.RegisterType((*GetWithValueHashProofResponse)(nil), "iavl.GetWithValueHashProofResponse")
+	proto.RegisterType((*GetAvailableVersionsResponse)(nil), "iavl.GetAvailableVersionsResponse")
+	proto.RegisterType((*SizeResponse)(nil), "iavl.SizeResponse")
+	proto.RegisterType((*ListResponse)(nil), "iavl.ListResponse")
+	proto.RegisterType((*RangeProofItem)(nil), "iavl.RangeProofItem")
+	proto.RegisterType((*GetNodeResponse)(nil), "iavl.GetNodeResponse")
+	proto.RegisterType((*KeySample)(nil), "iavl.KeySample")
+	proto.RegisterType((*SampleWithProofsResponse)(nil), "iavl.SampleWithProofsResponse")
+}
+
+func init() { proto.RegisterFile("iavl/iavl_api.proto", fileDescriptor_5cad6b4fafc2c047) }
+
+var fileDescriptor_5cad6b4fafc2c047 = []byte{
+	// 1986 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcf, 0x6f, 0x1b, 0xc7,
+	0x15, 0xf6, 0x8a, 0x94, 0x44, 0x3f, 0x52, 0x96, 0x34, 0x24, 0x65, 0x79, 0x25, 0xd1, 0xea, 0xc8,
+	0x76, 0xfc, 0xa3, 0x10, 0x6d, 0x39, 0x40, 0x51, 0xd7, 0x28, 0x2a, 0xc3, 0x8e, 0xe4, 0x5a, 0x4d,
+	0x03, 0xd2, 0xb1, 0x8b, 0xa0, 0x05, 0xb1, 0x12, 0x87, 0xe2, 0xc2, 0xd4, 0x2e, 0xc3, 0x5d, 0x32,
+	0x61, 0x82, 0xb4, 0x45, 0x0f, 0x6d, 0x8e, 0xfd, 0x71, 0x29, 0xd0, 0x73, 0xcf, 0xfd, 0x17, 0x7a,
+	0xec, 0x31, 0x68, 0x2f, 0x3d, 0x16, 0x76, 0xff, 0x90, 0x60, 0xde, 0xcc, 0xee, 0xce, 0xee, 0xce,
+	0x92, 0x14, 0x92, 0xf8, 0x22, 0x71, 0x67, 0x67, 0xbf, 0xef, 0x7b, 0x6f, 0x66, 0xde, 0x7b, 0xf3,
+	0xa0, 0x6c, 0x5b, 0xa3, 0x5e, 0x9d, 0xff, 0x69, 0x59, 0x7d, 0x7b, 0xb7, 0x3f, 0x70, 0x7d, 0x97,
+	0xe4, 0xf9, 0xb3, 0xb9, 0x79, 0xea, 0xba, 0xa7, 0x3d, 0x56, 0xb7, 0xfa, 0x76, 0xdd, 0x72, 0x1c,
+	0xd7, 0xb7, 0x7c, 0xdb, 0x75, 0x3c, 0x31, 0xc7, 0xdc, 0x90, 0x6f, 0xf1, 0xe9, 0x78, 0xd8, 0xa9,
+	0xb3, 0xb3, 0xbe, 0x3f, 0x96, 0x2f, 0x57, 0x10, 0xb5, 0x3f, 0x70, 0xdd, 0x8e, 0x18, 0xa1, 0x35,
+	0x80, 0x43, 0xcb, 0x6b, 0xb0, 0x8f, 0x87, 0xcc, 0xf3, 0xc9, 0x0a, 0xe4, 0x5e, 0xb1, 0xf1, 0xba,
+	0xb1, 0x6d, 0xdc, 0x2c, 0x35, 0xf8, 0x4f, 0xba, 0x0f, 0xe5, 0x43, 0xcb, 0x7b, 0xc1, 0x06, 0x9e,
+	0xed, 0x3a, 0xac, 0x1d, 0x4c, 0x5c, 0x87, 0xc5, 0x91, 0x18, 0xc3, 0xc9, 0xb9, 0x46, 0xf0, 0x18,
+	0x40, 0xcc, 0x45, 0x10, 0x35, 0x80, 0x03, 0xe6, 0x67, 0x53, 0xdc, 0x82, 0xd5, 0x03, 0xe6, 0x3f,
+	0x1a, 0x3f, 0x75, 0xda, 0xec, 0xd3, 0x60, 0x5a, 0x05, 0xe6, 0x6d, 0xfe, 0x2c, 0xe1, 0xc5, 0x03,
+	0x3d, 0x02, 0x33, 0x9a, 0x7a, 0x0e, 0x51, 0x21, 0xda, 0x9c, 0x8a, 0xb6, 0x0f, 0xe5, 0x03, 0xe6,
+	0x7f, 0x23, 0xdb, 0xde, 0x05, 0x68, 0x4e, 0xb0, 0x8d, 0x13, 0x8f, 0xac, 0xde, 0x90, 0xc9, 0x6f,
+	0xc4, 0x03, 0xfd, 0x1e, 0x2c, 0x35, 0xd8, 0x99, 0x3b, 0x62, 0xd9, 0x4e, 0xb9, 0x0b, 0x95, 0xc7,
+	0xac, 0xc7, 0x7c, 0x26, 0xe5, 0x4d, 0x15, 0xc7, 0xbf, 0x90, 0x73, 0x9f, 0x7c, 0x6a, 0x7b, 0xbe,
+	0x37, 0xfd, 0x8b, 0xe7, 0xb0, 0xf4, 0x82, 0x0d, 0xec, 0xce, 0x38, 0x98, 0xba, 0x01, 0x17, 0x07,
+	0xae, 0xeb, 0xb7, 0xba, 0x96, 0xd7, 0x95, 0x62, 0x0a, 0x7c, 0xe0, 0xd0, 0xf2, 0xba, 0xe4, 0x06,
+	0xcc, 0xe3, 0xc6, 0x41, 0x53, 0x8a, 0x7b, 0x2b, 0xbb, 0x7c, 0x2f, 0xed, 0x36, 0x2c, 0xe7, 0x94,
+	0x7d, 0xc0, 0xc7, 0x1b, 0xe2, 0x35, 0xfd, 0x08, 0xd6, 0x04, 0xea, 0x39, 0x1c, 0x3b, 0x2b, 0xf6,
+	0x6f, 0x0d, 0x58, 0x15, 0xe0, 0x4f, 0x7d, 0x76, 0xf6, 0x6d, 0xca, 0x0e, 0x96, 0x20, 0xa7, 0x59,
+	0xbb, 0xbc, 0xba, 0x76, 0xbf, 0x37, 0xc0, 0x8c, 0x24, 0x7c, 0xfb, 0x36, 0xce, 0x2c, 0xe4, 0xcf,
+	0x71, 0x21, 0x7c, 0x8c, 0x1b, 0xfc, 0x1d, 0x3b, 0x65, 0x0b, 0x00, 0xe9, 0x05, 0xae, 0x10, 0x74,
+	0x71, 0x14, 0x90, 0xd3, 0xbf, 0x1a, 0xb0, 0xa3, 0x11, 0xf5, 0x56, 0xdc, 0x34, 0x45, 0xda, 0x19,
+	0x9e, 0x0f, 0xbb, 0x33, 0xde, 0x3f, 0xf6, 0x98, 0x73, 0xc2, 0xbe, 0x5b, 0x47, 0x51, 0x0f, 0xb6,
+	0x62, 0x74, 0x6f, 0xc3, 0x05, 0x74, 0x17, 0xc8, 0x91, 0x6b, 0xb5, 0x67, 0x8e, 0x19, 0x0f, 0x61,
+	0x5b, 0x99, 0xff, 0x9e, 0x3b, 0xf8, 0xf9, 0x88, 0x0d, 0x3e, 0x19, 0xd8, 0xbe, 0xed, 0x9c, 0x4e,
+	0xff, 0xfa, 0x1f, 0x06, 0x14, 0x8f, 0x6c, 0x2f, 0x0c, 0x7f, 0x57, 0xa0, 0xd0, 0x19, 0xb8, 0x67,
+	0xad, 0x28, 0x94, 0x2d, 0xf2, 0xe7, 0x67, 0x6c, 0x4c, 0xaa, 0xb0, 0xe0, 0xbb, 0xad, 0x28, 0x78,
+	0xce, 0xfb, 0x2e, 0x1f, 0xae, 0x01, 0xb4, 0x99, 0x77, 0xc2, 0x9c, 0xb6, 0xed, 0x9c, 0xa2, 0x21,
+	0x85, 0x86, 0x32, 0x42, 0x6e, 0xc1, 0x8a, 0xed, 0x9c, 0xf4, 0x86, 0x6d, 0xd6, 0x92, 0xa4, 0x1e,
+	0x2e, 0x6c, 0xa1, 0xb1, 0x2c, 0xc7, 0xa5, 0x74, 0x8f, 0xec, 0xc0, 0x52, 0x30, 0x55, 0x38, 0x6f,
+	0x1e, 0xe7, 0x95, 0xe4, 0x20, 0x3a, 0x8e, 0xfe, 0xdb, 0x80, 0x0a, 0x57, 0x7c, 0x8e, 0xc5, 0x50,
+	0x8d, 0x9a, 0xcb, 0x32, 0x2a, 0x97, 0x6d, 0x54, 0x7e, 0x26, 0xa3, 0xe6, 0x67, 0x34, 0x6a, 0x41,
+	0x63, 0xd4, 0x5d, 0xa8, 0x9c, 0xef, 0x8c, 0xf1, 0x2f, 0x9a, 0xf6, 0x67, 0xe7, 0xd8, 0x92, 0xf4,
+	0x1a, 0x5c, 0x3a, 0x60, 0xfe, 0xfb, 0x6e, 0x3b, 0x3c, 0x36, 0x04, 0xf2, 0xca, 0x89, 0xc1, 0xdf,
+	0xf4, 0x43, 0xb8, 0xdc, 0xb4, 0xce, 0xfa, 0x3d, 0xf6, 0xd2, 0xf6, 0xbb, 0x28, 0x6e, 0x7a, 0x16,
+	0xe2, 0x40, 0x1e, 0x63, 0x6d, 0xe9, 0x5c, 0xfc, 0x4d, 0x4a, 0x60, 0x38, 0xe8, 0xd4, 0x5c, 0xc3,
+	0x70, 0xe8, 0x75, 0x28, 0x62, 0x8d, 0xe2, 0xf5, 0x5d, 0xc7, 0x63, 0x64, 0x0d, 0x16, 0x06, 0xcc,
+	0x1b, 0xf6, 0x7c, 0x44, 0x2a, 0x34, 0xe4, 0x13, 0x7d, 0x01, 0x45, 0xac, 0x33, 0xe4, 0x34, 0x6d,
+	0x05, 0xa1, 0x4f, 0xc8, 0x3c, 0x06, 0x38, 0xae, 0xdf, 0xea, 0xb8, 0x43, 0xa7, 0x2d, 0xb7, 0x61,
+	0xc1, 0x71, 0xfd, 0xf7, 0xf8, 0x33, 0x7d, 0x08, 0x44, 0xad, 0x4f, 0x24, 0xfc, 0xac, 0xb9, 0xfe,
+	0x1d, 0x28, 0x36, 0x15, 0x55, 0xeb, 0xb0, 0x38, 0xec, 0xb7, 0x2d, 0x9f, 0xb5, 0xa5, 0xfa, 0xe0,
+	0x91, 0xfe, 0x04, 0x2e, 0x05, 0x45, 0x41, 0x64, 0x81, 0x00, 0x34, 0x54, 0xad, 0xeb, 0xb0, 0x38,
+	0xc0, 0x79, 0xc2, 0x65, 0x85, 0x46, 0xf0, 0x48, 0x8f, 0xa0, 0xdc, 0xb4, 0x46, 0x51, 0xc5, 0x20,
+	0x61, 0x26, 0x06, 0x38, 0x65, 0x5d, 0xe6, 0xe2, 0x4b, 0xfe, 0x3e, 0x54, 0x13, 0x15, 0xc8, 0x37,
+	0xc3, 0xbb, 0x03, 0xcb, 0x49, 0xa4, 0xec, 0xfd, 0x76, 0x07, 0x4a, 0x22, 0x99, 0xcd, 0xc0, 0x49,
+	0xeb, 0x50, 0x4d, 0x54, 0x3e, 0x53, 0x76, 0xca, 0x73, 0xa8, 0x1c, 0x30, 0x3f, 0xdc, 0xa4, 0x53,
+	0x1c, 0x3e, 0x6b, 0x71, 0xf2, 0x07, 0x03, 0xb6, 0x24, 0x6c, 0x98, 0xf8, 0xe2, 0xf8, 0xf1, 0x0c,
+	0x65, 0x24, 0x32, 0xd4, 0xcc, 0x71, 0xff, 0x2a, 0x14, 0xed, 0x13, 0x6f, 0xef, 0xbe, 0x8c, 0x09,
+	0x22, 0xf8, 0x00, 0x0e, 0x89, 0x88, 0xf0, 0x00, 0x36, 0x0f, 0x98, 0xbf, 0x3f, 0xb2, 0xec, 0x9e,
+	0x75, 0xdc, 0x0b, 0xc3, 0x49, 0xa8, 0xc3, 0x84, 0x42, 0x18, 0x79, 0x8c, 0xed, 0xdc, 0xcd, 0x5c,
+	0x23, 0x7c, 0xa6, 0x14, 0x4a, 0x3c, 0x36, 0x84, 0x73, 0xf9, 0xf1, 0xb4, 0x3f, 0x63, 0x72, 0x81,
+	0xf0, 0x37, 0xcf, 0xf2, 0x25, 0x11, 0xf8, 0xcf, 0x77, 0x18, 0xd4, 0x05, 0xcf, 0xc5, 0xa3, 0xc0,
+	0xed, 0xc0, 0xf6, 0x3c, 0xda, 0x5e, 0x49, 0xda, 0x8e, 0xf5, 0x9e, 0xb4, 0xdf, 0x84, 0xc2, 0x31,
+	0x3f, 0x99, 0xd6, 0x60, 0x2c, 0x03, 0x67, 0xf8, 0x4c, 0x5b, 0x70, 0x29, 0xfe, 0x11, 0xb9, 0x06,
+	0xf9, 0xbe, 0xe5, 0x0b, 0x77, 0x87, 0x4e, 0xfd, 0xc0, 0xf2, 0xbb, 0xcf, 0xdd, 0x23, 0x66, 0x75,
+	0x1a, 0xf8, 0x96, 0xbc, 0x03, 0xf9, 0x1e, 0xb3, 0x02, 0xd7, 0x97, 0xe5, 0x2c, 0x0e, 0xc2, 0x27,
+	0x61, 0xe0, 0xc3, 0x09, 0xf4, 0x3a, 0x2c, 0x87, 0x91, 0x30, 0x72, 0x91, 0xe3, 0xb6, 0x83, 0x5d,
+	0x83, 0xbf, 0xe9, 0xc7, 0x70, 0xf1, 0x19, 0x1b, 0x8b, 0x68, 0x98, 0x11, 0x8a, 0x52, 0xb7, 0x89,
+	0xc8, 0x69, 0x39, 0xed, 0xfe, 0xcb, 0x4f, 0xde, 0x7f, 0x4f, 0x60, 0x3d, 0x1d, 0x7d, 0xa5, 0xc4,
+	0x5b, 0xb0, 0xe8, 0xe1, 0x3b, 0xb1, 0xe0, 0xc5, 0xbd, 0x65, 0x81, 0x12, 0x6a, 0x6c, 0x04, 0xef,
+	0xf7, 0xfe, 0xb9, 0x05, 0xc5, 0xa7, 0xfb, 0x2f, 0x8e, 0x9a, 0x6c, 0x30, 0xb2, 0x4f, 0x18, 0xf9,
+	0x11, 0xe4, 0x0e, 0x2d, 0x8f, 0x48, 0xda, 0xe8, 0xb2, 0x68, 0xae, 0x2a, 0x23, 0x82, 0x86, 0x2e,
+	0xff, 0xee, 0x3f, 0xff, 0xff, 0xcb, 0xdc, 0x45, 0xb2, 0x58, 0x1f, 0xdd, 0xab, 0x77, 0x2d, 0x8f,
+	0xbc, 0xc4, 0x73, 0x1c, 0x26, 0x1a, 0x72, 0x25, 0xfc, 0x26, 0x99, 0x7c, 0x74, 0x70, 0x57, 0x10,
+	0xae, 0x4c, 0x56, 0x25, 0x5c, 0x90, 0x2f, 0x59, 0x9b, 0xab, 0x3a, 0x60, 0x7e, 0xa0, 0x2a, 0xba,
+	0x5f, 0x9a, 0xab, 0xca, 0x88, 0x4e, 0xd5, 0x29, 0xf3, 0xc9, 0x4b, 0x80, 0x28, 0xa2, 0x93, 0xcb,
+	0xe1, 0x17, 0xf1, 0x3b, 0xa8, 0xb9, 0x9e, 0x7e, 0x21, 0x11, 0xd7, 0x10, 0x71, 0x85, 0x5c, 0x92,
+	0x88, 0xc7, 0x63, 0xb1, 0xa4, 0x5f, 0x40, 0x39, 0x9a, 0x1d, 0x59, 0xbd, 0x9d, 0x04, 0x4a, 0x19,
+	0x9f, 0x4d, 0x75, 0x1b, 0xa9, 0xae, 0x11, 0xca, 0xa9, 0x3e, 0x97, 0x0e, 0xf8, 0x42, 0x21, 0x55,
+	0x9c, 0xf2, 0x0b, 0x28, 0xa9, 0x71, 0x4d, 0xe3, 0x1d, 0x33, 0x1c, 0x49, 0x45, 0x3f, 0x6a, 0x22,
+	0x53, 0x85, 0x10, 0x69, 0x54, 0xeb, 0x13, 0xdb, 0xef, 0x8a, 0x18, 0x43, 0x2c, 0x44, 0x4e, 0xad,
+	0xa3, 0xe6, 0xfa, 0xac, 0x5b, 0x80, 0x1d, 0x44, 0xde, 0x22, 0x1b, 0x29, 0x1b, 0x14, 0xf1, 0xbf,
+	0x86, 0xaa, 0x0a, 0x17, 0x59, 0x31, 0x81, 0x6b, 0x92, 0x39, 0xbb, 0x48, 0x7a, 0x93, 0xdc, 0x98,
+	0x40, 0xaa, 0x9a, 0x38, 0x84, 0xaa, 0xc4, 0x89, 0x47, 0x6f, 0x8d, 0x17, 0x77, 0x62, 0xb4, 0xfa,
+	0x60, 0x4f, 0xaf, 0x23, 0xff, 0x55, 0xb2, 0x15, 0x73, 0x67, 0x14, 0xff, 0x25, 0xed, 0xdf, 0x0c,
+	0xa8, 0x25, 0xed, 0x4e, 0x08, 0x98, 0xe0, 0x80, 0x99, 0x94, 0xfc, 0x10, 0x95, 0xdc, 0x27, 0xf7,
+	0xa6, 0x7a, 0x22, 0xa5, 0xee, 0xc7, 0x90, 0x6b, 0x46, 0xc7, 0xac, 0x99, 0x3a, 0x66, 0x4a, 0x69,
+	0x43, 0x09, 0xd2, 0x94, 0x1e, 0x18, 0xb7, 0x29, 0x9e, 0x34, 0x8f, 0xf9, 0xe4, 0xa7, 0xb0, 0x20,
+	0x8a, 0x1a, 0x22, 0x43, 0x6a, 0xac, 0xef, 0x61, 0x56, 0xe2, 0x83, 0x12, 0xa8, 0x8a, 0x40, 0xcb,
+	0x1c, 0x08, 0x38, 0x90, 0xa8, 0x6f, 0xc8, 0xaf, 0xa0, 0xa8, 0x94, 0x37, 0x64, 0x6d, 0x57, 0x74,
+	0xba, 0x76, 0x83, 0x4e, 0xd7, 0xee, 0x13, 0xde, 0xe9, 0x32, 0xa5, 0xb7, 0x34, 0x95, 0x10, 0xdd,
+	0x40, 0xe0, 0x2a, 0x07, 0x5e, 0x41, 0x85, 0xd6, 0x28, 0xac, 0xc1, 0xc9, 0x29, 0x2c, 0xc5, 0xea,
+	0x1d, 0x22, 0x37, 0x97, 0xae, 0x0d, 0x63, 0x6e, 0x68, 0xdf, 0x49, 0x9a, 0x2d, 0xa4, 0xb9, 0xcc,
+	0x69, 0xf0, 0x2c, 0xb5, 0x71, 0x56, 0x48, 0xf4, 0x33, 0x58, 0x9c, 0x66, 0x43, 0x55, 0xc0, 0x27,
+	0x81, 0xcb, 0x08, 0xbc, 0x44, 0x8a, 0x1c, 0x35, 0x80, 0x7b, 0x0c, 0x79, 0xac, 0x1e, 0xb2, 0xb0,
+	0x48, 0x18, 0x57, 0xc3, 0x72, 0x8a, 0xae, 0x20, 0x10, 0x90, 0x82, 0x0c, 0xac, 0x5d, 0xc2, 0x60,
+	0x29, 0x76, 0x89, 0x08, 0xac, 0xd7, 0xdd, 0x2c, 0xb4, 0x90, 0xd7, 0x10, 0xb2, 0x46, 0x36, 0xe3,
+	0x9b, 0x0c, 0xf7, 0x52, 0x74, 0xc8, 0xdb, 0xd8, 0x72, 0x8a, 0x4a, 0xb5, 0x80, 0x46, 0xd7, 0xb9,
+	0x32, 0x37, 0xb4, 0xef, 0x74, 0xd1, 0x4a, 0x12, 0xb4, 0x98, 0x00, 0xfd, 0x10, 0x16, 0xc4, 0xdd,
+	0x3b, 0xd8, 0x75, 0xb1, 0x36, 0x97, 0x99, 0xe1, 0x29, 0x5a, 0x43, 0xc8, 0x75, 0xb2, 0x86, 0x9b,
+	0x8e, 0xe7, 0x57, 0x71, 0x0a, 0xea, 0x23, 0x01, 0xf6, 0x39, 0x2c, 0x27, 0x3a, 0x5b, 0x64, 0x53,
+	0xc5, 0x4f, 0xf9, 0x29, 0x8b, 0x68, 0x0f, 0x89, 0xbe, 0x4f, 0x6e, 0xc7, 0x7d, 0x95, 0xa6, 0x54,
+	0x3c, 0x77, 0x0c, 0x10, 0x35, 0x56, 0x82, 0x9c, 0x95, 0xea, 0x85, 0x65, 0x52, 0xc6, 0x42, 0xb0,
+	0x86, 0xc8, 0xe6, 0xa8, 0x5f, 0x1a, 0x50, 0xd6, 0xf4, 0xb6, 0x82, 0xfc, 0x95, 0xdd, 0xf6, 0xca,
+	0xa4, 0xfd, 0x01, 0xd2, 0xde, 0x23, 0xf5, 0xa9, 0x96, 0x72, 0x01, 0x8a, 0xb9, 0xbf, 0x89, 0x29,
+	0x09, 0x4b, 0xe4, 0xb4, 0x92, 0x44, 0xdf, 0x2b, 0x53, 0x49, 0x2c, 0x1d, 0x64, 0xf2, 0x87, 0xf1,
+	0x8f, 0xfc, 0xdd, 0x80, 0xcd, 0x49, 0x9d, 0x2c, 0x72, 0x2b, 0x53, 0xca, 0xcc, 0xde, 0x79, 0x84,
+	0x9a, 0x1e, 0x92, 0x07, 0x33, 0x7a, 0x27, 0x8a, 0xce, 0x91, 0xa3, 0x5e, 0xc1, 0x52, 0xac, 0xcf,
+	0xa4, 0x9c, 0xa8, 0x54, 0xaf, 0x2b, 0x53, 0xc8, 0x0d, 0x14, 0xb2, 0x4d, 0x6a, 0x19, 0xce, 0xb1,
+	0x24, 0xf6, 0x9f, 0x0c, 0x58, 0x8b, 0x01, 0x47, 0xee, 0xd8, 0xd1, 0xd0, 0xce, 0xec, 0x88, 0x07,
+	0xc8, 0xff, 0x2e, 0xd9, 0x9b, 0xea, 0x08, 0xa9, 0x44, 0x71, 0x40, 0x13, 0x0a, 0x0d, 0xb7, 0xd7,
+	0x3b, 0xb6, 0x4e, 0x5e, 0x65, 0xc6, 0xc0, 0x2c, 0xde, 0xcb, 0xc8, 0xbb, 0xca, 0x23, 0x75, 0x09,
+	0x4d, 0x0f, 0x80, 0x06, 0x50, 0xd1, 0xdd, 0xa0, 0x32, 0x09, 0x68, 0x98, 0x87, 0x33, 0x6f, 0x5d,
+	0xf1, 0xf0, 0x62, 0x05, 0xd3, 0x02, 0x4b, 0x3c, 0xf2, 0x0c, 0xf2, 0xbc, 0x19, 0x77, 0x6e, 0x23,
+	0x64, 0x56, 0xe0, 0x46, 0x60, 0x3c, 0xef, 0x71, 0x90, 0x5f, 0x42, 0x51, 0xe9, 0xec, 0x11, 0x59,
+	0x5f, 0xa6, 0x9b, 0x83, 0x99, 0xa8, 0xc9, 0x5c, 0xc9, 0x51, 0xc3, 0x14, 0xf6, 0xa5, 0x01, 0x57,
+	0x32, 0x1b, 0x87, 0xe4, 0x46, 0x8a, 0x4c, 0xdb, 0x59, 0xcc, 0xa4, 0xbe, 0x83, 0xd4, 0xd7, 0x39,
+	0xf5, 0x76, 0x92, 0xba, 0xd5, 0x71, 0x07, 0x2d, 0x57, 0x21, 0x7b, 0x0c, 0x79, 0x7e, 0x5f, 0x9d,
+	0x96, 0xfe, 0xd4, 0x3b, 0x6d, 0x3c, 0xfd, 0xf1, 0x1b, 0x2d, 0x4f, 0x7f, 0xb1, 0x8e, 0x58, 0x70,
+	0x8a, 0x74, 0x6d, 0x32, 0x2d, 0x64, 0x46, 0xfa, 0xe3, 0xe0, 0xca, 0x5e, 0xdd, 0x87, 0x3c, 0xbf,
+	0x37, 0x13, 0x59, 0x3d, 0x29, 0xcd, 0x53, 0x93, 0xa8, 0x43, 0x3a, 0x9d, 0x3d, 0xdb, 0xf3, 0xef,
+	0x1a, 0xbc, 0x4c, 0x89, 0x75, 0x30, 0x03, 0xa5, 0xba, 0xb6, 0xa6, 0x16, 0x34, 0x43, 0x29, 0x87,
+	0x8f, 0x94, 0xde, 0x35, 0xc8, 0x21, 0x2c, 0xca, 0x8b, 0x2e, 0xa9, 0x84, 0xbb, 0x5b, 0xe9, 0x00,
+	0x9a, 0xd5, 0xc4, 0xa8, 0x4e, 0x34, 0xbf, 0x0b, 0x13, 0x17, 0x56, 0x92, 0x17, 0x53, 0xb2, 0x15,
+	0x54, 0x69, 0xda, 0x76, 0xa1, 0x59, 0xcb, 0x7a, 0x2d, 0x49, 0x36, 0x91, 0x64, 0x8d, 0x54, 0x12,
+	0xee, 0xc6, 0xf9, 0x8f, 0xae, 0xfe, 0xeb, 0x75, 0xcd, 0xf8, 0xea, 0x75, 0xcd, 0xf8, 0xdf, 0xeb,
+	0x9a, 0xf1, 0xc7, 0x37, 0xb5, 0x0b, 0x5f, 0xbd, 0xa9, 0x5d, 0xf8, 0xef, 0x9b, 0xda, 0x85, 0x8f,
+	0xe6, 0xc5, 0xee, 0x58, 0xc0, 0x7f, 0xf7, 0xbf, 0x1e, 0x00, 0xfe, 0x8c, 0x2d, 0x0f, 0x66, 0x1d,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IAVLServiceClient is the client API for IAVLService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IAVLServiceClient interface {
+	// Has returns a result containing a boolean on whether or not the IAVL tree
+	// has a given key at a specific tree version.
+	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	HasVersioned(ctx context.Context, in *HasVersionedRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	// Get returns a result containing the index and value for a given
+	// key based on the current state (version) of the tree.
+	// If the key does not exist, Get returns the index of the next value.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// GetByIndex returns a result containing the key and value for a given
+	// index based on the current state (version) of the tree.
+	GetByIndex(ctx context.Context, in *GetByIndexRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error)
+	// GetByIndexVersioned returns a result containing the key and value for a
+	// given index at a specific tree version.
+	GetByIndexVersioned(ctx context.Context, in *GetByIndexVersionedRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error)
+	// GetWithProof returns a result containing the IAVL tree version and value for
+	// a given key based on the current state (version) of the tree including a
+	// verifiable Merkle proof.
+	GetWithProof(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error)
+	// GetVersioned returns a result containing the IAVL tree version and value
+	// for a given key at a specific tree version.
+	GetVersioned(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// GetVersionedWithProof returns a result containing the IAVL tree version and
+	// value for a given key at a specific tree version including a verifiable Merkle
+	// proof.
+	GetVersionedWithProof(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error)
+	// GetWithValueHashProof returns a result containing the SHA256 hash of the
+	// value for a given key based on the current state (version) of the tree
+	// including a verifiable Merkle proof, without disclosing the value.
+	GetWithValueHashProof(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetWithValueHashProofResponse, error)
+	// GetVersionedWithValueHashProof returns a result containing the SHA256 hash
+	// of the value for a given key at a specific tree version including a
+	// verifiable Merkle proof, without disclosing the value.
+	GetVersionedWithValueHashProof(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetWithValueHashProofResponse, error)
+	// Set returns a result after inserting a key/value pair into the IAVL tree
+	// based on the current state (version) of the tree.
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
+	// Remove returns a result after removing a key/value pair from the IAVL tree
+	// based on the current state (version) of the tree.
+	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error)
+	// SaveVersion saves a new IAVL tree version to the DB based on the current
+	// state (version) of the tree. It returns a result containing the hash and
+	// new version number.
+	SaveVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SaveVersionResponse, error)
+	// DeleteVersion deletes an IAVL tree version from the DB. The version can then
+	// no longer be accessed. It returns a result containing the version and root
+	// hash of the versioned tree that was deleted.
+	DeleteVersion(ctx context.Context, in *DeleteVersionRequest, opts ...grpc.CallOption) (*DeleteVersionResponse, error)
+	// Version returns the IAVL tree version based on the current state.
+	Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionResponse, error)
+	// Hash returns the IAVL tree root hash based on the current state.
+	Hash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HashResponse, error)
+	// HashVersioned returns the IAVL tree root hash at a specific tree version.
+	HashVersioned(ctx context.Context, in *HashVersionedRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	// VersionExists returns a result containing a boolean on whether or not a given
+	// version exists in the IAVL tree.
+	VersionExists(ctx context.Context, in *VersionExistsRequest, opts ...grpc.CallOption) (*VersionExistsResponse, error)
+	// Verify verifies an IAVL range proof returning an error if the proof is
+	// invalid.
+	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyVersioned verifies an IAVL range proof against the root hash of a
+	// specific tree version, returning an error if the proof is invalid.
+	VerifyVersioned(ctx context.Context, in *VerifyVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyItem verifies if a given key/value pair in an IAVL range proof returning
+	// an error if the proof or key is invalid.
+	VerifyItem(ctx context.Context, in *VerifyItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyItemVersioned verifies if a given key/value pair in an IAVL range
+	// proof against the root hash of a specific tree version, returning an error
+	// if the proof or key is invalid.
+	VerifyItemVersioned(ctx context.Context, in *VerifyItemVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyItemValueHash verifies if a given key and value hash pair in an IAVL
+	// range proof returning an error if the proof or key is invalid.
+	VerifyItemValueHash(ctx context.Context, in *VerifyItemValueHashRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyItemValueHashVersioned verifies if a given key and value hash pair in
+	// an IAVL range proof against the root hash of a specific tree version,
+	// returning an error if the proof or key is invalid.
+	VerifyItemValueHashVersioned(ctx context.Context, in *VerifyItemValueHashVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyAbsence verifies the absence of a given key in an IAVL range proof
+	// returning an error if the proof or key is invalid.
+	VerifyAbsence(ctx context.Context, in *VerifyAbsenceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyAbsenceVersioned verifies the absence of a given key in an IAVL range
+	// proof against the root hash of a specific tree version, returning an error
+	// if the proof or key is invalid.
+	VerifyAbsenceVersioned(ctx context.Context, in *VerifyAbsenceVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Rollback resets the working tree to the latest saved version, discarding
+	// any unsaved modifications.
+	Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Returns the committed versions
+	GetAvailableVersions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAvailableVersionsResponse, error)
+	// Load the most recent version
+	Load(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Load a specific version
+	LoadVersion(ctx context.Context, in *LoadVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Load a specific version and delete all the more recent versions
+	LoadVersionForOverwriting(ctx context.Context, in *LoadVersionForOverwritingRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Get the number of leaves in the tree
+	Size(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SizeResponse, error)
+	// Get the number of leaves in the tree at a specific tree version
+	SizeVersioned(ctx context.Context, in *SizeVersionedRequest, opts ...grpc.CallOption) (*SizeResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (IAVLService_ListClient, error)
+	// ListVersioned lists the key/value pairs in a range at a specific tree
+	// version.
+	ListVersioned(ctx context.Context, in *ListVersionedRequest, opts ...grpc.CallOption) (IAVLService_ListVersionedClient, error)
+	// GetNode returns the encoded persisted node with the given hash, allowing
+	// clients to traverse the tree remotely.
+	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error)
+	// SampleWithProofs returns pseudo-random keys at a specific tree version,
+	// derived from a seed and the root hash, with proofs binding each key to its
+	// index.
+	SampleWithProofs(ctx context.Context, in *SampleWithProofsRequest, opts ...grpc.CallOption) (*SampleWithProofsResponse, error)
+}
+
+type iAVLServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIAVLServiceClient(cc *grpc.ClientConn) IAVLServiceClient {
+	return &iAVLServiceClient{cc}
+}
+
+func (c *iAVLServiceClient) Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	out := new(HasResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Has", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Hash(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Hash",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Hash(ctx, req.(*empty.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_VersionExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VersionExistsRequest)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) HasVersioned(ctx context.Context, in *HasVersionedRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	out := new(HasResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/HasVersioned", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).VersionExists(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/VersionExists",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).VersionExists(ctx, req.(*VersionExistsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyRequest)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Get", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Verify(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Verify",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Verify(ctx, req.(*VerifyRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_VerifyItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyItemRequest)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) GetByIndex(ctx context.Context, in *GetByIndexRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error) {
+	out := new(GetByIndexResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetByIndex", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).VerifyItem(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/VerifyItem",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).VerifyItem(ctx, req.(*VerifyItemRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_VerifyAbsence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyAbsenceRequest)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) GetByIndexVersioned(ctx context.Context, in *GetByIndexVersionedRequest, opts ...grpc.CallOption) (*GetByIndexResponse, error) {
+	out := new(GetByIndexResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetByIndexVersioned", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).VerifyAbsence(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/VerifyAbsence",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).VerifyAbsence(ctx, req.(*VerifyAbsenceRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) GetWithProof(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error) {
+	out := new(GetWithProofResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetWithProof", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Rollback(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Rollback",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Rollback(ctx, req.(*empty.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_GetAvailableVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) GetVersioned(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetVersioned", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).GetAvailableVersions(ctx, in)
+	return out, nil
+}
+
+func (c *iAVLServiceClient) GetVersionedWithProof(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetWithProofResponse, error) {
+	out := new(GetWithProofResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetVersionedWithProof", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/GetAvailableVersions",
+	return out, nil
+}
+
+func (c *iAVLServiceClient) GetWithValueHashProof(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetWithValueHashProofResponse, error) {
+	out := new(GetWithValueHashProofResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetWithValueHashProof", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).GetAvailableVersions(ctx, req.(*empty.Empty))
+	return out, nil
+}
+
+func (c *iAVLServiceClient) GetVersionedWithValueHashProof(ctx context.Context, in *GetVersionedRequest, opts ...grpc.CallOption) (*GetWithValueHashProofResponse, error) {
+	out := new(GetWithValueHashProofResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetVersionedWithValueHashProof", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_Load_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
+	out := new(SetResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Set", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Load(ctx, in)
+	return out, nil
+}
+
+func (c *iAVLServiceClient) Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error) {
+	out := new(RemoveResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Load",
+	return out, nil
+}
+
+func (c *iAVLServiceClient) SaveVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SaveVersionResponse, error) {
+	out := new(SaveVersionResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/SaveVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Load(ctx, req.(*empty.Empty))
+	return out, nil
+}
+
+func (c *iAVLServiceClient) DeleteVersion(ctx context.Context, in *DeleteVersionRequest, opts ...grpc.CallOption) (*DeleteVersionResponse, error) {
+	out := new(DeleteVersionResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/DeleteVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_LoadVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoadVersionRequest)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Version", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).LoadVersion(ctx, in)
+	return out, nil
+}
+
+func (c *iAVLServiceClient) Hash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HashResponse, error) {
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Hash", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/LoadVersion",
+	return out, nil
+}
+
+func (c *iAVLServiceClient) HashVersioned(ctx context.Context, in *HashVersionedRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/HashVersioned", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).LoadVersion(ctx, req.(*LoadVersionRequest))
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VersionExists(ctx context.Context, in *VersionExistsRequest, opts ...grpc.CallOption) (*VersionExistsResponse, error) {
+	out := new(VersionExistsResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VersionExists", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_LoadVersionForOverwriting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoadVersionForOverwritingRequest)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Verify", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).LoadVersionForOverwriting(ctx, in)
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VerifyVersioned(ctx context.Context, in *VerifyVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyVersioned", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/LoadVersionForOverwriting",
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VerifyItem(ctx context.Context, in *VerifyItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyItem", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).LoadVersionForOverwriting(ctx, req.(*LoadVersionForOverwritingRequest))
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VerifyItemVersioned(ctx context.Context, in *VerifyItemVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyItemVersioned", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_Size_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
+func (c *iAVLServiceClient) VerifyItemValueHash(ctx context.Context, in *VerifyItemValueHashRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyItemValueHash", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(IAVLServiceServer).Size(ctx, in)
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VerifyItemValueHashVersioned(ctx context.Context, in *VerifyItemValueHashVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyItemValueHashVersioned", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/iavl.IAVLService/Size",
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VerifyAbsence(ctx context.Context, in *VerifyAbsenceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyAbsence", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IAVLServiceServer).Size(ctx, req.(*empty.Empty))
+	return out, nil
+}
+
+func (c *iAVLServiceClient) VerifyAbsenceVersioned(ctx context.Context, in *VerifyAbsenceVersionedRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/VerifyAbsenceVersioned", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _IAVLService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (c *iAVLServiceClient) Rollback(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return srv.(IAVLServiceServer).List(m, &iAVLServiceListServer{stream})
+	return out, nil
 }
 
-type IAVLService_ListServer interface {
-	Send(*ListResponse) error
-	grpc.ServerStream
+func (c *iAVLServiceClient) GetAvailableVersions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAvailableVersionsResponse, error) {
+	out := new(GetAvailableVersionsResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetAvailableVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type iAVLServiceListServer struct {
-	grpc.ServerStream
+func (c *iAVLServiceClient) Load(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Load", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *iAVLServiceListServer) Send(m *ListResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (c *iAVLServiceClient) LoadVersion(ctx context.Context, in *LoadVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/LoadVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-var _IAVLService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "iavl.IAVLService",
-	HandlerType: (*IAVLServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Has",
-			Handler:    _IAVLService_Has_Handler,
-		},
-		{
-			MethodName: "HasVersioned",
-			Handler:    _IAVLService_HasVersioned_Handler,
-		},
-		{
-			MethodName: "Get",
-			Handler:    _IAVLService_Get_Handler,
-		},
-		{
-			MethodName: "GetByIndex",
-			Handler:    _IAVLService_GetByIndex_Handler,
-		},
-		{
-			MethodName: "GetWithProof",
-			Handler:    _IAVLService_GetWithProof_Handler,
-		},
-		{
-			MethodName: "GetVersioned",
-			Handler:    _IAVLService_GetVersioned_Handler,
-		},
-		{
-			MethodName: "GetVersionedWithProof",
-			Handler:    _IAVLService_GetVersionedWithProof_Handler,
-		},
-		{
-			MethodName: "Set",
-			Handler:    _IAVLService_Set_Handler,
-		},
-		{
-			MethodName: "Remove",
-			Handler:    _IAVLService_Remove_Handler,
-		},
-		{
-			MethodName: "SaveVersion",
-			Handler:    _IAVLService_SaveVersion_Handler,
-		},
-		{
-			MethodName: "DeleteVersion",
-			Handler:    _IAVLService_DeleteVersion_Handler,
-		},
-		{
-			MethodName: "Version",
-			Handler:    _IAVLService_Version_Handler,
-		},
-		{
-			MethodName: "Hash",
-			Handler:    _IAVLService_Hash_Handler,
-		},
-		{
-			MethodName: "VersionExists",
-			Handler:    _IAVLService_VersionExists_Handler,
-		},
-		{
-			MethodName: "Verify",
-			Handler:    _IAVLService_Verify_Handler,
-		},
-		{
-			MethodName: "VerifyItem",
-			Handler:    _IAVLService_VerifyItem_Handler,
-		},
-		{
-			MethodName: "VerifyAbsence",
-			Handler:    _IAVLService_VerifyAbsence_Handler,
-		},
-		{
-			MethodName: "Rollback",
-			Handler:    _IAVLService_Rollback_Handler,
-		},
-		{
-			MethodName: "GetAvailableVersions",
-			Handler:    _IAVLService_GetAvailableVersions_Handler,
-		},
-		{
-			MethodName: "Load",
-			Handler:    _IAVLService_Load_Handler,
-		},
-		{
-			MethodName: "LoadVersion",
-			Handler:    _IAVLService_LoadVersion_Handler,
-		},
-		{
-			MethodName: "LoadVersionForOverwriting",
-			Handler:    _IAVLService_LoadVersionForOverwriting_Handler,
-		},
-		{
-			MethodName: "Size",
-			Handler:    _IAVLService_Size_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "List",
-			Handler:       _IAVLService_List_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "iavl/iavl_api.proto",
-}
-
-func (m *HasRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *iAVLServiceClient) LoadVersionForOverwriting(ctx context.Context, in *LoadVersionForOverwritingRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/LoadVersionForOverwriting", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HasRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return out, nil
 }
 
-func (m *HasRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+func (c *iAVLServiceClient) Size(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SizeResponse, error) {
+	out := new(SizeResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/Size", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *HasVersionedRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *iAVLServiceClient) SizeVersioned(ctx context.Context, in *SizeVersionedRequest, opts ...grpc.CallOption) (*SizeResponse, error) {
+	out := new(SizeResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/SizeVersioned", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HasVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return out, nil
 }
 
-func (m *HasVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x12
+func (c *iAVLServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (IAVLService_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_IAVLService_serviceDesc.Streams[0], "/iavl.IAVLService/List", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
+	x := &iAVLServiceListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GetRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return x, nil
 }
 
-func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type IAVLService_ListClient interface {
+	Recv() (*ListResponse, error)
+	grpc.ClientStream
 }
 
-func (m *GetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+type iAVLServiceListClient struct {
+	grpc.ClientStream
+}
+
+func (x *iAVLServiceListClient) Recv() (*ListResponse, error) {
+	m := new(ListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *GetByIndexRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *iAVLServiceClient) ListVersioned(ctx context.Context, in *ListVersionedRequest, opts ...grpc.CallOption) (IAVLService_ListVersionedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_IAVLService_serviceDesc.Streams[1], "/iavl.IAVLService/ListVersioned", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &iAVLServiceListVersionedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *GetByIndexRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type IAVLService_ListVersionedClient interface {
+	Recv() (*ListResponse, error)
+	grpc.ClientStream
 }
 
-func (m *GetByIndexRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x8
+type iAVLServiceListVersionedClient struct {
+	grpc.ClientStream
+}
+
+func (x *iAVLServiceListVersionedClient) Recv() (*ListResponse, error) {
+	m := new(ListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *GetVersionedRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *iAVLServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*GetNodeResponse, error) {
+	out := new(GetNodeResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/GetNode", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *iAVLServiceClient) SampleWithProofs(ctx context.Context, in *SampleWithProofsRequest, opts ...grpc.CallOption) (*SampleWithProofsResponse, error) {
+	out := new(SampleWithProofsResponse)
+	err := c.cc.Invoke(ctx, "/iavl.IAVLService/SampleWithProofs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *GetVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+// IAVLServiceServer is the server API for IAVLService service.
+type IAVLServiceServer interface {
+	// Has returns a result containing a boolean on whether or not the IAVL tree
+	// has a given key at a specific tree version.
+	Has(context.Context, *HasRequest) (*HasResponse, error)
+	HasVersioned(context.Context, *HasVersionedRequest) (*HasResponse, error)
+	// Get returns a result containing the index and value for a given
+	// key based on the current state (version) of the tree.
+	// If the key does not exist, Get returns the index of the next value.
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// GetByIndex returns a result containing the key and value for a given
+	// index based on the current state (version) of the tree.
+	GetByIndex(context.Context, *GetByIndexRequest) (*GetByIndexResponse, error)
+	// GetByIndexVersioned returns a result containing the key and value for a
+	// given index at a specific tree version.
+	GetByIndexVersioned(context.Context, *GetByIndexVersionedRequest) (*GetByIndexResponse, error)
+	// GetWithProof returns a result containing the IAVL tree version and value for
+	// a given key based on the current state (version) of the tree including a
+	// verifiable Merkle proof.
+	GetWithProof(context.Context, *GetRequest) (*GetWithProofResponse, error)
+	// GetVersioned returns a result containing the IAVL tree version and value
+	// for a given key at a specific tree version.
+	GetVersioned(context.Context, *GetVersionedRequest) (*GetResponse, error)
+	// GetVersionedWithProof returns a result containing the IAVL tree version and
+	// value for a given key at a specific tree version including a verifiable Merkle
+	// proof.
+	GetVersionedWithProof(context.Context, *GetVersionedRequest) (*GetWithProofResponse, error)
+	// GetWithValueHashProof returns a result containing the SHA256 hash of the
+	// value for a given key based on the current state (version) of the tree
+	// including a verifiable Merkle proof, without disclosing the value.
+	GetWithValueHashProof(context.Context, *GetRequest) (*GetWithValueHashProofResponse, error)
+	// GetVersionedWithValueHashProof returns a result containing the SHA256 hash
+	// of the value for a given key at a specific tree version including a
+	// verifiable Merkle proof, without disclosing the value.
+	GetVersionedWithValueHashProof(context.Context, *GetVersionedRequest) (*GetWithValueHashProofResponse, error)
+	// Set returns a result after inserting a key/value pair into the IAVL tree
+	// based on the current state (version) of the tree.
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	// Remove returns a result after removing a key/value pair from the IAVL tree
+	// based on the current state (version) of the tree.
+	Remove(context.Context, *RemoveRequest) (*RemoveResponse, error)
+	// SaveVersion saves a new IAVL tree version to the DB based on the current
+	// state (version) of the tree. It returns a result containing the hash and
+	// new version number.
+	SaveVersion(context.Context, *empty.Empty) (*SaveVersionResponse, error)
+	// DeleteVersion deletes an IAVL tree version from the DB. The version can then
+	// no longer be accessed. It returns a result containing the version and root
+	// hash of the versioned tree that was deleted.
+	DeleteVersion(context.Context, *DeleteVersionRequest) (*DeleteVersionResponse, error)
+	// Version returns the IAVL tree version based on the current state.
+	Version(context.Context, *empty.Empty) (*VersionResponse, error)
+	// Hash returns the IAVL tree root hash based on the current state.
+	Hash(context.Context, *empty.Empty) (*HashResponse, error)
+	// HashVersioned returns the IAVL tree root hash at a specific tree version.
+	HashVersioned(context.Context, *HashVersionedRequest) (*HashResponse, error)
+	// VersionExists returns a result containing a boolean on whether or not a given
+	// version exists in the IAVL tree.
+	VersionExists(context.Context, *VersionExistsRequest) (*VersionExistsResponse, error)
+	// Verify verifies an IAVL range proof returning an error if the proof is
+	// invalid.
+	Verify(context.Context, *VerifyRequest) (*empty.Empty, error)
+	// VerifyVersioned verifies an IAVL range proof against the root hash of a
+	// specific tree version, returning an error if the proof is invalid.
+	VerifyVersioned(context.Context, *VerifyVersionedRequest) (*empty.Empty, error)
+	// VerifyItem verifies if a given key/value pair in an IAVL range proof returning
+	// an error if the proof or key is invalid.
+	VerifyItem(context.Context, *VerifyItemRequest) (*empty.Empty, error)
+	// VerifyItemVersioned verifies if a given key/value pair in an IAVL range
+	// proof against the root hash of a specific tree version, returning an error
+	// if the proof or key is invalid.
+	VerifyItemVersioned(context.Context, *VerifyItemVersionedRequest) (*empty.Empty, error)
+	// VerifyItemValueHash verifies if a given key and value hash pair in an IAVL
+	// range proof returning an error if the proof or key is invalid.
+	VerifyItemValueHash(context.Context, *VerifyItemValueHashRequest) (*empty.Empty, error)
+	// VerifyItemValueHashVersioned verifies if a given key and value hash pair in
+	// an IAVL range proof against the root hash of a specific tree version,
+	// returning an error if the proof or key is invalid.
+	VerifyItemValueHashVersioned(context.Context, *VerifyItemValueHashVersionedRequest) (*empty.Empty, error)
+	// VerifyAbsence verifies the absence of a given key in an IAVL range proof
+	// returning an error if the proof or key is invalid.
+	VerifyAbsence(context.Context, *VerifyAbsenceRequest) (*empty.Empty, error)
+	// VerifyAbsenceVersioned verifies the absence of a given key in an IAVL range
+	// proof against the root hash of a specific tree version, returning an error
+	// if the proof or key is invalid.
+	VerifyAbsenceVersioned(context.Context, *VerifyAbsenceVersionedRequest) (*empty.Empty, error)
+	// Rollback resets the working tree to the latest saved version, discarding
+	// any unsaved modifications.
+	Rollback(context.Context, *empty.Empty) (*empty.Empty, error)
+	// Returns the committed versions
+	GetAvailableVersions(context.Context, *empty.Empty) (*GetAvailableVersionsResponse, error)
+	// Load the most recent version
+	Load(context.Context, *empty.Empty) (*empty.Empty, error)
+	// Load a specific version
+	LoadVersion(context.Context, *LoadVersionRequest) (*empty.Empty, error)
+	// Load a specific version and delete all the more recent versions
+	LoadVersionForOverwriting(context.Context, *LoadVersionForOverwritingRequest) (*empty.Empty, error)
+	// Get the number of leaves in the tree
+	Size(context.Context, *empty.Empty) (*SizeResponse, error)
+	// Get the number of leaves in the tree at a specific tree version
+	SizeVersioned(context.Context, *SizeVersionedRequest) (*SizeResponse, error)
+	List(*ListRequest, IAVLService_ListServer) error
+	// ListVersioned lists the key/value pairs in a range at a specific tree
+	// version.
+	ListVersioned(*ListVersionedRequest, IAVLService_ListVersionedServer) error
+	// GetNode returns the encoded persisted node with the given hash, allowing
+	// clients to traverse the tree remotely.
+	GetNode(context.Context, *GetNodeRequest) (*GetNodeResponse, error)
+	// SampleWithProofs returns pseudo-random keys at a specific tree version,
+	// derived from a seed and the root hash, with proofs binding each key to its
+	// index.
+	SampleWithProofs(context.Context, *SampleWithProofsRequest) (*SampleWithProofsResponse, error)
 }
 
-func (m *SetRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// UnimplementedIAVLServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedIAVLServiceServer struct {
 }
 
-func (m *SetRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIAVLServiceServer) Has(ctx context.Context, req *HasRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Has not implemented")
 }
-
-func (m *SetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (*UnimplementedIAVLServiceServer) HasVersioned(ctx context.Context, req *HasVersionedRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasVersioned not implemented")
 }
-
-func (m *RemoveRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIAVLServiceServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-
-func (m *RemoveRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIAVLServiceServer) GetByIndex(ctx context.Context, req *GetByIndexRequest) (*GetByIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByIndex not implemented")
 }
-
-func (m *RemoveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (*UnimplementedIAVLServiceServer) GetByIndexVersioned(ctx context.Context, req *GetByIndexVersionedRequest) (*GetByIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByIndexVersioned not implemented")
 }
-
-func (m *DeleteVersionRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIAVLServiceServer) GetWithProof(ctx context.Context, req *GetRequest) (*GetWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithProof not implemented")
 }
-
-func (m *DeleteVersionRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIAVLServiceServer) GetVersioned(ctx context.Context, req *GetVersionedRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersioned not implemented")
 }
-
-func (m *DeleteVersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+func (*UnimplementedIAVLServiceServer) GetVersionedWithProof(ctx context.Context, req *GetVersionedRequest) (*GetWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersionedWithProof not implemented")
 }
-
-func (m *VersionExistsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIAVLServiceServer) GetWithValueHashProof(ctx context.Context, req *GetRequest) (*GetWithValueHashProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithValueHashProof not implemented")
 }
-
-func (m *VersionExistsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIAVLServiceServer) GetVersionedWithValueHashProof(ctx context.Context, req *GetVersionedRequest) (*GetWithValueHashProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersionedWithValueHashProof not implemented")
 }
-
-func (m *VersionExistsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+func (*UnimplementedIAVLServiceServer) Set(ctx context.Context, req *SetRequest) (*SetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
 }
-
-func (m *VerifyRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIAVLServiceServer) Remove(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
 }
-
-func (m *VerifyRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIAVLServiceServer) SaveVersion(ctx context.Context, req *empty.Empty) (*SaveVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveVersion not implemented")
 }
-
-func (m *VerifyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Proof != nil {
-		{
-			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIavlApi(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.RootHash) > 0 {
-		i -= len(m.RootHash)
-		copy(dAtA[i:], m.RootHash)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (*UnimplementedIAVLServiceServer) DeleteVersion(ctx context.Context, req *DeleteVersionRequest) (*DeleteVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVersion not implemented")
 }
-
-func (m *VerifyItemRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIAVLServiceServer) Version(ctx context.Context, req *empty.Empty) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (*UnimplementedIAVLServiceServer) Hash(ctx context.Context, req *empty.Empty) (*HashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hash not implemented")
+}
+func (*UnimplementedIAVLServiceServer) HashVersioned(ctx context.Context, req *HashVersionedRequest) (*HashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HashVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VersionExists(ctx context.Context, req *VersionExistsRequest) (*VersionExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VersionExists not implemented")
+}
+func (*UnimplementedIAVLServiceServer) Verify(ctx context.Context, req *VerifyRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyVersioned(ctx context.Context, req *VerifyVersionedRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyItem(ctx context.Context, req *VerifyItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyItem not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyItemVersioned(ctx context.Context, req *VerifyItemVersionedRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyItemVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyItemValueHash(ctx context.Context, req *VerifyItemValueHashRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyItemValueHash not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyItemValueHashVersioned(ctx context.Context, req *VerifyItemValueHashVersionedRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyItemValueHashVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyAbsence(ctx context.Context, req *VerifyAbsenceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAbsence not implemented")
+}
+func (*UnimplementedIAVLServiceServer) VerifyAbsenceVersioned(ctx context.Context, req *VerifyAbsenceVersionedRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAbsenceVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) Rollback(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+}
+func (*UnimplementedIAVLServiceServer) GetAvailableVersions(ctx context.Context, req *empty.Empty) (*GetAvailableVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableVersions not implemented")
+}
+func (*UnimplementedIAVLServiceServer) Load(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Load not implemented")
+}
+func (*UnimplementedIAVLServiceServer) LoadVersion(ctx context.Context, req *LoadVersionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadVersion not implemented")
+}
+func (*UnimplementedIAVLServiceServer) LoadVersionForOverwriting(ctx context.Context, req *LoadVersionForOverwritingRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadVersionForOverwriting not implemented")
+}
+func (*UnimplementedIAVLServiceServer) Size(ctx context.Context, req *empty.Empty) (*SizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Size not implemented")
+}
+func (*UnimplementedIAVLServiceServer) SizeVersioned(ctx context.Context, req *SizeVersionedRequest) (*SizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SizeVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) List(req *ListRequest, srv IAVLService_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedIAVLServiceServer) ListVersioned(req *ListVersionedRequest, srv IAVLService_ListVersionedServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListVersioned not implemented")
+}
+func (*UnimplementedIAVLServiceServer) GetNode(ctx context.Context, req *GetNodeRequest) (*GetNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNode not implemented")
+}
+func (*UnimplementedIAVLServiceServer) SampleWithProofs(ctx context.Context, req *SampleWithProofsRequest) (*SampleWithProofsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SampleWithProofs not implemented")
 }
 
-func (m *VerifyItemRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func RegisterIAVLServiceServer(s *grpc.Server, srv IAVLServiceServer) {
+	s.RegisterService(&_IAVLService_serviceDesc, srv)
 }
 
-func (m *VerifyItemRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x22
+func _IAVLService_Has_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x1a
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Has(ctx, in)
 	}
-	if m.Proof != nil {
-		{
-			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIavlApi(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Has",
 	}
-	if len(m.RootHash) > 0 {
-		i -= len(m.RootHash)
-		copy(dAtA[i:], m.RootHash)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
-		i--
-		dAtA[i] = 0xa
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Has(ctx, req.(*HasRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VerifyAbsenceRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_HasVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasVersionedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *VerifyAbsenceRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).HasVersioned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/HasVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).HasVersioned(ctx, req.(*HasVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VerifyAbsenceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x1a
+func _IAVLService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Proof != nil {
-		{
-			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIavlApi(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Get(ctx, in)
 	}
-	if len(m.RootHash) > 0 {
-		i -= len(m.RootHash)
-		copy(dAtA[i:], m.RootHash)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
-		i--
-		dAtA[i] = 0xa
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Get",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LoadVersionRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_GetByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIndexRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetByIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetByIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetByIndex(ctx, req.(*GetByIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LoadVersionRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _IAVLService_GetByIndexVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIndexVersionedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetByIndexVersioned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetByIndexVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetByIndexVersioned(ctx, req.(*GetByIndexVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LoadVersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
+func _IAVLService_GetWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetWithProof(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LoadVersionForOverwritingRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_GetVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetVersioned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetVersioned(ctx, req.(*GetVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LoadVersionForOverwritingRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LoadVersionForOverwritingRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
+func _IAVLService_GetVersionedWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetVersionedWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetVersionedWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetVersionedWithProof(ctx, req.(*GetVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ListRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_GetWithValueHashProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Descending {
-		i--
-		if m.Descending {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetWithValueHashProof(ctx, in)
 	}
-	if len(m.ToKey) > 0 {
-		i -= len(m.ToKey)
-		copy(dAtA[i:], m.ToKey)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.ToKey)))
-		i--
-		dAtA[i] = 0x12
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetWithValueHashProof",
 	}
-	if len(m.FromKey) > 0 {
-		i -= len(m.FromKey)
-		copy(dAtA[i:], m.FromKey)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.FromKey)))
-		i--
-		dAtA[i] = 0xa
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetWithValueHashProof(ctx, req.(*GetRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HasResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_GetVersionedWithValueHashProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HasResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HasResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Result {
-		i--
-		if m.Result {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetVersionedWithValueHashProof(ctx, in)
 	}
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetVersionedWithValueHashProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetVersionedWithValueHashProof(ctx, req.(*GetVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GetResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.NotFound {
-		i--
-		if m.NotFound {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Set(ctx, in)
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Set",
 	}
-	if m.Index != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x8
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Set(ctx, req.(*SetRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetByIndexResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Remove(ctx, req.(*RemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetByIndexResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _IAVLService_SaveVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).SaveVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/SaveVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).SaveVersion(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetByIndexResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
+func _IAVLService_DeleteVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).DeleteVersion(ctx, in)
 	}
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/DeleteVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).DeleteVersion(ctx, req.(*DeleteVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *SetResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Version(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *SetResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *SetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Updated {
-		i--
-		if m.Updated {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
+func _IAVLService_Hash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Hash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Hash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Hash(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RemoveResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_HashVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashVersionedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RemoveResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *RemoveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Removed {
-		i--
-		if m.Removed {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x10
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).HashVersioned(ctx, in)
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0xa
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/HashVersioned",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).HashVersioned(ctx, req.(*HashVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *SaveVersionResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_VersionExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionExistsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *SaveVersionResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VersionExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VersionExists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VersionExists(ctx, req.(*VersionExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *SaveVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x10
+func _IAVLService_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.RootHash) > 0 {
-		i -= len(m.RootHash)
-		copy(dAtA[i:], m.RootHash)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
-		i--
-		dAtA[i] = 0xa
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Verify(ctx, in)
 	}
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Verify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Verify(ctx, req.(*VerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *DeleteVersionResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_VerifyVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyVersionedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyVersioned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyVersioned(ctx, req.(*VerifyVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *DeleteVersionResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _IAVLService_VerifyItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyItem(ctx, req.(*VerifyItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *DeleteVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x10
+func _IAVLService_VerifyItemVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyItemVersionedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.RootHash) > 0 {
-		i -= len(m.RootHash)
-		copy(dAtA[i:], m.RootHash)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
-		i--
-		dAtA[i] = 0xa
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyItemVersioned(ctx, in)
 	}
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyItemVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyItemVersioned(ctx, req.(*VerifyItemVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VersionResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_VerifyItemValueHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyItemValueHashRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyItemValueHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyItemValueHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyItemValueHash(ctx, req.(*VerifyItemValueHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VersionResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _IAVLService_VerifyItemValueHashVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyItemValueHashVersionedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyItemValueHashVersioned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyItemValueHashVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyItemValueHashVersioned(ctx, req.(*VerifyItemValueHashVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Version != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
-		i--
-		dAtA[i] = 0x8
+func _IAVLService_VerifyAbsence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAbsenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyAbsence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyAbsence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyAbsence(ctx, req.(*VerifyAbsenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HashResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_VerifyAbsenceVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAbsenceVersionedRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).VerifyAbsenceVersioned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/VerifyAbsenceVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).VerifyAbsenceVersioned(ctx, req.(*VerifyAbsenceVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *HashResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HashResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.RootHash) > 0 {
-		i -= len(m.RootHash)
-		copy(dAtA[i:], m.RootHash)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
-		i--
-		dAtA[i] = 0xa
+func _IAVLService_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Rollback(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VersionExistsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_GetAvailableVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetAvailableVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetAvailableVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetAvailableVersions(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VersionExistsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _IAVLService_Load_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Load(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Load",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Load(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *VersionExistsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Result {
-		i--
-		if m.Result {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
+func _IAVLService_LoadVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).LoadVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/LoadVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).LoadVersion(ctx, req.(*LoadVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetWithProofResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _IAVLService_LoadVersionForOverwriting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadVersionForOverwritingRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).LoadVersionForOverwriting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/LoadVersionForOverwriting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).LoadVersionForOverwriting(ctx, req.(*LoadVersionForOverwritingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetWithProofResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _IAVLService_Size_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).Size(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/Size",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).Size(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetWithProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Proof != nil {
-		{
-			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIavlApi(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func _IAVLService_SizeVersioned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SizeVersionedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0xa
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).SizeVersioned(ctx, in)
 	}
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/SizeVersioned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).SizeVersioned(ctx, req.(*SizeVersionedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetAvailableVersionsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func _IAVLService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(IAVLServiceServer).List(m, &iAVLServiceListServer{stream})
 }
 
-func (m *GetAvailableVersionsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type IAVLService_ListServer interface {
+	Send(*ListResponse) error
+	grpc.ServerStream
 }
 
-func (m *GetAvailableVersionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Versions) > 0 {
-		dAtA6 := make([]byte, len(m.Versions)*10)
-		var j5 int
-		for _, num1 := range m.Versions {
-			num := uint64(num1)
-			for num >= 1<<7 {
-				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j5++
-			}
-			dAtA6[j5] = uint8(num)
-			j5++
-		}
-		i -= j5
-		copy(dAtA[i:], dAtA6[:j5])
-		i = encodeVarintIavlApi(dAtA, i, uint64(j5))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+type iAVLServiceListServer struct {
+	grpc.ServerStream
 }
 
-func (m *SizeResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (x *iAVLServiceListServer) Send(m *ListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IAVLService_ListVersioned_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVersionedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(IAVLServiceServer).ListVersioned(m, &iAVLServiceListVersionedServer{stream})
 }
 
-func (m *SizeResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type IAVLService_ListVersionedServer interface {
+	Send(*ListResponse) error
+	grpc.ServerStream
 }
 
-func (m *SizeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Size_ != 0 {
-		i = encodeVarintIavlApi(dAtA, i, uint64(m.Size_))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+type iAVLServiceListVersionedServer struct {
+	grpc.ServerStream
 }
 
-func (m *ListResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *iAVLServiceListVersionedServer) Send(m *ListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IAVLService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).GetNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/GetNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
+func _IAVLService_SampleWithProofs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SampleWithProofsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IAVLServiceServer).SampleWithProofs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iavl.IAVLService/SampleWithProofs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IAVLServiceServer).SampleWithProofs(ctx, req.(*SampleWithProofsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _IAVLService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "iavl.IAVLService",
+	HandlerType: (*IAVLServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Has",
+			Handler:    _IAVLService_Has_Handler,
+		},
+		{
+			MethodName: "HasVersioned",
+			Handler:    _IAVLService_HasVersioned_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _IAVLService_Get_Handler,
+		},
+		{
+			MethodName: "GetByIndex",
+			Handler:    _IAVLService_GetByIndex_Handler,
+		},
+		{
+			MethodName: "GetByIndexVersioned",
+			Handler:    _IAVLService_GetByIndexVersioned_Handler,
+		},
+		{
+			MethodName: "GetWithProof",
+			Handler:    _IAVLService_GetWithProof_Handler,
+		},
+		{
+			MethodName: "GetVersioned",
+			Handler:    _IAVLService_GetVersioned_Handler,
+		},
+		{
+			MethodName: "GetVersionedWithProof",
+			Handler:    _IAVLService_GetVersionedWithProof_Handler,
+		},
+		{
+			MethodName: "GetWithValueHashProof",
+			Handler:    _IAVLService_GetWithValueHashProof_Handler,
+		},
+		{
+			MethodName: "GetVersionedWithValueHashProof",
+			Handler:    _IAVLService_GetVersionedWithValueHashProof_Handler,
+		},
+		{
+			MethodName: "Set",
+			Handler:    _IAVLService_Set_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _IAVLService_Remove_Handler,
+		},
+		{
+			MethodName: "SaveVersion",
+			Handler:    _IAVLService_SaveVersion_Handler,
+		},
+		{
+			MethodName: "DeleteVersion",
+			Handler:    _IAVLService_DeleteVersion_Handler,
+		},
+		{
+			MethodName: "Version",
+			Handler:    _IAVLService_Version_Handler,
+		},
+		{
+			MethodName: "Hash",
+			Handler:    _IAVLService_Hash_Handler,
+		},
+		{
+			MethodName: "HashVersioned",
+			Handler:    _IAVLService_HashVersioned_Handler,
+		},
+		{
+			MethodName: "VersionExists",
+			Handler:    _IAVLService_VersionExists_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _IAVLService_Verify_Handler,
+		},
+		{
+			MethodName: "VerifyVersioned",
+			Handler:    _IAVLService_VerifyVersioned_Handler,
+		},
+		{
+			MethodName: "VerifyItem",
+			Handler:    _IAVLService_VerifyItem_Handler,
+		},
+		{
+			MethodName: "VerifyItemVersioned",
+			Handler:    _IAVLService_VerifyItemVersioned_Handler,
+		},
+		{
+			MethodName: "VerifyItemValueHash",
+			Handler:    _IAVLService_VerifyItemValueHash_Handler,
+		},
+		{
+			MethodName: "VerifyItemValueHashVersioned",
+			Handler:    _IAVLService_VerifyItemValueHashVersioned_Handler,
+		},
+		{
+			MethodName: "VerifyAbsence",
+			Handler:    _IAVLService_VerifyAbsence_Handler,
+		},
+		{
+			MethodName: "VerifyAbsenceVersioned",
+			Handler:    _IAVLService_VerifyAbsenceVersioned_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _IAVLService_Rollback_Handler,
+		},
+		{
+			MethodName: "GetAvailableVersions",
+			Handler:    _IAVLService_GetAvailableVersions_Handler,
+		},
+		{
+			MethodName: "Load",
+			Handler:    _IAVLService_Load_Handler,
+		},
+		{
+			MethodName: "LoadVersion",
+			Handler:    _IAVLService_LoadVersion_Handler,
+		},
+		{
+			MethodName: "LoadVersionForOverwriting",
+			Handler:    _IAVLService_LoadVersionForOverwriting_Handler,
+		},
+		{
+			MethodName: "Size",
+			Handler:    _IAVLService_Size_Handler,
+		},
+		{
+			MethodName: "SizeVersioned",
+			Handler:    _IAVLService_SizeVersioned_Handler,
+		},
+		{
+			MethodName: "GetNode",
+			Handler:    _IAVLService_GetNode_Handler,
+		},
+		{
+			MethodName: "SampleWithProofs",
+			Handler:    _IAVLService_SampleWithProofs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _IAVLService_List_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListVersioned",
+			Handler:       _IAVLService_ListVersioned_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "iavl/iavl_api.proto",
+}
+
+func (m *HasRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HasRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HasRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
-	}
 	if len(m.Key) > 0 {
 		i -= len(m.Key)
 		copy(dAtA[i:], m.Key)
@@ -3624,462 +4207,4167 @@ func (m *ListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintIavlApi(dAtA []byte, offset int, v uint64) int {
-	offset -= sovIavlApi(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *HasVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *HasRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	return n
+
+func (m *HasVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HasVersionedRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *HasVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetByIndexRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetByIndexRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetByIndexRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetByIndexRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.Index != 0 {
-		n += 1 + sovIavlApi(uint64(m.Index))
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetVersionedRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
-	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+func (m *GetByIndexVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *SetRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetByIndexVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetByIndexVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if m.Index != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RemoveRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+func (m *GetVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DeleteVersionRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
-	}
-	return n
+func (m *GetVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *VersionExistsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *VerifyRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.Proof != nil {
-		l = m.Proof.Size()
-		n += 1 + l + sovIavlApi(uint64(l))
+func (m *SetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *VerifyItemRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.Proof != nil {
-		l = m.Proof.Size()
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	return n
+func (m *SetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *VerifyAbsenceRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.Proof != nil {
-		l = m.Proof.Size()
-		n += 1 + l + sovIavlApi(uint64(l))
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LoadVersionRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RemoveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LoadVersionForOverwritingRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteVersionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteVersionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteVersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ListRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.FromKey)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	l = len(m.ToKey)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.Descending {
-		n += 2
+func (m *VersionExistsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *HasResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Result {
-		n += 2
-	}
-	return n
+func (m *VersionExistsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *VersionExistsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Index != 0 {
-		n += 1 + sovIavlApi(uint64(m.Index))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.NotFound {
-		n += 2
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetByIndexResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+func (m *VerifyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *SetResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Updated {
-		n += 2
-	}
-	return n
+func (m *VerifyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RemoveResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *VerifyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Removed {
-		n += 2
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *SaveVersionResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
+func (m *VerifyVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DeleteVersionResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
-	}
-	return n
+func (m *VerifyVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *VersionResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *VerifyVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.Version != 0 {
-		n += 1 + sovIavlApi(uint64(m.Version))
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HashResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.RootHash)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+func (m *VerifyItemRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *VersionExistsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Result {
-		n += 2
-	}
-	return n
+func (m *VerifyItemRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetWithProofResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *VerifyItemRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x1a
 	}
 	if m.Proof != nil {
-		l = m.Proof.Size()
-		n += 1 + l + sovIavlApi(uint64(l))
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *GetAvailableVersionsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *VerifyItemVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyItemVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyItemVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Versions) > 0 {
-		l = 0
-		for _, e := range m.Versions {
-			l += sovIavlApi(uint64(e))
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
 		}
-		n += 1 + sovIavlApi(uint64(l)) + l
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *SizeResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *VerifyItemValueHashRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyItemValueHashRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyItemValueHashRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyItemValueHashVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyItemValueHashVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyItemValueHashVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Size_ != 0 {
-		n += 1 + sovIavlApi(uint64(m.Size_))
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyAbsenceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyAbsenceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyAbsenceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyAbsenceVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyAbsenceVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyAbsenceVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LoadVersionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoadVersionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoadVersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LoadVersionForOverwritingRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoadVersionForOverwritingRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoadVersionForOverwritingRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IncludeProof {
+		i--
+		if m.IncludeProof {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.IncludeVersions {
+		i--
+		if m.IncludeVersions {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Descending {
+		i--
+		if m.Descending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ToKey) > 0 {
+		i -= len(m.ToKey)
+		copy(dAtA[i:], m.ToKey)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.ToKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.FromKey) > 0 {
+		i -= len(m.FromKey)
+		copy(dAtA[i:], m.FromKey)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.FromKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IncludeProof {
+		i--
+		if m.IncludeProof {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.IncludeVersions {
+		i--
+		if m.IncludeVersions {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Descending {
+		i--
+		if m.Descending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ToKey) > 0 {
+		i -= len(m.ToKey)
+		copy(dAtA[i:], m.ToKey)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.ToKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.FromKey) > 0 {
+		i -= len(m.FromKey)
+		copy(dAtA[i:], m.FromKey)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.FromKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HashVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HashVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HashVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SizeVersionedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SizeVersionedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SizeVersionedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SampleWithProofsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SampleWithProofsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SampleWithProofsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.N != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.N))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Seed) > 0 {
+		i -= len(m.Seed)
+		copy(dAtA[i:], m.Seed)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Seed)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HasResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HasResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HasResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Result {
+		i--
+		if m.Result {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NotFound {
+		i--
+		if m.NotFound {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetByIndexResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetByIndexResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetByIndexResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Updated {
+		i--
+		if m.Updated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Removed {
+		i--
+		if m.Removed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SaveVersionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SaveVersionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SaveVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteVersionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteVersionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VersionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HashResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HashResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HashResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VersionExistsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersionExistsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersionExistsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Result {
+		i--
+		if m.Result {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetWithProofResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetWithProofResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetWithProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetWithValueHashProofResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetWithValueHashProofResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetWithValueHashProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ics23Proof) > 0 {
+		i -= len(m.Ics23Proof)
+		copy(dAtA[i:], m.Ics23Proof)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Ics23Proof)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAvailableVersionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAvailableVersionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAvailableVersionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Versions) > 0 {
+		dAtA12 := make([]byte, len(m.Versions)*10)
+		var j11 int
+		for _, num1 := range m.Versions {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA12[j11] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j11++
+			}
+			dAtA12[j11] = uint8(num)
+			j11++
+		}
+		i -= j11
+		copy(dAtA[i:], dAtA12[:j11])
+		i = encodeVarintIavlApi(dAtA, i, uint64(j11))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SizeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SizeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SizeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Boundary {
+		i--
+		if m.Boundary {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Version != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RangeProofItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RangeProofItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RangeProofItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Leaf != nil {
+		{
+			size, err := m.Leaf.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Path != nil {
+		{
+			size, err := m.Path.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetNodeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNodeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetNodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Node) > 0 {
+		i -= len(m.Node)
+		copy(dAtA[i:], m.Node)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Node)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeySample) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeySample) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeySample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIavlApi(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIavlApi(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintIavlApi(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SampleWithProofsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SampleWithProofsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SampleWithProofsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Samples) > 0 {
+		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Samples[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIavlApi(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIavlApi(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIavlApi(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *HasRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *HasVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *GetByIndexRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovIavlApi(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *GetByIndexVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	if m.Index != 0 {
+		n += 1 + sovIavlApi(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *GetVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *SetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *RemoveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteVersionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *VersionExistsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *VerifyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyItemRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyItemVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyItemValueHashRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyItemValueHashVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyAbsenceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyAbsenceVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *LoadVersionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *LoadVersionForOverwritingRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *ListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FromKey)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.ToKey)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Descending {
+		n += 2
+	}
+	if m.IncludeVersions {
+		n += 2
+	}
+	if m.IncludeProof {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	l = len(m.FromKey)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.ToKey)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Descending {
+		n += 2
+	}
+	if m.IncludeVersions {
+		n += 2
+	}
+	if m.IncludeProof {
+		n += 2
+	}
+	return n
+}
+
+func (m *HashVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *SizeVersionedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *GetNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *SampleWithProofsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	l = len(m.Seed)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.N != 0 {
+		n += 1 + sovIavlApi(uint64(m.N))
+	}
+	return n
+}
+
+func (m *HasResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovIavlApi(uint64(m.Index))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.NotFound {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetByIndexResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *SetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Updated {
+		n += 2
+	}
+	return n
+}
+
+func (m *RemoveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Removed {
+		n += 2
+	}
+	return n
+}
+
+func (m *SaveVersionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *DeleteVersionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *VersionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *HashResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *VersionExistsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetWithProofResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *GetWithValueHashProofResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Ics23Proof)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *GetAvailableVersionsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Versions) > 0 {
+		l = 0
+		for _, e := range m.Versions {
+			l += sovIavlApi(uint64(e))
+		}
+		n += 1 + sovIavlApi(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *SizeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		n += 1 + sovIavlApi(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *ListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovIavlApi(uint64(m.Version))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Boundary {
+		n += 2
+	}
+	return n
+}
+
+func (m *RangeProofItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Path != nil {
+		l = m.Path.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Leaf != nil {
+		l = m.Leaf.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *GetNodeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *KeySample) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovIavlApi(uint64(m.Index))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovIavlApi(uint64(l))
+	}
+	return n
+}
+
+func (m *SampleWithProofsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Samples) > 0 {
+		for _, e := range m.Samples {
+			l = e.Size()
+			n += 1 + l + sovIavlApi(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovIavlApi(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIavlApi(x uint64) (n int) {
+	return sovIavlApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *HasRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HasRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HasRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HasVersionedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HasVersionedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HasVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetByIndexRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetByIndexRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetByIndexRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetByIndexVersionedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetByIndexVersionedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetByIndexVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetVersionedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetVersionedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteVersionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteVersionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersionExistsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersionExistsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersionExistsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyVersionedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyVersionedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyItemRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyItemRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyItemRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyItemVersionedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyItemVersionedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyItemVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyItemValueHashRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyItemValueHashRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyItemValueHashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *ListResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIavlApi(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovIavlApi(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozIavlApi(x uint64) (n int) {
-	return sovIavlApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *HasRequest) Unmarshal(dAtA []byte) error {
+func (m *VerifyItemValueHashVersionedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4102,13 +8390,68 @@ func (m *HasRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyItemValueHashVersionedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyItemValueHashVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
@@ -4142,16 +8485,47 @@ func (m *HasRequest) Unmarshal(dAtA []byte) error {
 				m.Key = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4166,7 +8540,7 @@ func (m *HasRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HasVersionedRequest) Unmarshal(dAtA []byte) error {
+func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4189,17 +8563,17 @@ func (m *HasVersionedRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasVersionedRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyAbsenceRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyAbsenceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
 			}
-			m.Version = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -4209,12 +8583,63 @@ func (m *HasVersionedRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
@@ -4254,10 +8679,7 @@ func (m *HasVersionedRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4272,7 +8694,7 @@ func (m *HasVersionedRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRequest) Unmarshal(dAtA []byte) error {
+func (m *VerifyAbsenceVersionedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4295,13 +8717,68 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyAbsenceVersionedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyAbsenceVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
@@ -4341,10 +8818,7 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4359,7 +8833,7 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetByIndexRequest) Unmarshal(dAtA []byte) error {
+func (m *LoadVersionRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4382,17 +8856,17 @@ func (m *GetByIndexRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetByIndexRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LoadVersionRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetByIndexRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LoadVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.Index = 0
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -4402,7 +8876,7 @@ func (m *GetByIndexRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= int64(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -4413,10 +8887,7 @@ func (m *GetByIndexRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4431,7 +8902,7 @@ func (m *GetByIndexRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetVersionedRequest) Unmarshal(dAtA []byte) error {
+func (m *LoadVersionForOverwritingRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4454,10 +8925,10 @@ func (m *GetVersionedRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetVersionedRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LoadVersionForOverwritingRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LoadVersionForOverwritingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4479,50 +8950,13 @@ func (m *GetVersionedRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4537,7 +8971,7 @@ func (m *GetVersionedRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetRequest) Unmarshal(dAtA []byte) error {
+func (m *ListRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4560,15 +8994,15 @@ func (m *SetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FromKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4595,14 +9029,14 @@ func (m *SetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.FromKey = append(m.FromKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.FromKey == nil {
+				m.FromKey = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ToKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4629,21 +9063,78 @@ func (m *SetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.ToKey = append(m.ToKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ToKey == nil {
+				m.ToKey = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Descending", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Descending = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeVersions", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeVersions = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeProof", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeProof = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4658,7 +9149,7 @@ func (m *SetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveRequest) Unmarshal(dAtA []byte) error {
+func (m *ListVersionedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4681,15 +9172,34 @@ func (m *RemoveRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListVersionedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FromKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4716,69 +9226,90 @@ func (m *RemoveRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.FromKey = append(m.FromKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.FromKey == nil {
+				m.FromKey = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipIavlApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthIavlApi
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthIavlApi
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DeleteVersionRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowIavlApi
+			m.ToKey = append(m.ToKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ToKey == nil {
+				m.ToKey = []byte{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Descending", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteVersionRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Descending = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeVersions", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeVersions = bool(v != 0)
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeProof", wireType)
 			}
-			m.Version = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -4788,21 +9319,19 @@ func (m *DeleteVersionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.IncludeProof = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4817,7 +9346,7 @@ func (m *DeleteVersionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VersionExistsRequest) Unmarshal(dAtA []byte) error {
+func (m *HashVersionedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4840,10 +9369,10 @@ func (m *VersionExistsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VersionExistsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HashVersionedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VersionExistsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HashVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4871,10 +9400,7 @@ func (m *VersionExistsRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -4889,7 +9415,7 @@ func (m *VersionExistsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
+func (m *SizeVersionedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4912,51 +9438,17 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SizeVersionedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SizeVersionedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -4966,38 +9458,18 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Proof == nil {
-				m.Proof = &RangeProof{}
-			}
-			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5012,7 +9484,7 @@ func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VerifyItemRequest) Unmarshal(dAtA []byte) error {
+func (m *GetNodeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5035,119 +9507,15 @@ func (m *VerifyItemRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyItemRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetNodeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyItemRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Proof == nil {
-				m.Proof = &RangeProof{}
-			}
-			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5174,9 +9542,9 @@ func (m *VerifyItemRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5185,10 +9553,7 @@ func (m *VerifyItemRequest) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5203,7 +9568,7 @@ func (m *VerifyItemRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
+func (m *SampleWithProofsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5226,17 +9591,17 @@ func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VerifyAbsenceRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SampleWithProofsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VerifyAbsenceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SampleWithProofsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var byteLen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5246,31 +9611,16 @@ func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Seed", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5280,33 +9630,31 @@ func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthIavlApi
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Proof == nil {
-				m.Proof = &RangeProof{}
-			}
-			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Seed = append(m.Seed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seed == nil {
+				m.Seed = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field N", wireType)
 			}
-			var byteLen int
+			m.N = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5316,36 +9664,18 @@ func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.N |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5360,7 +9690,7 @@ func (m *VerifyAbsenceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LoadVersionRequest) Unmarshal(dAtA []byte) error {
+func (m *HasResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5383,17 +9713,17 @@ func (m *LoadVersionRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LoadVersionRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HasResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LoadVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HasResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.Version = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5403,21 +9733,19 @@ func (m *LoadVersionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Result = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5432,7 +9760,7 @@ func (m *LoadVersionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LoadVersionForOverwritingRequest) Unmarshal(dAtA []byte) error {
+func (m *GetResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5455,17 +9783,17 @@ func (m *LoadVersionForOverwritingRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LoadVersionForOverwritingRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LoadVersionForOverwritingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.Version = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5475,21 +9803,72 @@ func (m *LoadVersionForOverwritingRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				m.Index |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotFound", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.NotFound = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5504,7 +9883,7 @@ func (m *LoadVersionForOverwritingRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListRequest) Unmarshal(dAtA []byte) error {
+func (m *GetByIndexResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5527,15 +9906,15 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetByIndexResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetByIndexResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FromKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5562,14 +9941,14 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FromKey = append(m.FromKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.FromKey == nil {
-				m.FromKey = []byte{}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5596,14 +9975,64 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ToKey = append(m.ToKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.ToKey == nil {
-				m.ToKey = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Descending", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -5620,17 +10049,14 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Descending = bool(v != 0)
+			m.Updated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5645,7 +10071,7 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HasResponse) Unmarshal(dAtA []byte) error {
+func (m *RemoveResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5668,15 +10094,49 @@ func (m *HasResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HasResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HasResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -5693,17 +10153,14 @@ func (m *HasResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Result = bool(v != 0)
+			m.Removed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5718,7 +10175,7 @@ func (m *HasResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetResponse) Unmarshal(dAtA []byte) error {
+func (m *SaveVersionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5741,34 +10198,15 @@ func (m *GetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SaveVersionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SaveVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
-			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5795,16 +10233,16 @@ func (m *GetResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotFound", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5814,22 +10252,18 @@ func (m *GetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.NotFound = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5844,7 +10278,7 @@ func (m *GetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetByIndexResponse) Unmarshal(dAtA []byte) error {
+func (m *DeleteVersionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5867,15 +10301,15 @@ func (m *GetByIndexResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetByIndexResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteVersionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetByIndexResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5902,16 +10336,16 @@ func (m *GetByIndexResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var byteLen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -5921,36 +10355,18 @@ func (m *GetByIndexResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -5965,7 +10381,7 @@ func (m *GetByIndexResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetResponse) Unmarshal(dAtA []byte) error {
+func (m *VersionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5988,17 +10404,17 @@ func (m *SetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: VersionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -6008,22 +10424,18 @@ func (m *SetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Updated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6038,7 +10450,7 @@ func (m *SetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveResponse) Unmarshal(dAtA []byte) error {
+func (m *HashResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6061,15 +10473,15 @@ func (m *RemoveResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: HashResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6096,14 +10508,64 @@ func (m *RemoveResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersionExistsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersionExistsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersionExistsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -6120,17 +10582,14 @@ func (m *RemoveResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
+			m.Result = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6145,7 +10604,7 @@ func (m *RemoveResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SaveVersionResponse) Unmarshal(dAtA []byte) error {
+func (m *GetWithProofResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6168,15 +10627,15 @@ func (m *SaveVersionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SaveVersionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetWithProofResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SaveVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetWithProofResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6203,16 +10662,16 @@ func (m *SaveVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
-			m.Version = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -6222,21 +10681,35 @@ func (m *SaveVersionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6251,7 +10724,7 @@ func (m *SaveVersionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteVersionResponse) Unmarshal(dAtA []byte) error {
+func (m *GetWithValueHashProofResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6274,15 +10747,15 @@ func (m *DeleteVersionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteVersionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetWithValueHashProofResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetWithValueHashProofResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6309,16 +10782,16 @@ func (m *DeleteVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
-			m.Version = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -6328,69 +10801,33 @@ func (m *DeleteVersionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipIavlApi(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthIavlApi
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthIavlApi
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *VersionResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowIavlApi
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: VersionResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ics23Proof", wireType)
 			}
-			m.Version = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -6400,21 +10837,33 @@ func (m *VersionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ics23Proof = append(m.Ics23Proof[:0], dAtA[iNdEx:postIndex]...)
+			if m.Ics23Proof == nil {
+				m.Ics23Proof = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
+				return err
 			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6429,7 +10878,7 @@ func (m *VersionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HashResponse) Unmarshal(dAtA []byte) error {
+func (m *GetAvailableVersionsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6452,56 +10901,95 @@ func (m *HashResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HashResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAvailableVersionsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAvailableVersionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIavlApi
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIavlApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.Versions = append(m.Versions, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIavlApi
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIavlApi
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIavlApi
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
+				elementCount = count
+				if elementCount != 0 && len(m.Versions) == 0 {
+					m.Versions = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIavlApi
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Versions = append(m.Versions, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.RootHash == nil {
-				m.RootHash = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6516,7 +11004,7 @@ func (m *HashResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VersionExistsResponse) Unmarshal(dAtA []byte) error {
+func (m *SizeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6539,17 +11027,17 @@ func (m *VersionExistsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VersionExistsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SizeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VersionExistsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SizeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
 			}
-			var v int
+			m.Size_ = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -6559,22 +11047,18 @@ func (m *VersionExistsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Size_ |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Result = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6589,7 +11073,7 @@ func (m *VersionExistsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetWithProofResponse) Unmarshal(dAtA []byte) error {
+func (m *ListResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6612,13 +11096,47 @@ func (m *GetWithProofResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetWithProofResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetWithProofResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
@@ -6652,7 +11170,26 @@ func (m *GetWithProofResponse) Unmarshal(dAtA []byte) error {
 				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
@@ -6682,22 +11219,39 @@ func (m *GetWithProofResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Proof == nil {
-				m.Proof = &RangeProof{}
+				m.Proof = &RangeProofItem{}
 			}
 			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Boundary", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Boundary = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6712,7 +11266,7 @@ func (m *GetWithProofResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetAvailableVersionsResponse) Unmarshal(dAtA []byte) error {
+func (m *RangeProofItem) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6730,103 +11284,96 @@ func (m *GetAvailableVersionsResponse) Unmarshal(dAtA []byte) error {
 			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
 				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetAvailableVersionsResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAvailableVersionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType == 0 {
-				var v int64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowIavlApi
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= int64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Versions = append(m.Versions, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowIavlApi
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthIavlApi
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthIavlApi
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RangeProofItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RangeProofItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.Versions) == 0 {
-					m.Versions = make([]int64, 0, elementCount)
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Path == nil {
+				m.Path = &PathToLeaf{}
+			}
+			if err := m.Path.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaf", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
 				}
-				for iNdEx < postIndex {
-					var v int64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowIavlApi
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= int64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Versions = append(m.Versions, v)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
 			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leaf == nil {
+				m.Leaf = &ProofLeafNode{}
+			}
+			if err := m.Leaf.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6841,7 +11388,7 @@ func (m *GetAvailableVersionsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SizeResponse) Unmarshal(dAtA []byte) error {
+func (m *GetNodeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6864,17 +11411,17 @@ func (m *SizeResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SizeResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetNodeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SizeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetNodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
-			m.Size_ = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIavlApi
@@ -6884,21 +11431,33 @@ func (m *SizeResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size_ |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = append(m.Node[:0], dAtA[iNdEx:postIndex]...)
+			if m.Node == nil {
+				m.Node = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIavlApi
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -6913,7 +11472,7 @@ func (m *SizeResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListResponse) Unmarshal(dAtA []byte) error {
+func (m *KeySample) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6936,13 +11495,32 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: KeySample: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KeySample: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
@@ -6976,7 +11554,7 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 				m.Key = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
@@ -7010,16 +11588,133 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 				m.Value = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &RangeProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIavlApi(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SampleWithProofsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIavlApi
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SampleWithProofsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SampleWithProofsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIavlApi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthIavlApi
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIavlApi
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, &KeySample{})
+			if err := m.Samples[len(m.Samples)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIavlApi(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthIavlApi
 			}
 			if (iNdEx + skippy) > l {
@@ -0,0 +1,306 @@
+package iavl
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// RebuildResult describes a tree rebuilt by MutableTree.Rebuild().
+type RebuildResult struct {
+	Version int64  // The new version containing the rebuilt tree.
+	Hash    []byte // The root hash of the rebuilt tree.
+
+	// OldHeight and NewHeight are the tree heights before and after rebuilding, i.e. the maximum
+	// number of inner nodes in a proof.
+	OldHeight int8
+	NewHeight int8
+
+	// OldProofSize and NewProofSize are the average number of inner nodes in the proof of a key
+	// before and after rebuilding, i.e. the average depth of the leaf nodes.
+	OldProofSize float64
+	NewProofSize float64
+}
+
+// An interrupted rebuild is recorded with the version being rebuilt, since nodes of the new
+// version and orphan entries for the nodes of the rebuilt version may already have been written.
+var rebuildKeyFormat = NewKeyFormat('b') // b
+
+// Rebuild rewrites the state at the latest saved version into a perfectly balanced, canonical
+// tree, and saves it as a new version. It is an explicit, opt-in operation meant for coordinated
+// upgrades: since all nodes are rewritten with the new version, the new root hash differs from the
+// old one even though the keys and values are unchanged.
+//
+// The result is deterministic, i.e. the same state rebuilt at the same version always gives the
+// same root hash regardless of the history of the tree. In the canonical shape, the leaves of
+// every inner node are split evenly between its children, with the left child getting the extra
+// leaf if the number is odd.
+//
+// The new nodes are written to the database in bounded batches, followed by orphan entries for
+// all nodes of the previous version, so the old version can be pruned. The new root is written
+// last. Since the orphan entries must not be used unless the new root exists, the rebuild is
+// recorded in a checkpoint until it completes. If it is interrupted, e.g. by a crash, no other
+// versions can be saved until either Rebuild() is called again to complete it, or it is discarded
+// with LoadVersionForOverwriting() at the latest version. The working tree cannot contain unsaved
+// changes.
+func (tree *MutableTree) Rebuild() (*RebuildResult, error) {
+	if err := tree.checkPending(); err != nil {
+		return nil, err
+	}
+	if tree.version == 0 {
+		return nil, errors.New("cannot rebuild a tree without saved versions")
+	}
+	if tree.root != tree.lastSaved.root {
+		return nil, errors.New("cannot rebuild a tree with unsaved changes")
+	}
+	version := tree.version + 1
+	if tree.VersionExists(version) {
+		return nil, errors.Errorf("version %v already exists", version)
+	}
+	interrupted, err := tree.ndb.getRebuildCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	if interrupted != 0 && interrupted != version {
+		return nil, errors.Errorf("found interrupted rebuild of version %v, but next version is %v",
+			interrupted, version)
+	}
+	if err = tree.ndb.checkMigration(); err != nil {
+		return nil, err
+	}
+
+	result := &RebuildResult{Version: version}
+	old := tree.ImmutableTree
+	var root *Node
+	if old.root != nil {
+		// Record the rebuild before writing anything else. Resuming it simply rebuilds again,
+		// since rewriting the same nodes and orphan entries is idempotent.
+		if err = tree.ndb.saveRebuildCheckpoint(version); err != nil {
+			return nil, err
+		}
+		if err = tree.ndb.Commit(); err != nil {
+			return nil, err
+		}
+
+		r := &rebuilder{ndb: tree.ndb, version: version, old: old}
+		iter := old.Iterator(nil, nil, true)
+		root, _, err = r.build(iter, old.root.size, 0)
+		iter.Close()
+		if err != nil {
+			return nil, err
+		}
+		if r.ndb.pageLevels() > 0 {
+			if err = r.ndb.savePage(root.pageNodes(nil)); err != nil {
+				return nil, err
+			}
+		}
+		result.NewHeight = root.height
+		result.NewProofSize = float64(r.depthSum) / float64(root.size)
+
+		// Orphan all existing nodes, and measure the old shape.
+		r.depthSum = 0
+		r.orphan(old.root, 0, tree.ndb.getPreviousVersion(version))
+		result.OldHeight = old.root.height
+		result.OldProofSize = float64(r.depthSum) / float64(old.root.size)
+
+		if err = tree.ndb.SaveRoot(root, version); err != nil {
+			return nil, err
+		}
+	} else if err = tree.ndb.SaveEmptyRoot(version); err != nil {
+		return nil, err
+	}
+	if err = tree.ndb.deleteRebuildCheckpoint(); err != nil {
+		return nil, err
+	}
+	if err = tree.ndb.Commit(); err != nil {
+		return nil, err
+	}
+
+	tree.mtx.Lock()
+	defer tree.mtx.Unlock()
+	tree.version = version
+	tree.versions[version] = true
+	tree.ImmutableTree = &ImmutableTree{root: root, ndb: tree.ndb, version: version}
+	tree.lastSaved = tree.ImmutableTree.clone()
+	tree.orphans = map[string]int64{}
+
+	result.Hash = tree.Hash()
+	return result, nil
+}
+
+// rebuilder builds a canonical tree from the leaves of the old tree, saving nodes as it goes, and
+// orphans the nodes of the old tree.
+type rebuilder struct {
+	ndb       *nodeDB
+	version   int64
+	old       *ImmutableTree
+	batchSize int
+	depthSum  int64
+}
+
+// build builds a canonical subtree of the next size leaves of the iterator at the given depth,
+// and returns its root and leftmost key. The returned root does not retain its children, unless
+// they are in the root's unsaved node page.
+func (r *rebuilder) build(iter *Iterator, size int64, depth int64) (*Node, []byte, error) {
+	var node *Node
+	var minKey []byte
+	if size == 1 {
+		if !iter.Valid() {
+			return nil, nil, errors.New("tree has fewer leaves than its size")
+		}
+		node = NewNode(iter.Key(), iter.Value(), r.version)
+		minKey = node.key
+		r.depthSum += depth
+		iter.Next()
+	} else {
+		left, leftKey, err := r.build(iter, (size+1)/2, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		right, rightKey, err := r.build(iter, size/2, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		node = &Node{
+			key:       rightKey,
+			version:   r.version,
+			height:    maxInt8(left.height, right.height) + 1,
+			size:      size,
+			leftHash:  left.hash,
+			rightHash: right.hash,
+		}
+		if r.ndb.pageLevels() > 0 {
+			node.leftNode, node.rightNode = left, right
+		}
+		minKey = leftKey
+	}
+
+	node._hash()
+	if r.ndb.pageLevels() > 0 {
+		if err := r.ndb.flushChildPages(node, r.ndb.savePage); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		r.ndb.SaveNode(node)
+	}
+	r.written()
+	return node, minKey, nil
+}
+
+// orphan saves orphan entries for the given node of the old tree and all of its descendants,
+// expiring at toVersion, and adds the depths of all leaves to depthSum.
+func (r *rebuilder) orphan(node *Node, depth int64, toVersion int64) {
+	r.ndb.mtx.Lock()
+	r.ndb.saveOrphan(node.hash, node.version, toVersion)
+	r.ndb.mtx.Unlock()
+	r.written()
+
+	if node.isLeaf() {
+		r.depthSum += depth
+		return
+	}
+	r.orphan(node.getLeftNode(r.old), depth+1, toVersion)
+	r.orphan(node.getRightNode(r.old), depth+1, toVersion)
+}
+
+// written records an entry written to the batch, and flushes the batch when it is full.
+func (r *rebuilder) written() {
+	r.batchSize++
+	if r.batchSize >= maxBatchSize {
+		r.ndb.resetBatch()
+		r.batchSize = 0
+	}
+}
+
+// getRebuildCheckpoint returns the version of an interrupted rebuild, or 0 if none.
+func (ndb *nodeDB) getRebuildCheckpoint() (int64, error) {
+	buf, err := ndb.db.Get(rebuildKeyFormat.Key())
+	if err != nil || buf == nil {
+		return 0, err
+	}
+	version, _, err := decodeVarint(buf)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to decode rebuild checkpoint")
+	}
+	return version, nil
+}
+
+// saveRebuildCheckpoint records a rebuild of the given version in the batch.
+func (ndb *nodeDB) saveRebuildCheckpoint(version int64) error {
+	var buf bytes.Buffer
+	if err := encodeVarint(&buf, version); err != nil {
+		return err
+	}
+
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+	return ndb.batch.Set(rebuildKeyFormat.Key(), buf.Bytes())
+}
+
+// deleteRebuildCheckpoint deletes the rebuild checkpoint, if any, in the batch.
+func (ndb *nodeDB) deleteRebuildCheckpoint() error {
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+	return ndb.batch.Delete(rebuildKeyFormat.Key())
+}
+
+// discardRebuild deletes the orphan entries written by an interrupted rebuild, if any, committing
+// them in bounded batches. The checkpoint itself is deleted in the batch, and must be committed by
+// the caller.
+func (ndb *nodeDB) discardRebuild() error {
+	version, err := ndb.getRebuildCheckpoint()
+	if err != nil || version == 0 {
+		return err
+	}
+
+	// Since the rebuilt version does not exist, all orphan entries expiring at the previous
+	// version were written by the rebuild.
+	if err = ndb.deleteOrphanEntries(version - 1); err != nil {
+		return err
+	}
+	return ndb.deleteRebuildCheckpoint()
+}
+
+// deleteOrphanEntries deletes all orphan entries expiring at the given version, but not the
+// orphaned nodes, committing them in bounded batches.
+func (ndb *nodeDB) deleteOrphanEntries(toVersion int64) error {
+	start, end := orphanKeyFormat.Key(toVersion), orphanKeyFormat.Key(toVersion+1)
+	for {
+		// Collect the keys first, since the database may not be written while iterating.
+		var keys [][]byte
+		ndb.traverseRange(start, end, func(key, value []byte) {
+			if len(keys) < maxBatchSize {
+				keys = append(keys, append([]byte{}, key...))
+			}
+		})
+		if len(keys) == 0 {
+			return nil
+		}
+		ndb.mtx.Lock()
+		for _, key := range keys {
+			if err := ndb.batch.Delete(key); err != nil {
+				ndb.mtx.Unlock()
+				return err
+			}
+		}
+		ndb.mtx.Unlock()
+		if err := ndb.Commit(); err != nil {
+			return err
+		}
+		start = append(keys[len(keys)-1], 0)
+	}
+}
+
+// checkRebuild returns an error if a rebuild was interrupted, since saving another version would
+// make its orphan entries prune nodes which are still in use.
+func (ndb *nodeDB) checkRebuild() error {
+	version, err := ndb.getRebuildCheckpoint()
+	if err != nil {
+		return err
+	}
+	if version != 0 {
+		return errors.Errorf("found interrupted rebuild of version %v, complete it with Rebuild() "+
+			"or discard it with LoadVersionForOverwriting()", version)
+	}
+	return nil
+}
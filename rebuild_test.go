@@ -0,0 +1,229 @@
+package iavl
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// setupRebuildTree sets up a tree with the keys 0..size-1 after the given number of versions of
+// random inserts and deletes, ending up with the same state regardless of the seed.
+func setupRebuildTree(t *testing.T, memDB db.DB, size int, versions int, seed int64) *MutableTree {
+	r := rand.New(rand.NewSource(seed))
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	for v := 0; v < versions; v++ {
+		for _, i := range r.Perm(size) {
+			key := []byte(fmt.Sprintf("key-%05d", i))
+			if r.Intn(2) == 0 && v < versions-1 {
+				tree.Remove(key)
+			} else {
+				tree.Set(key, []byte(fmt.Sprintf("value-%d", i)))
+			}
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+func TestMutableTree_Rebuild(t *testing.T) {
+	const size = 1000
+	memDB := db.NewMemDB()
+	tree := setupRebuildTree(t, memDB, size, 5, 1)
+	oldHash := tree.Hash()
+	oldVersion := tree.Version()
+	oldHeight := tree.Height()
+
+	result, err := tree.Rebuild()
+	require.NoError(t, err)
+	require.Equal(t, oldVersion+1, result.Version)
+	require.Equal(t, oldVersion+1, tree.Version())
+	require.Equal(t, tree.Hash(), result.Hash)
+	require.NotEqual(t, oldHash, result.Hash)
+	require.Equal(t, oldHeight, result.OldHeight)
+	require.EqualValues(t, 10, result.NewHeight) // ceil(log2(1000))
+	require.EqualValues(t, 10, tree.Height())
+	require.Less(t, result.NewProofSize, result.OldProofSize)
+	require.LessOrEqual(t, result.NewHeight, result.OldHeight)
+	require.EqualValues(t, size, tree.Size())
+
+	for i := 0; i < size; i++ {
+		index, value := tree.Get([]byte(fmt.Sprintf("key-%05d", i)))
+		require.EqualValues(t, i, index)
+		require.Equal(t, []byte(fmt.Sprintf("value-%d", i)), value)
+	}
+	key := []byte("key-00042")
+	value, proof, err := tree.GetWithProof(key)
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(result.Hash))
+	require.NoError(t, proof.VerifyItem(key, value))
+
+	// The old version is still available, and can be pruned.
+	oldTree, err := tree.GetImmutable(oldVersion)
+	require.NoError(t, err)
+	require.Equal(t, oldHash, oldTree.Hash())
+	require.NoError(t, tree.DeleteVersionsRange(1, result.Version))
+
+	// The rebuilt version can be loaded from disk, and written to.
+	reloaded, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = reloaded.Load()
+	require.NoError(t, err)
+	require.Equal(t, result.Hash, reloaded.Hash())
+	require.EqualValues(t, size, reloaded.Size())
+	reloaded.Set([]byte("key-99999"), []byte("new"))
+	reloaded.Remove([]byte("key-00001"))
+	_, _, err = reloaded.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, size, reloaded.Size())
+
+	// Only nodes of the rebuilt and later versions remain after pruning.
+	require.NoError(t, reloaded.DeleteVersion(result.Version))
+	for _, node := range reloaded.ndb.nodes() {
+		require.Greater(t, node.version, oldVersion)
+	}
+}
+
+func TestMutableTree_Rebuild_Deterministic(t *testing.T) {
+	hashes := [][]byte{}
+	for seed := int64(1); seed <= 3; seed++ {
+		tree := setupRebuildTree(t, db.NewMemDB(), 500, 3, seed)
+		result, err := tree.Rebuild()
+		require.NoError(t, err)
+		hashes = append(hashes, result.Hash)
+	}
+	require.Equal(t, hashes[0], hashes[1])
+	require.Equal(t, hashes[0], hashes[2])
+}
+
+func TestMutableTree_Rebuild_Sizes(t *testing.T) {
+	for _, size := range []int{1, 2, 3, 7, 8, 9} {
+		size := size
+		t.Run(fmt.Sprintf("size %v", size), func(t *testing.T) {
+			tree := setupRebuildTree(t, db.NewMemDB(), size, 1, 1)
+			result, err := tree.Rebuild()
+			require.NoError(t, err)
+			require.EqualValues(t, size, tree.Size())
+
+			height := int8(0)
+			for 1<<height < size {
+				height++
+			}
+			require.Equal(t, height, result.NewHeight)
+
+			// The rebuilt tree must be identical to one imported from its own export.
+			newTree, err := NewMutableTree(db.NewMemDB(), 0)
+			require.NoError(t, err)
+			importer, err := newTree.Import(tree.Version())
+			require.NoError(t, err)
+			defer importer.Close()
+			exporter := tree.ImmutableTree.Export()
+			defer exporter.Close()
+			for {
+				node, err := exporter.Next()
+				if err == ExportDone {
+					break
+				}
+				require.NoError(t, err)
+				require.NoError(t, importer.Add(node))
+			}
+			require.NoError(t, importer.Commit())
+			require.Equal(t, tree.Hash(), newTree.Hash())
+		})
+	}
+}
+
+func TestMutableTree_Rebuild_Empty(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	_, err = tree.Rebuild()
+	require.Error(t, err)
+
+	tree.Set([]byte("a"), []byte{1})
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	tree.Remove([]byte("a"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	result, err := tree.Rebuild()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, result.Version)
+	require.EqualValues(t, 0, tree.Size())
+	require.True(t, tree.VersionExists(3))
+}
+
+func TestMutableTree_Rebuild_UnsavedChanges(t *testing.T) {
+	tree := setupRebuildTree(t, db.NewMemDB(), 10, 1, 1)
+	tree.Set([]byte("unsaved"), []byte{1})
+	_, err := tree.Rebuild()
+	require.Error(t, err)
+
+	tree.Rollback()
+	_, err = tree.Rebuild()
+	require.NoError(t, err)
+}
+
+// interruptRebuild simulates a rebuild interrupted after orphaning the nodes of the latest version.
+func interruptRebuild(t *testing.T, tree *MutableTree) {
+	require.NoError(t, tree.ndb.saveRebuildCheckpoint(tree.Version()+1))
+	r := &rebuilder{ndb: tree.ndb, version: tree.Version() + 1, old: tree.ImmutableTree}
+	r.orphan(tree.root, 0, tree.Version())
+	require.NoError(t, tree.ndb.Commit())
+}
+
+func TestMutableTree_Rebuild_Interrupted(t *testing.T) {
+	const size = 100
+	memDB := db.NewMemDB()
+	tree := setupRebuildTree(t, memDB, size, 3, 1)
+	version := tree.Version()
+	interruptRebuild(t, tree)
+
+	// No other versions can be saved until the rebuild is completed.
+	reloaded, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = reloaded.Load()
+	require.NoError(t, err)
+	reloaded.Set([]byte("new"), []byte{1})
+	_, _, err = reloaded.SaveVersion()
+	require.Error(t, err)
+	reloaded.Rollback()
+
+	result, err := reloaded.Rebuild()
+	require.NoError(t, err)
+	expected, err := setupRebuildTree(t, db.NewMemDB(), size, 3, 1).Rebuild()
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash, result.Hash)
+
+	reloaded.Set([]byte("new"), []byte{1})
+	_, _, err = reloaded.SaveVersion()
+	require.NoError(t, err)
+	require.NoError(t, reloaded.DeleteVersionsRange(1, version+1))
+	require.EqualValues(t, size+1, reloaded.Size())
+}
+
+func TestMutableTree_Rebuild_InterruptedDiscard(t *testing.T) {
+	const size = 100
+	tree := setupRebuildTree(t, db.NewMemDB(), size, 3, 1)
+	version := tree.Version()
+	interruptRebuild(t, tree)
+
+	_, err := tree.LoadVersionForOverwriting(version)
+	require.NoError(t, err)
+	tree.Set([]byte("new"), []byte{1})
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	// Pruning the old version must not delete the nodes still in use by the new one.
+	require.NoError(t, tree.DeleteVersionsRange(1, version+1))
+	require.EqualValues(t, size+1, tree.Size())
+	for i := 0; i < size; i++ {
+		_, value := tree.Get([]byte(fmt.Sprintf("key-%05d", i)))
+		require.Equal(t, []byte(fmt.Sprintf("value-%d", i)), value)
+	}
+}
@@ -0,0 +1,275 @@
+package iavl
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	iavlproto "github.com/cosmos/iavl/proto"
+)
+
+// errRemoteReadOnly is returned when attempting to write to a RemoteNodeDB.
+var errRemoteReadOnly = errors.New("remote node database is read-only")
+
+var _ dbm.DB = (*RemoteNodeDB)(nil)
+
+// RemoteNodeDB is a read-only node store which fetches nodes by hash from a remote node service,
+// i.e. the GetNode RPC of iavlserver. It implements dbm.DB such that it can back an ImmutableTree
+// opened with NewRemoteImmutableTree(), which serves Get, Iterate and proofs without holding the
+// full database locally. Only node keys are served, all other keys are reported as missing.
+//
+// Each fetched node is verified by rehashing it, and is rejected if its hash does not match the
+// requested one. Since the key of an inner node is not part of its hash, a server can still
+// misdirect lookups in the tree, so clients that do not trust the server should verify proofs
+// against a trusted root hash.
+//
+// Verified nodes are optionally cached in a local database, bounded by a maximum number of nodes
+// which are evicted in least-recently-used order. Nodes are also cached in memory by the tree
+// itself.
+type RemoteNodeDB struct {
+	client iavlproto.IAVLServiceClient
+
+	mtx        sync.Mutex
+	cache      dbm.DB                   // Local node cache, or nil.
+	cacheLimit int                      // Maximum number of nodes in cache, or 0 for unbounded.
+	cacheQueue *list.List               // Cached node keys in access order. Used for LRU eviction.
+	cacheElems map[string]*list.Element // Cached node keys by key.
+}
+
+// NewRemoteNodeDB creates a new remote node store using the given client. If cache is not nil,
+// verified nodes are stored in it, up to cacheLimit nodes (0 means unbounded). Nodes already in
+// the cache are reused, and are not verified again.
+func NewRemoteNodeDB(client iavlproto.IAVLServiceClient, cache dbm.DB, cacheLimit int) (*RemoteNodeDB, error) {
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	if cacheLimit < 0 {
+		return nil, errors.Errorf("cache limit cannot be negative, got %v", cacheLimit)
+	}
+	db := &RemoteNodeDB{
+		client:     client,
+		cache:      cache,
+		cacheLimit: cacheLimit,
+		cacheQueue: list.New(),
+		cacheElems: map[string]*list.Element{},
+	}
+	if cache != nil {
+		itr, err := dbm.IteratePrefix(cache, nodeKeyFormat.Key())
+		if err != nil {
+			return nil, err
+		}
+		defer itr.Close()
+		for ; itr.Valid(); itr.Next() {
+			key := append([]byte{}, itr.Key()...)
+			db.cacheElems[string(key)] = db.cacheQueue.PushBack(key)
+		}
+		if err = itr.Error(); err != nil {
+			return nil, err
+		}
+		if err = db.evict(); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// NewRemoteImmutableTree opens the tree with the given root hash over a remote node store. Its
+// version is the version of the root node, i.e. the last version at which the tree was modified.
+// Nodes are fetched on demand, and cached in memory up to cacheSize nodes. As with other
+// databases, failing to fetch a node while traversing the tree causes a panic.
+func NewRemoteImmutableTree(db *RemoteNodeDB, rootHash []byte, cacheSize int) (*ImmutableTree, error) {
+	if db == nil {
+		return nil, errors.New("remote node database cannot be nil")
+	}
+	tree := &ImmutableTree{ndb: newNodeDB(db, cacheSize, nil)}
+	if bytes.Equal(rootHash, sha256.New().Sum(nil)) {
+		return tree, nil
+	}
+
+	buf, err := db.Get(tree.ndb.nodeKey(rootHash))
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, errors.Errorf("root node %X not found", rootHash)
+	}
+	root, err := MakeNode(buf)
+	if err != nil {
+		return nil, err
+	}
+	root.hash = rootHash
+	root.persisted = true
+	tree.root = root
+	tree.version = root.version
+	return tree, nil
+}
+
+// Get implements dbm.DB. It fetches and verifies the node for a node key, or returns nil if the
+// key is not a node key or the node does not exist.
+func (db *RemoteNodeDB) Get(key []byte) ([]byte, error) {
+	if len(key) != 1+hashSize || key[0] != nodeKeyFormat.Prefix()[0] {
+		return nil, nil
+	}
+	if db.cache != nil {
+		buf, err := db.cache.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if buf != nil {
+			db.touch(key)
+			return buf, nil
+		}
+	}
+
+	hash := key[1:]
+	res, err := db.client.GetNode(context.Background(), &iavlproto.GetNodeRequest{Hash: hash})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch node %X", hash)
+	}
+
+	node, err := MakeNode(res.Node)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid node %X", hash)
+	}
+	if !bytes.Equal(node._hash(), hash) {
+		return nil, errors.Errorf("node hash %X does not match requested hash %X", node.hash, hash)
+	}
+
+	if db.cache != nil {
+		if err = db.cacheNode(key, res.Node); err != nil {
+			return nil, err
+		}
+	}
+	return res.Node, nil
+}
+
+// cacheNode stores a verified node in the local cache, evicting old nodes if needed.
+func (db *RemoteNodeDB) cacheNode(key, buf []byte) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	if err := db.cache.Set(key, buf); err != nil {
+		return err
+	}
+	if elem, ok := db.cacheElems[string(key)]; ok {
+		db.cacheQueue.MoveToBack(elem)
+		return nil
+	}
+	key = append([]byte{}, key...)
+	db.cacheElems[string(key)] = db.cacheQueue.PushBack(key)
+	return db.evict()
+}
+
+// touch marks a cached node as recently used.
+func (db *RemoteNodeDB) touch(key []byte) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	if elem, ok := db.cacheElems[string(key)]; ok {
+		db.cacheQueue.MoveToBack(elem)
+	}
+}
+
+// evict removes the least recently used nodes from the cache until it is within the cache limit.
+func (db *RemoteNodeDB) evict() error {
+	for db.cacheLimit > 0 && db.cacheQueue.Len() > db.cacheLimit {
+		oldest := db.cacheQueue.Front()
+		key := oldest.Value.([]byte)
+		if err := db.cache.Delete(key); err != nil {
+			return err
+		}
+		db.cacheQueue.Remove(oldest)
+		delete(db.cacheElems, string(key))
+	}
+	return nil
+}
+
+// Has implements dbm.DB.
+func (db *RemoteNodeDB) Has(key []byte) (bool, error) {
+	buf, err := db.Get(key)
+	return buf != nil, err
+}
+
+// Set implements dbm.DB. It always errors, since the database is read-only.
+func (db *RemoteNodeDB) Set([]byte, []byte) error {
+	return errRemoteReadOnly
+}
+
+// SetSync implements dbm.DB. It always errors, since the database is read-only.
+func (db *RemoteNodeDB) SetSync([]byte, []byte) error {
+	return errRemoteReadOnly
+}
+
+// Delete implements dbm.DB. It always errors, since the database is read-only.
+func (db *RemoteNodeDB) Delete([]byte) error {
+	return errRemoteReadOnly
+}
+
+// DeleteSync implements dbm.DB. It always errors, since the database is read-only.
+func (db *RemoteNodeDB) DeleteSync([]byte) error {
+	return errRemoteReadOnly
+}
+
+// Iterator implements dbm.DB. It always errors, since nodes can only be fetched by hash.
+func (db *RemoteNodeDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	return nil, errors.New("remote node database does not support iteration")
+}
+
+// ReverseIterator implements dbm.DB. It always errors, since nodes can only be fetched by hash.
+func (db *RemoteNodeDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	return nil, errors.New("remote node database does not support iteration")
+}
+
+// Close implements dbm.DB. It does not close the client connection nor the cache.
+func (db *RemoteNodeDB) Close() error {
+	return nil
+}
+
+// NewBatch implements dbm.DB. Writes to the returned batch always error.
+func (db *RemoteNodeDB) NewBatch() dbm.Batch {
+	return remoteBatch{}
+}
+
+// Print implements dbm.DB.
+func (db *RemoteNodeDB) Print() error {
+	fmt.Printf("remote node database with %v cached nodes\n", db.cachedNodes())
+	return nil
+}
+
+// Stats implements dbm.DB.
+func (db *RemoteNodeDB) Stats() map[string]string {
+	return map[string]string{
+		"remote.cached_nodes": fmt.Sprintf("%v", db.cachedNodes()),
+	}
+}
+
+// IsTrackable implements dbm.DB.
+func (db *RemoteNodeDB) IsTrackable() bool {
+	return false
+}
+
+// cachedNodes returns the number of nodes in the local cache.
+func (db *RemoteNodeDB) cachedNodes() int {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return db.cacheQueue.Len()
+}
+
+// remoteBatch is the batch of a RemoteNodeDB, which does not allow writes.
+type remoteBatch struct{}
+
+func (remoteBatch) Set(key, value []byte) error { return errRemoteReadOnly }
+func (remoteBatch) Delete(key []byte) error     { return errRemoteReadOnly }
+func (remoteBatch) Write() error                { return nil }
+func (remoteBatch) WriteSync() error            { return nil }
+func (remoteBatch) Close() error                { return nil }
@@ -0,0 +1,212 @@
+package iavl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	db "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	iavlproto "github.com/cosmos/iavl/proto"
+)
+
+// nodeClient is an IAVLServiceClient serving GetNode from a local tree. Other methods panic.
+type nodeClient struct {
+	iavlproto.IAVLServiceClient
+	tree    *ImmutableTree
+	tamper  bool
+	fetched int
+}
+
+func (c *nodeClient) GetNode(_ context.Context, req *iavlproto.GetNodeRequest, _ ...grpc.CallOption) (*iavlproto.GetNodeResponse, error) {
+	c.fetched++
+	node, err := c.tree.GetEncodedNode(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if c.tamper {
+		node[len(node)-1]++
+	}
+	return &iavlproto.GetNodeResponse{Node: node}, nil
+}
+
+func TestRemoteImmutableTree(t *testing.T) {
+	tree := setupExportTreeSized(t, 256)
+	client := &nodeClient{tree: tree}
+	remoteDB, err := NewRemoteNodeDB(client, nil, 0)
+	require.NoError(t, err)
+	remote, err := NewRemoteImmutableTree(remoteDB, tree.Hash(), 1000)
+	require.NoError(t, err)
+
+	require.Equal(t, tree.Hash(), remote.Hash())
+	require.Equal(t, tree.Size(), remote.Size())
+	require.Equal(t, tree.root.version, remote.Version())
+
+	keys := 0
+	remote.Iterate(func(key, value []byte) bool {
+		index, expected := tree.Get(key)
+		remoteIndex, remoteValue := remote.Get(key)
+		require.Equal(t, index, remoteIndex)
+		require.Equal(t, expected, remoteValue)
+		require.Equal(t, expected, value)
+		keys++
+		return false
+	})
+	require.EqualValues(t, tree.Size(), keys)
+
+	key, _ := tree.GetByIndex(17)
+	value, proof, err := remote.GetWithProof(key)
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(tree.Hash()))
+	require.NoError(t, proof.VerifyItem(key, value))
+
+	_, proof, err = remote.GetWithProof([]byte("missing"))
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(tree.Hash()))
+	require.NoError(t, proof.VerifyAbsence([]byte("missing")))
+}
+
+func TestRemoteImmutableTree_Empty(t *testing.T) {
+	tree := NewImmutableTree(db.NewMemDB(), 0)
+	remoteDB, err := NewRemoteNodeDB(&nodeClient{tree: tree}, nil, 0)
+	require.NoError(t, err)
+	remote, err := NewRemoteImmutableTree(remoteDB, tree.Hash(), 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, remote.Size())
+	require.Equal(t, tree.Hash(), remote.Hash())
+}
+
+func TestRemoteImmutableTree_MissingRoot(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+	remoteDB, err := NewRemoteNodeDB(&nodeClient{tree: tree}, nil, 0)
+	require.NoError(t, err)
+	_, err = NewRemoteImmutableTree(remoteDB, make([]byte, hashSize), 0)
+	require.Error(t, err)
+}
+
+func TestRemoteNodeDB_Tampered(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+	remoteDB, err := NewRemoteNodeDB(&nodeClient{tree: tree, tamper: true}, nil, 0)
+	require.NoError(t, err)
+	_, err = NewRemoteImmutableTree(remoteDB, tree.Hash(), 0)
+	require.Error(t, err)
+
+	_, err = remoteDB.Get(nodeKeyFormat.Key(tree.Hash()))
+	require.Error(t, err)
+}
+
+func TestRemoteNodeDB_Cache(t *testing.T) {
+	tree := setupExportTreeSized(t, 64)
+	client := &nodeClient{tree: tree}
+	cache := db.NewMemDB()
+	remoteDB, err := NewRemoteNodeDB(client, cache, 16)
+	require.NoError(t, err)
+
+	// Without an in-memory tree cache, all reads go through the local cache.
+	remote, err := NewRemoteImmutableTree(remoteDB, tree.Hash(), 0)
+	require.NoError(t, err)
+	remote.Iterate(func(key, value []byte) bool { return false })
+	fetched := client.fetched
+	require.Greater(t, fetched, 16)
+	require.Equal(t, "16", remoteDB.Stats()["remote.cached_nodes"])
+
+	cached := [][]byte{}
+	itr, err := cache.Iterator(nil, nil)
+	require.NoError(t, err)
+	for ; itr.Valid(); itr.Next() {
+		cached = append(cached, itr.Key())
+	}
+	require.NoError(t, itr.Close())
+	require.Len(t, cached, 16)
+
+	// Cached nodes are not fetched again.
+	for _, key := range cached {
+		value, err := remoteDB.Get(key)
+		require.NoError(t, err)
+		require.NotNil(t, value)
+	}
+	require.Equal(t, fetched, client.fetched)
+
+	// A new store picks up cached nodes, and enforces its own limit.
+	remoteDB, err = NewRemoteNodeDB(client, cache, 4)
+	require.NoError(t, err)
+	require.Equal(t, "4", remoteDB.Stats()["remote.cached_nodes"])
+}
+
+func TestRemoteNodeDB_ReadOnly(t *testing.T) {
+	remoteDB, err := NewRemoteNodeDB(&nodeClient{tree: setupExportTreeBasic(t)}, nil, 0)
+	require.NoError(t, err)
+
+	require.Error(t, remoteDB.Set([]byte("a"), []byte{1}))
+	require.Error(t, remoteDB.Delete([]byte("a")))
+	_, err = remoteDB.Iterator(nil, nil)
+	require.Error(t, err)
+	batch := remoteDB.NewBatch()
+	require.Error(t, batch.Set([]byte("a"), []byte{1}))
+	require.NoError(t, batch.Close())
+
+	value, err := remoteDB.Get(rootKeyFormat.Key(int64(1)))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	_, err = NewRemoteNodeDB(nil, nil, 0)
+	require.Error(t, err)
+	_, err = NewRemoteNodeDB(&nodeClient{}, nil, -1)
+	require.Error(t, err)
+}
+
+func TestGetEncodedNode_Pruned(t *testing.T) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	tree.Set([]byte("a"), []byte{1})
+	hash, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	tree.Set([]byte("a"), []byte{2})
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	node, err := tree.GetEncodedNode(hash)
+	require.NoError(t, err)
+	require.NotNil(t, node)
+
+	require.NoError(t, tree.DeleteVersion(1))
+	node, err = tree.GetEncodedNode(hash)
+	require.NoError(t, err)
+	require.Nil(t, node)
+}
+
+func TestRemoteNodeDB_CacheLRU(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+	client := &nodeClient{tree: tree}
+	remoteDB, err := NewRemoteNodeDB(client, db.NewMemDB(), 2)
+	require.NoError(t, err)
+
+	var keys [][]byte
+	tree.root.traverse(tree, true, func(node *Node) bool {
+		keys = append(keys, nodeKeyFormat.Key(node.hash))
+		return len(keys) == 3
+	})
+	get := func(key []byte) {
+		value, err := remoteDB.Get(key)
+		require.NoError(t, err)
+		require.NotNil(t, value)
+	}
+
+	// Reading the first node again makes the second one the least recently used.
+	get(keys[0])
+	get(keys[1])
+	get(keys[0])
+	require.Equal(t, 2, client.fetched)
+	get(keys[2])
+	require.Equal(t, 3, client.fetched)
+	get(keys[0])
+	require.Equal(t, 3, client.fetched)
+	get(keys[1])
+	require.Equal(t, 4, client.fetched)
+}
@@ -0,0 +1,137 @@
+package iavl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// KeySample is a key sampled by SampleWithProofs(), with a proof of its value and index.
+type KeySample struct {
+	Index int64
+	Key   []byte
+	Value []byte
+	Proof *RangeProof
+}
+
+// SampleIndices deterministically derives n pseudo-random indices in [0, size) from the seed and
+// root hash, such that neither the prover nor the auditor alone controls which keys are sampled.
+// Indices are sampled independently, and may repeat. The indices also depend on n, so a sample
+// cannot be passed off as a smaller one.
+func SampleIndices(rootHash []byte, seed []byte, size int64, n int) []int64 {
+	if size <= 0 {
+		return nil
+	}
+	// Reject values in the biased tail of the uint64 range, by counting up the nonce.
+	limit := math.MaxUint64 - math.MaxUint64%uint64(size)
+	indices := make([]int64, 0, n)
+	buf := make([]byte, 24)
+	binary.BigEndian.PutUint64(buf[:8], uint64(n))
+	for i := 0; i < n; i++ {
+		binary.BigEndian.PutUint64(buf[8:16], uint64(i))
+		for nonce := uint64(0); ; nonce++ {
+			binary.BigEndian.PutUint64(buf[16:], nonce)
+			h := sha256.New()
+			h.Write(rootHash)
+			h.Write(seed)
+			h.Write(buf)
+			value := binary.BigEndian.Uint64(h.Sum(nil))
+			if value < limit {
+				indices = append(indices, int64(value%uint64(size)))
+				break
+			}
+		}
+	}
+	return indices
+}
+
+// SampleWithProofs returns n pseudo-random keys of the given version with their values and proofs,
+// allowing auditors to spot-check that the full state is available. The indices are derived from
+// the seed and the root hash by SampleIndices(), and each proof binds its key to its index. The
+// samples can be checked with VerifySamples().
+func (tree *MutableTree) SampleWithProofs(version int64, seed []byte, n int) ([]KeySample, error) {
+	if !tree.VersionExists(version) {
+		return nil, errors.Wrapf(ErrVersionDoesNotExist, "version %v", version)
+	}
+	t, err := tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return t.SampleWithProofs(seed, n)
+}
+
+// SampleWithProofs returns n pseudo-random keys of the tree with their values and proofs. See
+// MutableTree.SampleWithProofs().
+func (t *ImmutableTree) SampleWithProofs(seed []byte, n int) ([]KeySample, error) {
+	if n < 0 {
+		return nil, errors.Errorf("sample size cannot be negative, got %v", n)
+	}
+	if n == 0 {
+		return []KeySample{}, nil
+	}
+	if t.root == nil {
+		return nil, errors.New("cannot sample an empty tree")
+	}
+
+	samples := make([]KeySample, 0, n)
+	for _, index := range SampleIndices(t.Hash(), seed, t.Size(), n) {
+		key, value := t.GetByIndex(index)
+		if key == nil {
+			return nil, errors.Errorf("no key found at index %v", index)
+		}
+		proofValue, proof, err := t.GetWithProof(key)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(proofValue, value) {
+			return nil, errors.Errorf("inconsistent value for key %X at index %v", key, index)
+		}
+		samples = append(samples, KeySample{Index: index, Key: key, Value: value, Proof: proof})
+	}
+	return samples, nil
+}
+
+// VerifySamples verifies samples returned by SampleWithProofs() for the given root hash, seed and
+// sample size. It recomputes the sampled indices using the tree size proven by the proofs, and
+// checks that each sample is proven to have the expected index, key and value.
+func VerifySamples(rootHash []byte, seed []byte, n int, samples []KeySample) error {
+	if len(samples) != n {
+		return errors.Errorf("expected %v samples, got %v", n, len(samples))
+	}
+	if n == 0 {
+		return nil
+	}
+
+	var indices []int64
+	for i, sample := range samples {
+		proof := sample.Proof
+		if proof == nil {
+			return errors.Errorf("sample %v has no proof", i)
+		}
+		if len(proof.Leaves) != 1 {
+			return errors.Errorf("sample %v proof must contain a single leaf, has %v", i, len(proof.Leaves))
+		}
+		if err := proof.Verify(rootHash); err != nil {
+			return errors.Wrapf(err, "sample %v", i)
+		}
+		if err := proof.VerifyItem(sample.Key, sample.Value); err != nil {
+			return errors.Wrapf(err, "sample %v", i)
+		}
+		// The root of the proof path contains the (hashed) size of the tree.
+		size := int64(1)
+		if len(proof.LeftPath) > 0 {
+			size = proof.LeftPath[0].Size
+		}
+		if indices == nil {
+			indices = SampleIndices(rootHash, seed, size, n)
+		}
+		if sample.Index != indices[i] || proof.LeftIndex() != indices[i] {
+			return errors.Errorf("sample %v has index %v and proven index %v, expected %v",
+				i, sample.Index, proof.LeftIndex(), indices[i])
+		}
+	}
+	return nil
+}
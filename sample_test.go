@@ -0,0 +1,126 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func setupSampleTree(t *testing.T, size int) *MutableTree {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	for i := 0; i < size; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte(fmt.Sprintf("value-%d", i)))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	return tree
+}
+
+func TestSampleIndices(t *testing.T) {
+	indices := SampleIndices([]byte("root"), []byte("seed"), 10, 1000)
+	require.Len(t, indices, 1000)
+	counts := map[int64]int{}
+	for _, index := range indices {
+		require.True(t, index >= 0 && index < 10)
+		counts[index]++
+	}
+	require.Len(t, counts, 10)
+
+	require.Equal(t, indices, SampleIndices([]byte("root"), []byte("seed"), 10, 1000))
+	require.NotEqual(t, indices, SampleIndices([]byte("root"), []byte("other"), 10, 1000))
+	require.NotEqual(t, indices, SampleIndices([]byte("other"), []byte("seed"), 10, 1000))
+	require.Nil(t, SampleIndices([]byte("root"), []byte("seed"), 0, 10))
+}
+
+func TestMutableTree_SampleWithProofs(t *testing.T) {
+	tree := setupSampleTree(t, 100)
+	tree.Set([]byte("key-000"), []byte("new"))
+	_, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	oldTree, err := tree.GetImmutable(1)
+	require.NoError(t, err)
+	seed := []byte("auditor nonce")
+
+	samples, err := tree.SampleWithProofs(1, seed, 20)
+	require.NoError(t, err)
+	require.Len(t, samples, 20)
+	require.NoError(t, VerifySamples(oldTree.Hash(), seed, 20, samples))
+	for _, sample := range samples {
+		index, value := oldTree.Get(sample.Key)
+		require.Equal(t, index, sample.Index)
+		require.Equal(t, value, sample.Value)
+	}
+
+	again, err := tree.SampleWithProofs(1, seed, 20)
+	require.NoError(t, err)
+	for i, sample := range again {
+		require.Equal(t, samples[i].Index, sample.Index)
+		require.Equal(t, samples[i].Key, sample.Key)
+		require.Equal(t, samples[i].Proof.ToProto(), sample.Proof.ToProto())
+	}
+
+	// Samples only verify against their own root, seed and size.
+	require.Error(t, VerifySamples(tree.Hash(), seed, 20, samples))
+	require.Error(t, VerifySamples(oldTree.Hash(), []byte("other"), 20, samples))
+	require.Error(t, VerifySamples(oldTree.Hash(), seed, 19, samples[:19]))
+	require.Error(t, VerifySamples(oldTree.Hash(), seed, 21, samples))
+
+	_, err = tree.SampleWithProofs(3, seed, 20)
+	require.Error(t, err)
+	_, err = tree.SampleWithProofs(1, seed, -1)
+	require.Error(t, err)
+}
+
+func TestVerifySamples_Tampered(t *testing.T) {
+	tree := setupSampleTree(t, 50)
+	seed := []byte("seed")
+	hash := tree.Hash()
+
+	tamper := func(f func(samples []KeySample)) error {
+		samples, err := tree.SampleWithProofs(1, seed, 5)
+		require.NoError(t, err)
+		f(samples)
+		return VerifySamples(hash, seed, 5, samples)
+	}
+	require.NoError(t, tamper(func(samples []KeySample) {}))
+
+	// A valid proof of a different key than the one sampled.
+	require.Error(t, tamper(func(samples []KeySample) {
+		other := (samples[0].Index + 1) % 50
+		key, value := tree.GetByIndex(other)
+		_, proof, err := tree.GetWithProof(key)
+		require.NoError(t, err)
+		samples[0] = KeySample{Index: samples[0].Index, Key: key, Value: value, Proof: proof}
+	}))
+	require.Error(t, tamper(func(samples []KeySample) { samples[1].Index++ }))
+	require.Error(t, tamper(func(samples []KeySample) { samples[0], samples[4] = samples[4], samples[0] }))
+	require.Error(t, tamper(func(samples []KeySample) { samples[2].Value = []byte("forged") }))
+	require.Error(t, tamper(func(samples []KeySample) { samples[3].Proof = nil }))
+	require.Error(t, tamper(func(samples []KeySample) {
+		samples[3].Proof.LeftPath[0].Size++
+	}))
+}
+
+func TestSampleWithProofs_Small(t *testing.T) {
+	tree := setupSampleTree(t, 1)
+	samples, err := tree.SampleWithProofs(1, []byte("seed"), 3)
+	require.NoError(t, err)
+	require.NoError(t, VerifySamples(tree.Hash(), []byte("seed"), 3, samples))
+	for _, sample := range samples {
+		require.EqualValues(t, 0, sample.Index)
+	}
+
+	empty, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	_, _, err = empty.SaveVersion()
+	require.NoError(t, err)
+	_, err = empty.SampleWithProofs(1, []byte("seed"), 1)
+	require.Error(t, err)
+	samples, err = empty.SampleWithProofs(1, []byte("seed"), 0)
+	require.NoError(t, err)
+	require.NoError(t, VerifySamples(empty.Hash(), []byte("seed"), 0, samples))
+}
@@ -0,0 +1,170 @@
+package iavl
+
+import (
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// pendingVersion is a version saved into a caller-provided batch by SaveVersionInto(), which has
+// not been finalized yet.
+type pendingVersion struct {
+	version  int64
+	rootHash []byte
+	tree     *ImmutableTree // the working tree which was saved
+	batch    dbm.Batch      // the batch the version was written to, possibly mirroring to a shadow database
+}
+
+// SaveVersionInto is like SaveVersion(), but writes all node, orphan and root entries of the new
+// version into the given batch of the tree database instead of committing them itself. This allows
+// writing other data in the same batch, such that it becomes durable atomically with the version.
+// It returns the hash and number of the new version.
+//
+// The caller must write the batch, and then call FinalizeVersion(), or call DiscardVersion() if it
+// does not write the batch. Until then, the tree can be read, including the saved nodes which are
+// kept in memory, but operations which modify the working tree or save, load or delete versions
+// return an error, or panic if they cannot return one (Set, Remove and Rollback). Unlike
+// SaveVersion(), saving a version which already exists is an error. If SaveVersionInto() returns
+// an error, the batch must be discarded.
+func (tree *MutableTree) SaveVersionInto(batch dbm.Batch) ([]byte, int64, error) {
+	if batch == nil {
+		return nil, 0, errors.New("batch cannot be nil")
+	}
+	if err := tree.checkPending(); err != nil {
+		return nil, 0, err
+	}
+	if err := tree.ndb.checkRebuild(); err != nil {
+		return nil, 0, err
+	}
+	if err := tree.ndb.checkMigration(); err != nil {
+		return nil, 0, err
+	}
+	version := tree.nextVersion()
+	span := tree.ndb.startSpan(SpanSaveVersion)
+	defer span.End()
+	span.SetAttribute("version", version)
+
+	if tree.VersionExists(version) {
+		return nil, version, errors.Errorf("version %v already exists", version)
+	}
+
+	batch = tree.ndb.useBatch(batch)
+	rootHash, err := tree.writeVersion(version)
+	tree.ndb.useBatch(nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	tree.pending = &pendingVersion{version: version, rootHash: rootHash, tree: tree.ImmutableTree.clone(),
+		batch: batch}
+	return tree.WorkingHash(), version, nil
+}
+
+// FinalizeVersion finalizes the version saved by SaveVersionInto(), once the caller has written the
+// batch, making it the latest saved version. If the batch was not written, the version and the
+// working tree changes it contained are discarded, and an error is returned.
+func (tree *MutableTree) FinalizeVersion() error {
+	pending := tree.pending
+	if pending == nil {
+		return errors.New("no version saved into a batch to finalize")
+	}
+	written, err := tree.ndb.HasRoot(pending.version)
+	if err != nil {
+		return err
+	}
+	tree.pending = nil
+	if !written {
+		tree.discardPending(pending)
+		return errors.Errorf("batch with version %v was not written, discarded the version", pending.version)
+	}
+
+	if err = tree.ndb.batchWritten(pending.batch); err != nil {
+		return err
+	}
+	tree.ndb.compareShadowRoot(pending.version, pending.rootHash)
+	tree.mtx.Lock()
+	tree.ImmutableTree = pending.tree
+	tree.mtx.Unlock()
+	tree.finishVersion(pending.version)
+	return nil
+}
+
+// DiscardVersion discards the version saved by SaveVersionInto() along with the working tree
+// changes it contained, when the caller does not write the batch. The batch must not be written
+// afterwards.
+func (tree *MutableTree) DiscardVersion() error {
+	pending := tree.pending
+	if pending == nil {
+		return errors.New("no version saved into a batch to discard")
+	}
+	tree.pending = nil
+	tree.discardPending(pending)
+	return nil
+}
+
+// discardPending discards a pending version whose batch was not written.
+func (tree *MutableTree) discardPending(pending *pendingVersion) {
+	tree.ndb.batchDiscarded(pending.batch)
+	tree.ndb.resetLatestVersion(tree.version)
+	tree.Rollback()
+}
+
+// checkPending returns an error if a version saved by SaveVersionInto() has not been finalized,
+// since the working tree and the latest saved version must not change until it is.
+func (tree *MutableTree) checkPending() error {
+	if tree.pending != nil {
+		return errors.Errorf("version %v saved into a batch must be finalized first", tree.pending.version)
+	}
+	return nil
+}
+
+// useBatch replaces the node database batch with a batch provided by the caller, returning it
+// wrapped for shadow writes if needed, or restores the node database batch if nil. The caller is
+// responsible for writing its batch, see batchWritten().
+func (ndb *nodeDB) useBatch(batch dbm.Batch) dbm.Batch {
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+
+	if batch == nil {
+		ndb.batch, ndb.ownBatch = ndb.ownBatch, nil
+		return ndb.batch
+	}
+	if ndb.shadow != nil {
+		batch = ndb.shadow.newBatch(batch)
+	}
+	ndb.batch, ndb.ownBatch = batch, ndb.batch
+	return batch
+}
+
+// batchWritten completes the write of a caller-provided batch, once the caller has written it.
+func (ndb *nodeDB) batchWritten(batch dbm.Batch) error {
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+
+	ndb.pinned = map[string]*Node{}
+	if b, ok := batch.(*shadowBatch); ok {
+		if ndb.opts.Sync {
+			b.written(b.secondary.WriteSync())
+		} else {
+			b.written(b.secondary.Write())
+		}
+		b.secondary.Close()
+	}
+	if ndb.cold != nil {
+		return ndb.cold.committed()
+	}
+	return nil
+}
+
+// batchDiscarded discards a caller-provided batch which the caller did not write, along with the
+// nodes saved into it.
+func (ndb *nodeDB) batchDiscarded(batch dbm.Batch) {
+	ndb.mtx.Lock()
+	defer ndb.mtx.Unlock()
+
+	if b, ok := batch.(*shadowBatch); ok {
+		b.secondary.Close()
+	}
+	for hash := range ndb.pinned {
+		ndb.uncacheNode([]byte(hash))
+	}
+	ndb.pinned = map[string]*Node{}
+}
@@ -0,0 +1,232 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func TestSaveVersionInto(t *testing.T) {
+	plainDB, memDB, shadowDB := db.NewMemDB(), db.NewMemDB(), db.NewMemDB()
+	plain, err := NewMutableTree(plainDB, 0)
+	require.NoError(t, err)
+	tree, divergences := newShadowTree(t, memDB, shadowDB)
+
+	for v := 1; v <= 3; v++ {
+		for i := 0; i < 50; i++ {
+			key := []byte(fmt.Sprintf("key-%02d", (i*v)%60))
+			value := []byte(fmt.Sprintf("value-%v-%v", v, i))
+			plain.Set(key, value)
+			tree.Set(key, value)
+		}
+		tree.Remove([]byte("key-00"))
+		plain.Remove([]byte("key-00"))
+		plainHash, plainVersion, err := plain.SaveVersion()
+		require.NoError(t, err)
+
+		before := dumpDB(t, memDB)
+		batch := memDB.NewBatch()
+		require.NoError(t, batch.Set([]byte(fmt.Sprintf("meta/%v", v)), []byte("metadata")))
+		hash, version, err := tree.SaveVersionInto(batch)
+		require.NoError(t, err)
+		require.Equal(t, plainHash, hash)
+		require.Equal(t, plainVersion, version)
+
+		// Nothing is written until the caller writes the batch.
+		require.Equal(t, before, dumpDB(t, memDB))
+		_, _, err = tree.SaveVersion()
+		require.Error(t, err)
+		require.NoError(t, batch.Write())
+		require.NoError(t, batch.Close())
+		require.NoError(t, tree.FinalizeVersion())
+		require.Error(t, tree.FinalizeVersion())
+
+		require.Equal(t, plainHash, tree.Hash())
+		require.Equal(t, plainVersion, tree.Version())
+	}
+
+	entries := dumpDB(t, memDB)
+	for v := 1; v <= 3; v++ {
+		require.Equal(t, "metadata", entries[fmt.Sprintf("meta/%v", v)])
+		delete(entries, fmt.Sprintf("meta/%v", v))
+	}
+	require.Equal(t, dumpDB(t, plainDB), entries)
+	require.Equal(t, dumpDB(t, plainDB), dumpDB(t, shadowDB))
+	require.Empty(t, *divergences)
+	require.EqualValues(t, 3, tree.ShadowStats().ComparedRoots)
+
+	reloaded, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	version, err := reloaded.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, version)
+	require.Equal(t, plain.Hash(), reloaded.Hash())
+}
+
+func TestSaveVersionInto_Discarded(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, _, err = tree.SaveVersionInto(nil)
+	require.Error(t, err)
+	require.Error(t, tree.FinalizeVersion())
+
+	tree.Set([]byte("a"), []byte("1"))
+	batch := memDB.NewBatch()
+	_, _, err = tree.SaveVersionInto(batch)
+	require.NoError(t, err)
+	require.Empty(t, dumpDB(t, memDB))
+	require.NoError(t, batch.Close())
+
+	// The version is discarded along with its changes, if the batch was not written.
+	require.Error(t, tree.FinalizeVersion())
+	require.Zero(t, tree.Version())
+	require.Zero(t, tree.Size())
+	require.Empty(t, dumpDB(t, memDB))
+
+	tree.Set([]byte("b"), []byte("2"))
+	_, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, version)
+	reloaded, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	_, err = reloaded.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, reloaded.Size())
+	_, value := reloaded.Get([]byte("b"))
+	require.Equal(t, []byte("2"), value)
+}
+
+func TestSaveVersionInto_PendingBlocksMutators(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTree(memDB, 0)
+	require.NoError(t, err)
+	for v := 0; v < 3; v++ {
+		tree.Set([]byte(fmt.Sprintf("a/%v", v)), []byte{1})
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	tree.Set([]byte("b"), []byte{2})
+	batch := memDB.NewBatch()
+	defer batch.Close()
+	_, _, err = tree.SaveVersionInto(batch)
+	require.NoError(t, err)
+
+	_, err = tree.Rebuild()
+	require.Error(t, err)
+	_, _, err = tree.Migrate(MigrationOptions{})
+	require.Error(t, err)
+	_, err = tree.MovePrefix([]byte("a/"), []byte("c/"))
+	require.Error(t, err)
+	require.Error(t, tree.DeleteVersion(1))
+	require.Error(t, tree.DeleteVersions(1))
+	require.Error(t, tree.DeleteVersionsRange(1, 2))
+	_, err = tree.LoadVersionForOverwriting(2)
+	require.Error(t, err)
+	_, err = tree.SoftLoadVersionForOverwriting(2)
+	require.Error(t, err)
+	_, err = tree.LoadVersion(2)
+	require.Error(t, err)
+	_, err = tree.LazyLoadVersion(2)
+	require.Error(t, err)
+	_, err = tree.Import(4)
+	require.Error(t, err)
+	require.Error(t, tree.PurgeTrash())
+	require.Panics(t, func() { tree.Set([]byte("c"), []byte{3}) })
+	require.Panics(t, func() { tree.Remove([]byte("b")) })
+	require.Panics(t, func() { tree.Rollback() })
+	require.True(t, tree.VersionExists(1))
+	require.True(t, tree.Has([]byte("b")))
+
+	// Discarding the version, e.g. because another store failed, allows further operations.
+	require.NoError(t, tree.DiscardVersion())
+	require.Error(t, tree.DiscardVersion())
+	require.EqualValues(t, 3, tree.Version())
+	require.False(t, tree.Has([]byte("b")))
+	require.NoError(t, tree.DeleteVersion(1))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+}
+
+func TestSaveVersionInto_PendingReads(t *testing.T) {
+	for _, levels := range []int{0, 3} {
+		levels := levels
+		t.Run(fmt.Sprintf("pages %v", levels), func(t *testing.T) {
+			memDB := db.NewMemDB()
+			tree, err := NewMutableTreeWithOpts(memDB, 0, &Options{NodePageLevels: levels})
+			require.NoError(t, err)
+			sync := NewSyncMutableTree(tree)
+			for i := 0; i < 100; i++ {
+				tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte{1})
+			}
+			_, _, err = tree.SaveVersion()
+			require.NoError(t, err)
+			for i := 0; i < 100; i += 3 {
+				tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte{2})
+			}
+
+			batch := memDB.NewBatch()
+			defer batch.Close()
+			hash, version, err := tree.SaveVersionInto(batch)
+			require.NoError(t, err)
+
+			// Without a node cache, the saved nodes are still readable before the batch is written.
+			for i := 0; i < 100; i++ {
+				key := []byte(fmt.Sprintf("key-%03d", i))
+				expected := []byte{1}
+				if i%3 == 0 {
+					expected = []byte{2}
+				}
+				_, value := sync.Get(key)
+				require.Equal(t, expected, value, "key %s", key)
+			}
+			require.Equal(t, hash, sync.WorkingHash())
+			_, proof, err := tree.GetWithProof([]byte("key-042"))
+			require.NoError(t, err)
+			require.NoError(t, proof.Verify(hash))
+			require.Panics(t, func() { sync.Set([]byte("key-000"), []byte{3}) })
+
+			// Once written and finalized, the nodes are read from the database.
+			require.NoError(t, batch.Write())
+			require.NoError(t, tree.FinalizeVersion())
+			require.Empty(t, tree.ndb.pinned)
+			require.Equal(t, version, sync.Version())
+			require.Equal(t, hash, sync.Hash())
+			sync.Set([]byte("key-000"), []byte{3})
+			_, _, err = sync.SaveVersion()
+			require.NoError(t, err)
+
+			reloaded, err := NewMutableTreeWithOpts(memDB, 0, &Options{NodePageLevels: levels})
+			require.NoError(t, err)
+			_, err = reloaded.LoadVersion(version)
+			require.NoError(t, err)
+			require.Equal(t, hash, reloaded.Hash())
+		})
+	}
+}
+
+func TestSaveVersionInto_DiscardUnpins(t *testing.T) {
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTree(memDB, 10)
+	require.NoError(t, err)
+	tree.Set([]byte("a"), []byte{1})
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	tree.Set([]byte("b"), []byte{2})
+	batch := memDB.NewBatch()
+	defer batch.Close()
+	_, _, err = tree.SaveVersionInto(batch)
+	require.NoError(t, err)
+	require.NotEmpty(t, tree.ndb.pinned)
+
+	// The discarded nodes are neither pinned nor cached.
+	pinned := tree.ndb.pinned
+	require.NoError(t, tree.DiscardVersion())
+	require.Empty(t, tree.ndb.pinned)
+	for hash := range pinned {
+		require.NotContains(t, tree.ndb.nodeCache, hash)
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	lrulist "container/list"
+	"fmt"
+	"sync"
+
+	pb "github.com/cosmos/iavl/proto"
+	"github.com/pkg/errors"
+)
+
+// DefaultProofCacheSize is the default number of proofs cached by the server.
+const DefaultProofCacheSize = 10000
+
+// ProofCacheStats contains proof cache metrics.
+type ProofCacheStats struct {
+	Hits      uint64 // requests served from the cache
+	Misses    uint64 // requests which generated a proof
+	Coalesced uint64 // requests which waited for a concurrent request for the same proof
+	Evictions uint64 // proofs evicted to keep the cache bounded
+	Entries   int    // proofs currently cached
+}
+
+// HitRate returns the fraction of requests which did not generate a proof.
+func (s ProofCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses + s.Coalesced
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Coalesced) / float64(total)
+}
+
+// String implements fmt.Stringer.
+func (s ProofCacheStats) String() string {
+	return fmt.Sprintf("hits=%v misses=%v coalesced=%v evictions=%v entries=%v hit_rate=%.3f",
+		s.Hits, s.Misses, s.Coalesced, s.Evictions, s.Entries, s.HitRate())
+}
+
+// maxCachedRangeItems is the maximum number of streamed responses of a cached range proof. Larger
+// ranges are streamed without caching them.
+const maxCachedRangeItems = 1000
+
+// errRangeNotCached is returned when generating a range proof with too many items to cache.
+var errRangeNotCached = errors.New("range proof is too large to cache")
+
+// proofCacheKey identifies a proof by version and key range. Key proofs use the key as both start
+// and end, range proofs use rangeBound() of the half-open range [start, end).
+type proofCacheKey struct {
+	version    int64
+	start      string
+	end        string
+	rangeProof bool
+}
+
+// rangeBound encodes a range bound for a proofCacheKey, distinguishing an open (nil) bound from
+// an empty key.
+func rangeBound(key []byte) string {
+	if key == nil {
+		return ""
+	}
+	return "\x01" + string(key)
+}
+
+// proofCacheEntry is a cached proof, either a *pb.GetWithProofResponse or a []*pb.ListResponse.
+type proofCacheEntry struct {
+	key proofCacheKey
+	res interface{}
+}
+
+// proofCall is an in-flight proof generation, which concurrent requests for the same proof wait
+// for.
+type proofCall struct {
+	done chan struct{}
+	res  interface{}
+	err  error
+}
+
+// proofCache is a bounded LRU cache of proofs at saved versions, which never change until the
+// version is deleted. Concurrent requests for the same uncached proof are coalesced, such that
+// the proof is only generated once. Cached responses are shared, and must not be modified.
+type proofCache struct {
+	mtx     sync.Mutex
+	size    int
+	lru     *lrulist.List // of *proofCacheEntry, most recently used first
+	entries map[proofCacheKey]*lrulist.Element
+	calls   map[proofCacheKey]*proofCall
+	stats   ProofCacheStats
+}
+
+// newProofCache creates a proof cache holding at most size proofs. A size of 0 disables caching,
+// but still coalesces concurrent requests.
+func newProofCache(size int) *proofCache {
+	return &proofCache{
+		size:    size,
+		lru:     lrulist.New(),
+		entries: map[proofCacheKey]*lrulist.Element{},
+		calls:   map[proofCacheKey]*proofCall{},
+	}
+}
+
+// get returns the cached key proof for the key, or generates it with the given function. Errors
+// are not cached.
+func (c *proofCache) get(key proofCacheKey, generate func() (*pb.GetWithProofResponse, error)) (
+	*pb.GetWithProofResponse, error) {
+
+	res, err := c.load(key, func() (interface{}, error) { return generate() })
+	if err != nil {
+		return nil, err
+	}
+	return res.(*pb.GetWithProofResponse), nil
+}
+
+// getRange returns the cached range proof responses for the key, or generates them with the given
+// function. Errors are not cached, and generate should return errRangeNotCached for ranges of more
+// than maxCachedRangeItems responses.
+func (c *proofCache) getRange(key proofCacheKey, generate func() ([]*pb.ListResponse, error)) (
+	[]*pb.ListResponse, error) {
+
+	res, err := c.load(key, func() (interface{}, error) { return generate() })
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*pb.ListResponse), nil
+}
+
+// load returns the cached proof for the key, or generates it with the given function.
+func (c *proofCache) load(key proofCacheKey, generate func() (interface{}, error)) (interface{}, error) {
+	c.mtx.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.stats.Hits++
+		c.mtx.Unlock()
+		return elem.Value.(*proofCacheEntry).res, nil
+	}
+	if call, ok := c.calls[key]; ok {
+		c.stats.Coalesced++
+		c.mtx.Unlock()
+		<-call.done
+		return call.res, call.err
+	}
+	c.stats.Misses++
+	call := &proofCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mtx.Unlock()
+
+	// Make sure waiters are released even if proof generation panics.
+	call.err = errors.New("proof generation failed")
+	defer func() {
+		c.mtx.Lock()
+		delete(c.calls, key)
+		if call.err == nil {
+			c.add(key, call.res)
+		}
+		c.mtx.Unlock()
+		close(call.done)
+	}()
+	call.res, call.err = generate()
+	return call.res, call.err
+}
+
+// add adds a proof to the cache, evicting the least recently used proofs if necessary. The caller
+// must hold the mutex.
+func (c *proofCache) add(key proofCacheKey, res interface{}) {
+	if c.size <= 0 {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&proofCacheEntry{key: key, res: res})
+	for c.lru.Len() > c.size {
+		elem := c.lru.Back()
+		c.lru.Remove(elem)
+		delete(c.entries, elem.Value.(*proofCacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// invalidate removes all proofs for versions at or above fromVersion and at or below toVersion.
+func (c *proofCache) invalidate(fromVersion, toVersion int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		key := elem.Value.(*proofCacheEntry).key
+		if key.version >= fromVersion && key.version <= toVersion {
+			c.lru.Remove(elem)
+			delete(c.entries, key)
+		}
+		elem = next
+	}
+}
+
+// Stats returns the cache metrics.
+func (c *proofCache) Stats() ProofCacheStats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
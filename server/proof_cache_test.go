@@ -0,0 +1,162 @@
+package server
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/cosmos/iavl/proto"
+)
+
+func TestProofCache_Coalescing(t *testing.T) {
+	const requests = 10
+	cache := newProofCache(10)
+	key := proofCacheKey{version: 1, start: "a", end: "a"}
+
+	var (
+		generated int32
+		wg        sync.WaitGroup
+		started   = make(chan struct{})
+		release   = make(chan struct{})
+		results   = make(chan *pb.GetWithProofResponse, requests)
+	)
+	generate := func() (*pb.GetWithProofResponse, error) {
+		atomic.AddInt32(&generated, 1)
+		close(started)
+		<-release
+		return &pb.GetWithProofResponse{Value: []byte{1}}, nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		res, err := cache.get(key, generate)
+		require.NoError(t, err)
+		results <- res
+	}()
+	<-started
+	for i := 1; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := cache.get(key, generate)
+			require.NoError(t, err)
+			results <- res
+		}()
+	}
+	require.Eventually(t, func() bool { return cache.Stats().Coalesced == requests-1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	require.EqualValues(t, 1, generated)
+	for res := range results {
+		require.Equal(t, []byte{1}, res.Value)
+	}
+	stats := cache.Stats()
+	require.EqualValues(t, 1, stats.Misses)
+	require.EqualValues(t, 0, stats.Hits)
+	require.Equal(t, 1, stats.Entries)
+
+	res, err := cache.get(key, generate)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, res.Value)
+	require.EqualValues(t, 1, generated)
+	require.EqualValues(t, 1, cache.Stats().Hits)
+	require.InDelta(t, 10.0/11.0, cache.Stats().HitRate(), 0.001)
+}
+
+func TestProofCache_Errors(t *testing.T) {
+	cache := newProofCache(10)
+	key := proofCacheKey{version: 1}
+
+	// Errors are not cached.
+	_, err := cache.get(key, func() (*pb.GetWithProofResponse, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+	require.Equal(t, 0, cache.Stats().Entries)
+
+	// Panics are propagated, and do not leave in-flight calls behind.
+	require.Panics(t, func() {
+		_, _ = cache.get(key, func() (*pb.GetWithProofResponse, error) { panic("boom") })
+	})
+	res, err := cache.get(key, func() (*pb.GetWithProofResponse, error) {
+		return &pb.GetWithProofResponse{}, nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 1, cache.Stats().Entries)
+}
+
+func TestProofCache_EvictionAndInvalidation(t *testing.T) {
+	cache := newProofCache(3)
+	get := func(version int64, key string) {
+		_, err := cache.get(proofCacheKey{version: version, start: key, end: key},
+			func() (*pb.GetWithProofResponse, error) { return &pb.GetWithProofResponse{}, nil })
+		require.NoError(t, err)
+	}
+	get(1, "a")
+	get(1, "b")
+	get(2, "a")
+	get(1, "a") // most recently used
+	get(3, "a") // evicts 1/b
+	stats := cache.Stats()
+	require.Equal(t, 3, stats.Entries)
+	require.EqualValues(t, 1, stats.Evictions)
+	require.EqualValues(t, 1, stats.Hits)
+
+	get(1, "a")
+	require.EqualValues(t, 2, cache.Stats().Hits)
+
+	cache.invalidate(2, 3)
+	require.Equal(t, 1, cache.Stats().Entries)
+	get(1, "a")
+	require.EqualValues(t, 3, cache.Stats().Hits)
+
+	// A disabled cache caches nothing.
+	cache = newProofCache(0)
+	get(1, "a")
+	get(1, "a")
+	require.EqualValues(t, 2, cache.Stats().Misses)
+	require.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestProofCache_Ranges(t *testing.T) {
+	cache := newProofCache(10)
+	generate := func() ([]*pb.ListResponse, error) {
+		return []*pb.ListResponse{{Key: []byte("a")}}, nil
+	}
+
+	// Key proofs, open ranges and ranges ending at the empty key are cached separately.
+	keys := []proofCacheKey{
+		{version: 1, start: "a", end: "a"},
+		{version: 1, start: rangeBound([]byte("a")), end: rangeBound(nil), rangeProof: true},
+		{version: 1, start: rangeBound([]byte("a")), end: rangeBound([]byte{}), rangeProof: true},
+	}
+	_, err := cache.get(keys[0], func() (*pb.GetWithProofResponse, error) { return &pb.GetWithProofResponse{}, nil })
+	require.NoError(t, err)
+	for _, key := range keys[1:] {
+		res, err := cache.getRange(key, generate)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+	}
+	require.EqualValues(t, 3, cache.Stats().Misses)
+	res, err := cache.getRange(keys[1], generate)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.EqualValues(t, 1, cache.Stats().Hits)
+
+	// Ranges too large to cache are not cached.
+	key := proofCacheKey{version: 2, rangeProof: true}
+	_, err = cache.getRange(key, func() ([]*pb.ListResponse, error) { return nil, errRangeNotCached })
+	require.Equal(t, errRangeNotCached, err)
+	require.Equal(t, 3, cache.Stats().Entries)
+
+	cache.invalidate(1, 1)
+	require.Equal(t, 0, cache.Stats().Entries)
+}
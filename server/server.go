@@ -1,7 +1,9 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"math"
 	"sync"
 
 	"google.golang.org/grpc/codes"
@@ -16,6 +18,9 @@ import (
 
 var _ pb.IAVLServiceServer = (*IAVLServer)(nil)
 
+// maxSamples is the maximum number of keys returned by SampleWithProofs.
+const maxSamples = 10000
+
 // IAVLServer implements the gRPC IAVLServiceServer interface. It provides a gRPC
 // API over an IAVL tree.
 // rwLock is used to ensure:
@@ -23,12 +28,19 @@ var _ pb.IAVLServiceServer = (*IAVLServer)(nil)
 // 2. Unlimited concurrent reads.
 // 3. Sequential writes.
 type IAVLServer struct {
-	rwLock sync.RWMutex
-	tree   *iavl.MutableTree
+	rwLock     sync.RWMutex
+	tree       *iavl.MutableTree
+	proofCache *proofCache
 }
 
-// New creates an IAVLServer.
+// New creates an IAVLServer, with a proof cache of DefaultProofCacheSize.
 func New(db dbm.DB, cacheSize, version int64) (*IAVLServer, error) {
+	return NewWithProofCache(db, cacheSize, version, DefaultProofCacheSize)
+}
+
+// NewWithProofCache creates an IAVLServer, caching up to proofCacheSize proofs at saved versions.
+// A proofCacheSize of 0 disables the proof cache.
+func NewWithProofCache(db dbm.DB, cacheSize, version, proofCacheSize int64) (*IAVLServer, error) {
 	tree, err := iavl.NewMutableTree(db, int(cacheSize))
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create iavl tree")
@@ -38,7 +50,7 @@ func New(db dbm.DB, cacheSize, version int64) (*IAVLServer, error) {
 		return nil, errors.Wrapf(err, "unable to load version %d", version)
 	}
 
-	return &IAVLServer{tree: tree}, nil
+	return &IAVLServer{tree: tree, proofCache: newProofCache(int(proofCacheSize))}, nil
 }
 
 // HasVersioned returns a result containing a boolean on whether or not the IAVL tree
@@ -100,6 +112,27 @@ func (s *IAVLServer) GetByIndex(_ context.Context, req *pb.GetByIndexRequest) (*
 
 }
 
+// GetByIndexVersioned returns a result containing the key and value for a given
+// index at a specific tree version.
+func (s *IAVLServer) GetByIndexVersioned(_ context.Context, req *pb.GetByIndexVersionedRequest) (*pb.GetByIndexResponse, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	iTree, err := s.getImmutable(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	key, value := iTree.GetByIndex(req.Index)
+	if key == nil {
+		e := status.New(codes.NotFound, "the index requested does not exist")
+		return nil, e.Err()
+	}
+
+	return &pb.GetByIndexResponse{Key: key, Value: value}, nil
+}
+
 // GetWithProof returns a result containing the IAVL tree version and value for
 // a given key based on the current state (version) of the tree including a
 // verifiable Merkle proof.
@@ -146,25 +179,82 @@ func (s *IAVLServer) GetVersioned(_ context.Context, req *pb.GetVersionedRequest
 
 // GetVersionedWithProof returns a result containing the IAVL tree version and
 // value for a given key at a specific tree version including a verifiable Merkle
-// proof.
+// proof. Proofs are served from the proof cache when possible.
 func (s *IAVLServer) GetVersionedWithProof(_ context.Context, req *pb.GetVersionedRequest) (*pb.GetWithProofResponse, error) {
 
 	s.rwLock.RLock()
 	defer s.rwLock.RUnlock()
 
-	value, proof, err := s.tree.GetVersionedWithProof(req.Key, req.Version)
+	key := proofCacheKey{version: req.Version, start: string(req.Key), end: string(req.Key)}
+	res, err := s.proofCache.get(key, func() (*pb.GetWithProofResponse, error) {
+		value, proof, err := s.tree.GetVersionedWithProof(req.Key, req.Version)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.GetWithProofResponse{Value: value, Proof: proof.ToProto()}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if value == nil {
+	if res.Value == nil {
 		s := status.New(codes.NotFound, "the key requested does not exist")
 		return nil, s.Err()
 	}
 
-	proofPb := proof.ToProto()
+	return res, nil
+}
 
-	return &pb.GetWithProofResponse{Value: value, Proof: proofPb}, nil
+// GetWithValueHashProof returns a result containing the SHA256 hash of the
+// value for a given key based on the current state (version) of the tree
+// including a verifiable Merkle proof, without disclosing the value.
+func (s *IAVLServer) GetWithValueHashProof(_ context.Context, req *pb.GetRequest) (*pb.GetWithValueHashProofResponse, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	return getWithValueHashProof(s.tree.ImmutableTree, req.Key)
+}
+
+// GetVersionedWithValueHashProof returns a result containing the SHA256 hash
+// of the value for a given key at a specific tree version including a
+// verifiable Merkle proof, without disclosing the value.
+func (s *IAVLServer) GetVersionedWithValueHashProof(_ context.Context, req *pb.GetVersionedRequest) (*pb.GetWithValueHashProofResponse, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	iTree, err := s.getImmutable(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return getWithValueHashProof(iTree, req.Key)
+}
+
+// getWithValueHashProof returns the value hash of a key in the tree, with both
+// a range proof and an ICS23 proof.
+func getWithValueHashProof(tree *iavl.ImmutableTree, key []byte) (*pb.GetWithValueHashProofResponse, error) {
+	valueHash, proof, err := tree.GetWithValueHashProof(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if valueHash == nil {
+		s := status.New(codes.NotFound, "the key requested does not exist")
+		return nil, s.Err()
+	}
+
+	ics23Proof, err := tree.GetValueHashMembershipProof(key)
+	if err != nil {
+		return nil, err
+	}
+	ics23ProofBytes, err := ics23Proof.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetWithValueHashProofResponse{ValueHash: valueHash, Proof: proof.ToProto(), Ics23Proof: ics23ProofBytes}, nil
 }
 
 // Set returns a result after inserting a key/value pair into the IAVL tree
@@ -228,6 +318,7 @@ func (s *IAVLServer) DeleteVersion(_ context.Context, req *pb.DeleteVersionReque
 	if err := s.tree.DeleteVersion(req.Version); err != nil {
 		return nil, err
 	}
+	s.proofCache.invalidate(req.Version, req.Version)
 
 	return &pb.DeleteVersionResponse{RootHash: iTree.Hash(), Version: req.Version}, nil
 }
@@ -246,6 +337,20 @@ func (s *IAVLServer) Hash(_ context.Context, _ *empty.Empty) (*pb.HashResponse,
 	return &pb.HashResponse{RootHash: s.tree.Hash()}, nil
 }
 
+// HashVersioned returns the IAVL tree root hash at a specific tree version.
+func (s *IAVLServer) HashVersioned(_ context.Context, req *pb.HashVersionedRequest) (*pb.HashResponse, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	iTree, err := s.getImmutable(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.HashResponse{RootHash: iTree.Hash()}, nil
+}
+
 // VersionExists returns a result containing a boolean on whether or not a given
 // version exists in the IAVL tree.
 func (s *IAVLServer) VersionExists(_ context.Context, req *pb.VersionExistsRequest) (*pb.VersionExistsResponse, error) {
@@ -272,6 +377,18 @@ func (*IAVLServer) Verify(ctx context.Context, req *pb.VerifyRequest) (*empty.Em
 	return &empty.Empty{}, nil
 }
 
+// VerifyVersioned verifies an IAVL range proof against the root hash of a specific
+// tree version, returning an error if the proof is invalid.
+func (s *IAVLServer) VerifyVersioned(ctx context.Context, req *pb.VerifyVersionedRequest) (*empty.Empty, error) {
+
+	rootHash, err := s.versionHash(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Verify(ctx, &pb.VerifyRequest{RootHash: rootHash, Proof: req.Proof})
+}
+
 // VerifyItem verifies if a given key/value pair in an IAVL range proof returning
 // an error if the proof or key is invalid.
 func (*IAVLServer) VerifyItem(ctx context.Context, req *pb.VerifyItemRequest) (*empty.Empty, error) {
@@ -293,6 +410,53 @@ func (*IAVLServer) VerifyItem(ctx context.Context, req *pb.VerifyItemRequest) (*
 	return &empty.Empty{}, nil
 }
 
+// VerifyItemVersioned verifies if a given key/value pair in an IAVL range proof
+// against the root hash of a specific tree version, returning an error if the
+// proof or key is invalid.
+func (s *IAVLServer) VerifyItemVersioned(ctx context.Context, req *pb.VerifyItemVersionedRequest) (*empty.Empty, error) {
+
+	rootHash, err := s.versionHash(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.VerifyItem(ctx, &pb.VerifyItemRequest{RootHash: rootHash, Proof: req.Proof, Key: req.Key, Value: req.Value})
+}
+
+// VerifyItemValueHash verifies if a given key and value hash pair in an IAVL
+// range proof returning an error if the proof or key is invalid.
+func (*IAVLServer) VerifyItemValueHash(ctx context.Context, req *pb.VerifyItemValueHashRequest) (*empty.Empty, error) {
+
+	proof, err := iavl.RangeProofFromProto(req.Proof)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := proof.Verify(req.RootHash); err != nil {
+		return nil, err
+	}
+
+	if err := proof.VerifyItemValueHash(req.Key, req.ValueHash); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// VerifyItemValueHashVersioned verifies if a given key and value hash pair in
+// an IAVL range proof against the root hash of a specific tree version,
+// returning an error if the proof or key is invalid.
+func (s *IAVLServer) VerifyItemValueHashVersioned(ctx context.Context, req *pb.VerifyItemValueHashVersionedRequest) (*empty.Empty, error) {
+
+	rootHash, err := s.versionHash(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.VerifyItemValueHash(ctx, &pb.VerifyItemValueHashRequest{RootHash: rootHash, Proof: req.Proof, Key: req.Key, ValueHash: req.ValueHash})
+}
+
 // VerifyAbsence verifies the absence of a given key in an IAVL range proof
 // returning an error if the proof or key is invalid.
 func (*IAVLServer) VerifyAbsence(ctx context.Context, req *pb.VerifyAbsenceRequest) (*empty.Empty, error) {
@@ -314,6 +478,19 @@ func (*IAVLServer) VerifyAbsence(ctx context.Context, req *pb.VerifyAbsenceReque
 	return &empty.Empty{}, nil
 }
 
+// VerifyAbsenceVersioned verifies the absence of a given key in an IAVL range proof
+// against the root hash of a specific tree version, returning an error if the
+// proof or key is invalid.
+func (s *IAVLServer) VerifyAbsenceVersioned(ctx context.Context, req *pb.VerifyAbsenceVersionedRequest) (*empty.Empty, error) {
+
+	rootHash, err := s.versionHash(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.VerifyAbsence(ctx, &pb.VerifyAbsenceRequest{RootHash: rootHash, Proof: req.Proof, Key: req.Key})
+}
+
 // Rollback resets the working tree to the latest saved version, discarding
 // any unsaved modifications.
 func (s *IAVLServer) Rollback(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
@@ -369,6 +546,8 @@ func (s *IAVLServer) LoadVersionForOverwriting(ctx context.Context, req *pb.Load
 	defer s.rwLock.Unlock()
 
 	_, err := s.tree.LoadVersionForOverwriting(req.Version)
+	// Later versions are deleted, and may be saved again with different contents.
+	s.proofCache.invalidate(req.Version+1, math.MaxInt64)
 
 	return &empty.Empty{}, err
 
@@ -383,20 +562,200 @@ func (s *IAVLServer) Size(ctx context.Context, req *empty.Empty) (*pb.SizeRespon
 
 }
 
+// SizeVersioned returns the number of leaves in the tree at a specific tree version.
+func (s *IAVLServer) SizeVersioned(ctx context.Context, req *pb.SizeVersionedRequest) (*pb.SizeResponse, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	iTree, err := s.getImmutable(req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SizeResponse{Size_: iTree.Size()}, nil
+}
+
 func (s *IAVLServer) List(req *pb.ListRequest, stream pb.IAVLService_ListServer) error {
 
 	s.rwLock.RLock()
 	defer s.rwLock.RUnlock()
 
+	return list(s.tree.ImmutableTree, req.FromKey, req.ToKey, req.Descending, req.IncludeVersions,
+		req.IncludeProof, stream.Send)
+}
+
+// ListVersioned lists the key/value pairs in a range at a specific tree version. Range proofs of
+// up to maxCachedRangeItems responses are served from the proof cache when possible.
+func (s *IAVLServer) ListVersioned(req *pb.ListVersionedRequest, stream pb.IAVLService_ListVersionedServer) error {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	iTree, err := s.getImmutable(req.Version)
+	if err != nil {
+		return err
+	}
+
+	if !req.IncludeProof || req.Descending {
+		return list(iTree, req.FromKey, req.ToKey, req.Descending, req.IncludeVersions, req.IncludeProof,
+			stream.Send)
+	}
+
+	key := proofCacheKey{version: req.Version, start: rangeBound(req.FromKey), end: rangeBound(req.ToKey),
+		rangeProof: true}
+	responses, err := s.proofCache.getRange(key, func() ([]*pb.ListResponse, error) {
+		var responses []*pb.ListResponse
+		err := list(iTree, req.FromKey, req.ToKey, false, true, true, func(res *pb.ListResponse) error {
+			if len(responses) == maxCachedRangeItems {
+				return errRangeNotCached
+			}
+			responses = append(responses, res)
+			return nil
+		})
+		return responses, err
+	})
+	if err == errRangeNotCached {
+		return list(iTree, req.FromKey, req.ToKey, false, req.IncludeVersions, true, stream.Send)
+	} else if err != nil {
+		return err
+	}
+
+	for _, res := range responses {
+		// Cached responses include leaf versions, and are shared so they must be copied to omit them.
+		if !req.IncludeVersions && res.Version != 0 {
+			res = &pb.ListResponse{Key: res.Key, Value: res.Value, Proof: res.Proof, Boundary: res.Boundary}
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list sends the key/value pairs in the range [from, to) of the given tree, including the
+// leaf versions and a streamed range proof if requested.
+func list(tree *iavl.ImmutableTree, from, to []byte, descending, includeVersions, includeProof bool,
+	send func(*pb.ListResponse) error) error {
+
+	if includeProof {
+		if descending {
+			e := status.New(codes.InvalidArgument, "proofs are not supported for descending lists")
+			return e.Err()
+		}
+		if from != nil && to != nil && bytes.Compare(from, to) >= 0 {
+			e := status.New(codes.InvalidArgument, "proofs require the from key to be before the to key")
+			return e.Err()
+		}
+		return tree.StreamRangeWithProof(from, to, func(item iavl.RangeProofItem, value []byte, inRange bool) error {
+			res := &pb.ListResponse{Proof: item.ToProto(), Boundary: !inRange}
+			if inRange {
+				res.Key, res.Value = item.Leaf.Key, value
+				if includeVersions {
+					res.Version = item.Leaf.Version
+				}
+			}
+			return send(res)
+		})
+	}
+
 	var err error
 
-	_ = s.tree.IterateRange(req.FromKey, req.ToKey, req.Descending, func(k []byte, v []byte) bool {
+	if !includeVersions {
+		_ = tree.IterateRange(from, to, !descending, func(k []byte, v []byte) bool {
+			err = send(&pb.ListResponse{Key: k, Value: v})
+			return err != nil
+		})
+		return err
+	}
 
-		res := &pb.ListResponse{Key: k, Value: v}
-		err = stream.Send(res)
+	// IterateRangeInclusive includes the end key, which is skipped to match IterateRange.
+	_ = tree.IterateRangeInclusive(from, to, !descending, func(k, v []byte, version int64) bool {
+		if to != nil && bytes.Equal(k, to) {
+			return false
+		}
+		err = send(&pb.ListResponse{Key: k, Value: v, Version: version})
 		return err != nil
 	})
-
 	return err
+}
+
+// GetNode returns the encoded persisted node with the given hash, allowing clients
+// to traverse the tree remotely, e.g. via iavl.RemoteNodeDB.
+func (s *IAVLServer) GetNode(_ context.Context, req *pb.GetNodeRequest) (*pb.GetNodeResponse, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	node, err := s.tree.GetEncodedNode(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if node == nil {
+		e := status.New(codes.NotFound, "the node requested does not exist")
+		return nil, e.Err()
+	}
+
+	return &pb.GetNodeResponse{Node: node}, nil
+}
+
+// SampleWithProofs returns pseudo-random keys at a specific tree version,
+// derived from the request seed and the root hash, with proofs binding each key
+// to its index. The samples can be verified with iavl.VerifySamples().
+func (s *IAVLServer) SampleWithProofs(_ context.Context, req *pb.SampleWithProofsRequest) (*pb.SampleWithProofsResponse, error) {
+
+	if req.N < 0 || req.N > maxSamples {
+		e := status.Newf(codes.InvalidArgument, "sample size must be between 0 and %d", maxSamples)
+		return nil, e.Err()
+	}
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	samples, err := s.tree.SampleWithProofs(req.Version, req.Seed, int(req.N))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.SampleWithProofsResponse{Samples: make([]*pb.KeySample, 0, len(samples))}
+	for _, sample := range samples {
+		res.Samples = append(res.Samples, &pb.KeySample{
+			Index: sample.Index,
+			Key:   sample.Key,
+			Value: sample.Value,
+			Proof: sample.Proof.ToProto(),
+		})
+	}
+
+	return res, nil
+}
+
+// getImmutable returns the immutable tree at the given version. The caller must
+// hold the read lock.
+func (s *IAVLServer) getImmutable(version int64) (*iavl.ImmutableTree, error) {
+	if !s.tree.VersionExists(version) {
+		return nil, iavl.ErrVersionDoesNotExist
+	}
+
+	return s.tree.GetImmutable(version)
+}
+
+// versionHash returns the root hash of the tree at the given version.
+func (s *IAVLServer) versionHash(version int64) ([]byte, error) {
+
+	s.rwLock.RLock()
+	defer s.rwLock.RUnlock()
+
+	iTree, err := s.getImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return iTree.Hash(), nil
+}
 
+// ProofCacheStats returns the proof cache metrics.
+func (s *IAVLServer) ProofCacheStats() ProofCacheStats {
+	return s.proofCache.Stats()
 }
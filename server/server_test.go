@@ -2,12 +2,15 @@ package server_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net"
 	"testing"
 
+	ics23 "github.com/confio/ics23/go"
 	"github.com/cosmos/iavl"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/stretchr/testify/suite"
 	dbm "github.com/tendermint/tm-db"
 	"google.golang.org/grpc"
@@ -852,6 +855,429 @@ func (suite *ServerTestSuite) TestAvailableVersions() {
 
 }
 
+func (suite *ServerTestSuite) TestGetNode() {
+	hashRes, err := suite.server.Hash(context.Background(), nil)
+	suite.NoError(err)
+
+	res, err := suite.client.GetNode(context.Background(), &pb.GetNodeRequest{Hash: hashRes.RootHash})
+	suite.NoError(err)
+	suite.NotEmpty(res.Node)
+
+	_, err = suite.client.GetNode(context.Background(), &pb.GetNodeRequest{Hash: make([]byte, 32)})
+	suite.Error(err)
+
+	_, err = suite.client.GetNode(context.Background(), &pb.GetNodeRequest{Hash: []byte("invalid")})
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestRemoteImmutableTree() {
+	hashRes, err := suite.server.Hash(context.Background(), nil)
+	suite.NoError(err)
+
+	remoteDB, err := iavl.NewRemoteNodeDB(suite.client, dbm.NewMemDB(), 100)
+	suite.NoError(err)
+	tree, err := iavl.NewRemoteImmutableTree(remoteDB, hashRes.RootHash, 100)
+	suite.NoError(err)
+	suite.Equal(hashRes.RootHash, tree.Hash())
+	suite.EqualValues(100, tree.Size())
+
+	_, value := tree.Get([]byte("key-42"))
+	suite.Equal([]byte("value-42"), value)
+
+	count := 0
+	tree.Iterate(func(key, value []byte) bool {
+		count++
+		return false
+	})
+	suite.Equal(100, count)
+
+	value, proof, err := tree.GetWithProof([]byte("key-7"))
+	suite.NoError(err)
+	suite.Equal([]byte("value-7"), value)
+	suite.NoError(proof.Verify(hashRes.RootHash))
+	suite.NoError(proof.VerifyItem([]byte("key-7"), value))
+}
+
+// saveModifiedVersion modifies the tree after version 1, and saves version 2.
+func (suite *ServerTestSuite) saveModifiedVersion() {
+	_, err := suite.server.Set(context.Background(), &pb.SetRequest{Key: []byte("key-0"), Value: []byte("NEW_VALUE")})
+	suite.NoError(err)
+	_, err = suite.server.Set(context.Background(), &pb.SetRequest{Key: []byte("a-new-key"), Value: []byte("new")})
+	suite.NoError(err)
+	res, err := suite.server.SaveVersion(context.Background(), nil)
+	suite.NoError(err)
+	suite.EqualValues(2, res.Version)
+}
+
+func (suite *ServerTestSuite) TestGetByIndexVersioned() {
+	suite.saveModifiedVersion()
+
+	res, err := suite.client.GetByIndexVersioned(context.Background(), &pb.GetByIndexVersionedRequest{Version: 1, Index: 0})
+	suite.NoError(err)
+	suite.Equal([]byte("key-0"), res.Key)
+	suite.Equal([]byte("value-0"), res.Value)
+
+	res, err = suite.client.GetByIndexVersioned(context.Background(), &pb.GetByIndexVersionedRequest{Version: 2, Index: 0})
+	suite.NoError(err)
+	suite.Equal([]byte("a-new-key"), res.Key)
+
+	_, err = suite.client.GetByIndexVersioned(context.Background(), &pb.GetByIndexVersionedRequest{Version: 1, Index: 100})
+	suite.Error(err)
+
+	_, err = suite.client.GetByIndexVersioned(context.Background(), &pb.GetByIndexVersionedRequest{Version: 3, Index: 0})
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestSizeAndHashVersioned() {
+	hashRes, err := suite.client.Hash(context.Background(), &empty.Empty{})
+	suite.NoError(err)
+	suite.saveModifiedVersion()
+
+	sizeRes, err := suite.client.SizeVersioned(context.Background(), &pb.SizeVersionedRequest{Version: 1})
+	suite.NoError(err)
+	suite.EqualValues(100, sizeRes.Size_)
+
+	sizeRes, err = suite.client.SizeVersioned(context.Background(), &pb.SizeVersionedRequest{Version: 2})
+	suite.NoError(err)
+	suite.EqualValues(101, sizeRes.Size_)
+
+	res, err := suite.client.HashVersioned(context.Background(), &pb.HashVersionedRequest{Version: 1})
+	suite.NoError(err)
+	suite.Equal(hashRes.RootHash, res.RootHash)
+
+	_, err = suite.client.SizeVersioned(context.Background(), &pb.SizeVersionedRequest{Version: 3})
+	suite.Error(err)
+	_, err = suite.client.HashVersioned(context.Background(), &pb.HashVersionedRequest{Version: 3})
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestVerifyVersioned() {
+	res, err := suite.client.GetWithProof(context.Background(), &pb.GetRequest{Key: []byte("key-0")})
+	suite.NoError(err)
+	suite.saveModifiedVersion()
+
+	_, err = suite.client.VerifyVersioned(context.Background(), &pb.VerifyVersionedRequest{Version: 1, Proof: res.Proof})
+	suite.NoError(err)
+	_, err = suite.client.VerifyVersioned(context.Background(), &pb.VerifyVersionedRequest{Version: 2, Proof: res.Proof})
+	suite.Error(err)
+
+	_, err = suite.client.VerifyItemVersioned(context.Background(), &pb.VerifyItemVersionedRequest{
+		Version: 1, Proof: res.Proof, Key: []byte("key-0"), Value: []byte("value-0"),
+	})
+	suite.NoError(err)
+	_, err = suite.client.VerifyItemVersioned(context.Background(), &pb.VerifyItemVersionedRequest{
+		Version: 1, Proof: res.Proof, Key: []byte("key-0"), Value: []byte("NEW_VALUE"),
+	})
+	suite.Error(err)
+
+	_, err = suite.client.VerifyAbsenceVersioned(context.Background(), &pb.VerifyAbsenceVersionedRequest{
+		Version: 1, Proof: res.Proof, Key: []byte("a"),
+	})
+	suite.NoError(err)
+	_, err = suite.client.VerifyAbsenceVersioned(context.Background(), &pb.VerifyAbsenceVersionedRequest{
+		Version: 3, Proof: res.Proof, Key: []byte("a"),
+	})
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestGetWithValueHashProof() {
+	suite.saveModifiedVersion()
+	valueHash := sha256.Sum256([]byte("value-0"))
+
+	res, err := suite.client.GetVersionedWithValueHashProof(context.Background(),
+		&pb.GetVersionedRequest{Version: 1, Key: []byte("key-0")})
+	suite.NoError(err)
+	suite.Equal(valueHash[:], res.ValueHash)
+
+	_, err = suite.client.VerifyItemValueHashVersioned(context.Background(), &pb.VerifyItemValueHashVersionedRequest{
+		Version: 1, Proof: res.Proof, Key: []byte("key-0"), ValueHash: valueHash[:],
+	})
+	suite.NoError(err)
+	_, err = suite.client.VerifyItemValueHashVersioned(context.Background(), &pb.VerifyItemValueHashVersionedRequest{
+		Version: 2, Proof: res.Proof, Key: []byte("key-0"), ValueHash: valueHash[:],
+	})
+	suite.Error(err)
+
+	hashRes, err := suite.client.HashVersioned(context.Background(), &pb.HashVersionedRequest{Version: 1})
+	suite.NoError(err)
+	proof := &ics23.CommitmentProof{}
+	suite.NoError(proof.Unmarshal(res.Ics23Proof))
+	suite.True(iavl.VerifyValueHashMembership(hashRes.RootHash, proof, []byte("key-0"), valueHash[:]))
+
+	newHash := sha256.Sum256([]byte("NEW_VALUE"))
+	res, err = suite.client.GetWithValueHashProof(context.Background(), &pb.GetRequest{Key: []byte("key-0")})
+	suite.NoError(err)
+	suite.Equal(newHash[:], res.ValueHash)
+	_, err = suite.client.VerifyItemValueHash(context.Background(), &pb.VerifyItemValueHashRequest{
+		RootHash: hashRes.RootHash, Proof: res.Proof, Key: []byte("key-0"), ValueHash: newHash[:],
+	})
+	suite.Error(err)
+
+	_, err = suite.client.GetWithValueHashProof(context.Background(), &pb.GetRequest{Key: []byte("a")})
+	suite.Error(err)
+	_, err = suite.client.GetVersionedWithValueHashProof(context.Background(),
+		&pb.GetVersionedRequest{Version: 3, Key: []byte("key-0")})
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestListVersioned() {
+	suite.saveModifiedVersion()
+
+	recv := func(stream pb.IAVLService_ListClient) []*pb.ListResponse {
+		results := []*pb.ListResponse{}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return results
+			}
+			suite.NoError(err)
+			results = append(results, res)
+		}
+	}
+
+	stream, err := suite.client.ListVersioned(context.Background(), &pb.ListVersionedRequest{
+		Version: 1, FromKey: []byte("a"), ToKey: []byte("key-1"),
+	})
+	suite.NoError(err)
+	results := recv(stream)
+	suite.Require().Len(results, 1)
+	suite.Equal(&pb.ListResponse{Key: []byte("key-0"), Value: []byte("value-0")}, results[0])
+
+	stream, err = suite.client.ListVersioned(context.Background(), &pb.ListVersionedRequest{
+		Version: 2, FromKey: []byte("a"), ToKey: []byte("key-10"), Descending: true, IncludeVersions: true,
+	})
+	suite.NoError(err)
+	results = recv(stream)
+	suite.Require().Len(results, 3)
+	suite.Equal(&pb.ListResponse{Key: []byte("key-1"), Value: []byte("value-1"), Version: 1}, results[0])
+	suite.Equal(&pb.ListResponse{Key: []byte("key-0"), Value: []byte("NEW_VALUE"), Version: 2}, results[1])
+	suite.Equal(&pb.ListResponse{Key: []byte("a-new-key"), Value: []byte("new"), Version: 2}, results[2])
+
+	listStream, err := suite.client.List(context.Background(), &pb.ListRequest{
+		FromKey: []byte("a"), ToKey: []byte("key-10"), IncludeVersions: true,
+	})
+	suite.NoError(err)
+	results = recv(listStream)
+	suite.Require().Len(results, 3)
+	suite.Equal([]byte("a-new-key"), results[0].Key)
+	suite.EqualValues(2, results[0].Version)
+
+	stream, err = suite.client.ListVersioned(context.Background(), &pb.ListVersionedRequest{Version: 3})
+	suite.NoError(err)
+	_, err = stream.Recv()
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestListWithProof() {
+	suite.saveModifiedVersion()
+	hashRes, err := suite.client.HashVersioned(context.Background(), &pb.HashVersionedRequest{Version: 1})
+	suite.NoError(err)
+
+	stream, err := suite.client.ListVersioned(context.Background(), &pb.ListVersionedRequest{
+		Version: 1, FromKey: []byte("key-1"), ToKey: []byte("key-2"), IncludeProof: true, IncludeVersions: true,
+	})
+	suite.NoError(err)
+	verifier := iavl.NewRangeProofVerifier(hashRes.RootHash, []byte("key-1"), []byte("key-2"))
+	keys := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		item, err := iavl.RangeProofItemFromProto(res.Proof)
+		suite.Require().NoError(err)
+		suite.Require().NoError(verifier.Add(item, res.Value))
+		if !res.Boundary {
+			suite.Equal([]byte(item.Leaf.Key), res.Key)
+			suite.EqualValues(1, res.Version)
+			keys++
+		} else {
+			suite.Nil(res.Key)
+		}
+	}
+	suite.NoError(verifier.Finish())
+	suite.Equal(11, keys) // key-1 and key-10 to key-19
+	suite.EqualValues(keys+1, verifier.Items())
+
+	// Proofs of the latest version do not verify against an earlier root.
+	listStream, err := suite.client.List(context.Background(), &pb.ListRequest{IncludeProof: true})
+	suite.NoError(err)
+	verifier = iavl.NewRangeProofVerifier(hashRes.RootHash, nil, nil)
+	res, err := listStream.Recv()
+	suite.Require().NoError(err)
+	item, err := iavl.RangeProofItemFromProto(res.Proof)
+	suite.Require().NoError(err)
+	suite.Error(verifier.Add(item, res.Value))
+
+	listStream, err = suite.client.List(context.Background(), &pb.ListRequest{IncludeProof: true, Descending: true})
+	suite.NoError(err)
+	_, err = listStream.Recv()
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestProofCache() {
+	suite.saveModifiedVersion()
+	req := &pb.GetVersionedRequest{Version: 2, Key: []byte("key-0")}
+
+	res, err := suite.client.GetVersionedWithProof(context.Background(), req)
+	suite.NoError(err)
+	suite.Equal([]byte("NEW_VALUE"), res.Value)
+	cached, err := suite.client.GetVersionedWithProof(context.Background(), req)
+	suite.NoError(err)
+	suite.Equal(res, cached)
+	_, err = suite.client.GetVersionedWithProof(context.Background(), &pb.GetVersionedRequest{Version: 1, Key: []byte("key-0")})
+	suite.NoError(err)
+
+	stats := suite.server.ProofCacheStats()
+	suite.EqualValues(1, stats.Hits)
+	suite.EqualValues(2, stats.Misses)
+	suite.Equal(2, stats.Entries)
+
+	// Absent keys are cached too.
+	for i := 0; i < 2; i++ {
+		_, err = suite.client.GetVersionedWithProof(context.Background(), &pb.GetVersionedRequest{Version: 2, Key: []byte("x")})
+		suite.Error(err)
+	}
+	suite.EqualValues(2, suite.server.ProofCacheStats().Hits)
+
+	// Overwriting versions invalidates their proofs, since they may be saved again with different contents.
+	_, err = suite.client.LoadVersionForOverwriting(context.Background(), &pb.LoadVersionForOverwritingRequest{Version: 1})
+	suite.NoError(err)
+	suite.Equal(1, suite.server.ProofCacheStats().Entries)
+	_, err = suite.client.Set(context.Background(), &pb.SetRequest{Key: []byte("key-0"), Value: []byte("OTHER_VALUE")})
+	suite.NoError(err)
+	_, err = suite.client.SaveVersion(context.Background(), &empty.Empty{})
+	suite.NoError(err)
+	res, err = suite.client.GetVersionedWithProof(context.Background(), req)
+	suite.NoError(err)
+	suite.Equal([]byte("OTHER_VALUE"), res.Value)
+
+	// Deleting a version invalidates its proofs.
+	_, err = suite.client.DeleteVersion(context.Background(), &pb.DeleteVersionRequest{Version: 1})
+	suite.NoError(err)
+	suite.Equal(1, suite.server.ProofCacheStats().Entries)
+	_, err = suite.client.GetVersionedWithProof(context.Background(), &pb.GetVersionedRequest{Version: 1, Key: []byte("key-0")})
+	suite.Error(err)
+}
+
+func (suite *ServerTestSuite) TestRangeProofCache() {
+	suite.saveModifiedVersion()
+	list := func(req *pb.ListVersionedRequest) []*pb.ListResponse {
+		stream, err := suite.client.ListVersioned(context.Background(), req)
+		suite.Require().NoError(err)
+		results := []*pb.ListResponse{}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return results
+			}
+			suite.Require().NoError(err)
+			results = append(results, res)
+		}
+	}
+	req := &pb.ListVersionedRequest{Version: 1, FromKey: []byte("key-1"), ToKey: []byte("key-2"),
+		IncludeProof: true, IncludeVersions: true}
+
+	results := list(req)
+	suite.Require().Len(results, 12) // key-1, key-10 to key-19 and the key-2 boundary leaf
+	suite.EqualValues(1, results[0].Version)
+	suite.Equal(results, list(req))
+	stats := suite.server.ProofCacheStats()
+	suite.EqualValues(1, stats.Misses)
+	suite.EqualValues(1, stats.Hits)
+	suite.Equal(1, stats.Entries)
+
+	// Leaf versions are omitted from cached responses if not requested.
+	req.IncludeVersions = false
+	withoutVersions := list(req)
+	suite.Require().Len(withoutVersions, len(results))
+	for i, res := range withoutVersions {
+		suite.Zero(res.Version)
+		suite.Equal(results[i].Key, res.Key)
+		suite.Equal(results[i].Proof, res.Proof)
+	}
+	suite.EqualValues(2, suite.server.ProofCacheStats().Hits)
+
+	// Open ranges and other versions are cached separately.
+	list(&pb.ListVersionedRequest{Version: 1, FromKey: []byte("key-1"), IncludeProof: true})
+	list(&pb.ListVersionedRequest{Version: 2, FromKey: []byte("key-1"), ToKey: []byte("key-2"), IncludeProof: true})
+	suite.EqualValues(3, suite.server.ProofCacheStats().Misses)
+	suite.Equal(3, suite.server.ProofCacheStats().Entries)
+
+	// Overwriting and deleting versions invalidates their range proofs.
+	_, err := suite.client.LoadVersionForOverwriting(context.Background(), &pb.LoadVersionForOverwritingRequest{Version: 1})
+	suite.NoError(err)
+	suite.Equal(2, suite.server.ProofCacheStats().Entries)
+	_, err = suite.client.DeleteVersion(context.Background(), &pb.DeleteVersionRequest{Version: 1})
+	suite.Error(err) // the latest version cannot be deleted
+	_, err = suite.client.SaveVersion(context.Background(), &empty.Empty{})
+	suite.NoError(err)
+	_, err = suite.client.DeleteVersion(context.Background(), &pb.DeleteVersionRequest{Version: 1})
+	suite.NoError(err)
+	suite.Equal(0, suite.server.ProofCacheStats().Entries)
+}
+
+func (suite *ServerTestSuite) TestRangeProofCache_Large() {
+	for i := 0; i < 1000; i++ {
+		_, err := suite.server.Set(context.Background(), &pb.SetRequest{
+			Key: []byte(fmt.Sprintf("large-%04d", i)), Value: []byte{1}})
+		suite.Require().NoError(err)
+	}
+	_, err := suite.server.SaveVersion(context.Background(), nil)
+	suite.Require().NoError(err)
+	hashRes, err := suite.client.HashVersioned(context.Background(), &pb.HashVersionedRequest{Version: 2})
+	suite.Require().NoError(err)
+
+	// Ranges too large to cache are streamed in full without caching them.
+	for i := 0; i < 2; i++ {
+		stream, err := suite.client.ListVersioned(context.Background(), &pb.ListVersionedRequest{
+			Version: 2, IncludeProof: true})
+		suite.Require().NoError(err)
+		verifier := iavl.NewRangeProofVerifier(hashRes.RootHash, nil, nil)
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			suite.Require().NoError(err)
+			item, err := iavl.RangeProofItemFromProto(res.Proof)
+			suite.Require().NoError(err)
+			suite.Require().NoError(verifier.Add(item, res.Value))
+		}
+		suite.NoError(verifier.Finish())
+		suite.EqualValues(1100, verifier.Items())
+	}
+	stats := suite.server.ProofCacheStats()
+	suite.EqualValues(2, stats.Misses)
+	suite.Equal(0, stats.Entries)
+}
+
+func (suite *ServerTestSuite) TestSampleWithProofs() {
+	suite.saveModifiedVersion()
+	seed := []byte("seed")
+
+	res, err := suite.client.SampleWithProofs(context.Background(), &pb.SampleWithProofsRequest{Version: 1, Seed: seed, N: 10})
+	suite.NoError(err)
+	suite.Require().Len(res.Samples, 10)
+
+	samples := make([]iavl.KeySample, 0, len(res.Samples))
+	for _, sample := range res.Samples {
+		proof, err := iavl.RangeProofFromProto(sample.Proof)
+		suite.Require().NoError(err)
+		samples = append(samples, iavl.KeySample{Index: sample.Index, Key: sample.Key, Value: sample.Value, Proof: &proof})
+	}
+	hashRes, err := suite.client.HashVersioned(context.Background(), &pb.HashVersionedRequest{Version: 1})
+	suite.NoError(err)
+	suite.NoError(iavl.VerifySamples(hashRes.RootHash, seed, 10, samples))
+	suite.Error(iavl.VerifySamples(hashRes.RootHash, []byte("other"), 10, samples))
+
+	_, err = suite.client.SampleWithProofs(context.Background(), &pb.SampleWithProofsRequest{Version: 3, Seed: seed, N: 10})
+	suite.Error(err)
+	_, err = suite.client.SampleWithProofs(context.Background(), &pb.SampleWithProofsRequest{Version: 1, Seed: seed, N: -1})
+	suite.Error(err)
+}
+
 func TestServerTestSuite(t *testing.T) {
 	suite.Run(t, new(ServerTestSuite))
 }
@@ -0,0 +1,315 @@
+package iavl
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/pkg/errors"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// DefaultShadowReadSampleRate is the default number of node database reads per read which is
+// compared with the shadow database.
+const DefaultShadowReadSampleRate = 100
+
+// Shadow operations, see ShadowDivergence.
+const (
+	ShadowOpWrite = "write" // a write to the shadow database failed
+	ShadowOpRead  = "read"  // a sampled node read from the shadow database differs
+	ShadowOpRoot  = "root"  // the root of a saved version differs in the shadow database
+)
+
+// ShadowOptions configures shadow writes, set via Options.Shadow. All node, orphan and root writes
+// to the database are mirrored to a secondary database, e.g. with a new backend, which is
+// periodically compared with the primary database. This allows validating the secondary database
+// under real load before cutting over to it.
+//
+// The secondary database must start out as a copy of the primary database, e.g. restored from the
+// same backup or populated via Export() and Import(), since only new writes are mirrored. Nodes are
+// written using the node encoding of the secondary database backend (see dbm.DB.IsTrackable()).
+// Errors in the secondary database are only reported as divergences, they never fail tree
+// operations.
+type ShadowOptions struct {
+	// DB is the secondary database.
+	DB dbm.DB
+
+	// ReadSampleRate is the number of node reads from the primary database per read which is also
+	// read from the secondary database and compared. Zero uses DefaultShadowReadSampleRate, and a
+	// negative rate disables read comparisons.
+	ReadSampleRate int
+
+	// OnDivergence is called with each divergence found. It is called while holding internal
+	// locks, so it must not call back into the tree. Defaults to logging with the standard logger.
+	OnDivergence func(ShadowDivergence)
+}
+
+// ShadowDivergence describes a divergence between the primary and the shadow database.
+type ShadowDivergence struct {
+	Op      string // ShadowOpWrite, ShadowOpRead or ShadowOpRoot
+	Version int64  // The saved version, for root comparisons.
+	Key     []byte // The database key.
+	Err     error  // The divergence found.
+}
+
+// String implements fmt.Stringer.
+func (d ShadowDivergence) String() string {
+	if d.Op == ShadowOpRoot {
+		return fmt.Sprintf("shadow %v divergence at version %v: %v", d.Op, d.Version, d.Err)
+	}
+	return fmt.Sprintf("shadow %v divergence at key %X: %v", d.Op, d.Key, d.Err)
+}
+
+// ShadowStats contains shadow write metrics.
+type ShadowStats struct {
+	Batches       int64 // The number of batches written to the shadow database.
+	ComparedReads int64 // The number of node reads compared.
+	ComparedRoots int64 // The number of saved version roots compared.
+	Divergences   int64 // The number of divergences found.
+}
+
+// String implements fmt.Stringer.
+func (s ShadowStats) String() string {
+	return fmt.Sprintf("%v batches, %v reads and %v roots compared, %v divergences",
+		s.Batches, s.ComparedReads, s.ComparedRoots, s.Divergences)
+}
+
+// ShadowStats returns the shadow write metrics, or zero metrics if Options.Shadow is not set.
+func (tree *MutableTree) ShadowStats() ShadowStats {
+	if tree.ndb.shadow == nil {
+		return ShadowStats{}
+	}
+	return tree.ndb.shadow.Stats()
+}
+
+// shadow mirrors writes to a secondary database, and compares it with the primary database.
+type shadow struct {
+	db               dbm.DB
+	trackable        bool // whether the secondary database uses the trackable node encoding
+	primaryTrackable bool
+	readSampleRate   int
+	onDivergence     func(ShadowDivergence)
+
+	mtx   sync.Mutex
+	reads int // node reads since the last compared read
+	stats ShadowStats
+}
+
+// newShadow creates a shadow of the given primary database.
+func newShadow(primary dbm.DB, opts *ShadowOptions) *shadow {
+	s := &shadow{
+		db:               opts.DB,
+		trackable:        opts.DB.IsTrackable(),
+		primaryTrackable: primary.IsTrackable(),
+		readSampleRate:   opts.ReadSampleRate,
+		onDivergence:     opts.OnDivergence,
+	}
+	if s.readSampleRate == 0 {
+		s.readSampleRate = DefaultShadowReadSampleRate
+	}
+	if s.onDivergence == nil {
+		s.onDivergence = func(d ShadowDivergence) {
+			log.Printf("iavl: %v", d)
+		}
+	}
+	return s
+}
+
+// Stats returns the shadow write metrics.
+func (s *shadow) Stats() ShadowStats {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.stats
+}
+
+// report reports a divergence.
+func (s *shadow) report(d ShadowDivergence) {
+	s.mtx.Lock()
+	s.stats.Divergences++
+	s.mtx.Unlock()
+	s.onDivergence(d)
+}
+
+// newBatch wraps a batch of the primary database such that it mirrors writes to the shadow.
+func (s *shadow) newBatch(primary dbm.Batch) dbm.Batch {
+	return &shadowBatch{shadow: s, primary: primary, secondary: s.db.NewBatch()}
+}
+
+// encode converts a value written to the primary database to the encoding of the shadow database.
+// Only node encodings differ, including nodes in pages.
+func (s *shadow) encode(key, value []byte) ([]byte, error) {
+	if s.trackable == s.primaryTrackable || len(key) != 1+hashSize ||
+		string(key[:1]) != nodeKeyFormat.Prefix() {
+		return value, nil
+	}
+	if _, ok := pageRefRoot(value); ok {
+		return value, nil
+	}
+	if isPage(value) {
+		nodes, err := decodePage(value)
+		if err != nil {
+			return nil, err
+		}
+		return encodePage(nodes, s.trackable)
+	}
+	node, err := MakeNode(value)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Grow(node.encodedSizeEx(s.trackable))
+	if err = node.writeBytesEx(&buf, s.trackable); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sampleRead returns true if a node read should be compared.
+func (s *shadow) sampleRead() bool {
+	if s.readSampleRate < 0 {
+		return false
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.reads++
+	if s.reads < s.readSampleRate {
+		return false
+	}
+	s.reads = 0
+	s.stats.ComparedReads++
+	return true
+}
+
+// compareNode reads a node from the shadow database and checks that it has the given hash.
+func (s *shadow) compareNode(key, hash []byte) error {
+	node, _, _, err := readNode(s.db.Get, key[1:])
+	if err != nil {
+		return errors.Wrap(err, "reading node")
+	}
+	if node == nil {
+		return errors.New("node missing")
+	}
+	node.hash = nil
+	if h := node._hash(); !bytes.Equal(h, hash) {
+		return errors.Errorf("node has hash %X", h)
+	}
+	return nil
+}
+
+// compareRead compares a sampled node read with the shadow database.
+func (s *shadow) compareRead(key, hash []byte) {
+	if err := s.compareNode(key, hash); err != nil {
+		s.report(ShadowDivergence{Op: ShadowOpRead, Key: key, Err: err})
+	}
+}
+
+// compareRoot compares the root of a saved version with the shadow database, including the root
+// node itself. The root hash is empty for an empty tree.
+func (s *shadow) compareRoot(version int64, rootKey, rootHash []byte) {
+	s.mtx.Lock()
+	s.stats.ComparedRoots++
+	s.mtx.Unlock()
+
+	err := func() error {
+		has, err := s.db.Has(rootKey)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return errors.New("root missing")
+		}
+		hash, err := s.db.Get(rootKey)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(hash, rootHash) {
+			return errors.Errorf("root hash %X, expected %X", hash, rootHash)
+		}
+		if len(rootHash) == 0 {
+			return nil
+		}
+		return errors.Wrap(s.compareNode(nodeKeyFormat.Key(rootHash), rootHash), "root node")
+	}()
+	if err != nil {
+		s.report(ShadowDivergence{Op: ShadowOpRoot, Version: version, Key: rootKey, Err: err})
+	}
+}
+
+// compareShadowRoot compares the root of a saved version with the shadow database, if any.
+func (ndb *nodeDB) compareShadowRoot(version int64, rootHash []byte) {
+	if ndb.shadow != nil {
+		ndb.shadow.compareRoot(version, ndb.rootKey(version), rootHash)
+	}
+}
+
+// shadowBatch is a batch which mirrors writes to a batch of the shadow database. Only errors from
+// the primary batch are returned.
+type shadowBatch struct {
+	shadow    *shadow
+	primary   dbm.Batch
+	secondary dbm.Batch
+}
+
+var _ dbm.Batch = (*shadowBatch)(nil)
+
+// Set implements dbm.Batch.
+func (b *shadowBatch) Set(key, value []byte) error {
+	if err := b.primary.Set(key, value); err != nil {
+		return err
+	}
+	value, err := b.shadow.encode(key, value)
+	if err == nil {
+		err = b.secondary.Set(key, value)
+	}
+	if err != nil {
+		b.shadow.report(ShadowDivergence{Op: ShadowOpWrite, Key: key, Err: err})
+	}
+	return nil
+}
+
+// Delete implements dbm.Batch.
+func (b *shadowBatch) Delete(key []byte) error {
+	if err := b.primary.Delete(key); err != nil {
+		return err
+	}
+	if err := b.secondary.Delete(key); err != nil {
+		b.shadow.report(ShadowDivergence{Op: ShadowOpWrite, Key: key, Err: err})
+	}
+	return nil
+}
+
+// Write implements dbm.Batch.
+func (b *shadowBatch) Write() error {
+	if err := b.primary.Write(); err != nil {
+		return err
+	}
+	b.written(b.secondary.Write())
+	return nil
+}
+
+// WriteSync implements dbm.Batch.
+func (b *shadowBatch) WriteSync() error {
+	if err := b.primary.WriteSync(); err != nil {
+		return err
+	}
+	b.written(b.secondary.WriteSync())
+	return nil
+}
+
+// written records a write of the secondary batch.
+func (b *shadowBatch) written(err error) {
+	if err != nil {
+		b.shadow.report(ShadowDivergence{Op: ShadowOpWrite, Err: errors.Wrap(err, "writing batch")})
+		return
+	}
+	b.shadow.mtx.Lock()
+	b.shadow.stats.Batches++
+	b.shadow.mtx.Unlock()
+}
+
+// Close implements dbm.Batch.
+func (b *shadowBatch) Close() error {
+	b.secondary.Close()
+	return b.primary.Close()
+}
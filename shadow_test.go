@@ -0,0 +1,143 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// trackableDB is a database which uses the trackable (BSON) node encoding.
+type trackableDB struct {
+	db.DB
+}
+
+func (trackableDB) IsTrackable() bool { return true }
+
+// failingBatchDB is a database whose batches fail to write.
+type failingBatchDB struct {
+	db.DB
+}
+
+func (d failingBatchDB) NewBatch() db.Batch {
+	return failingBatch{d.DB.NewBatch()}
+}
+
+type failingBatch struct {
+	db.Batch
+}
+
+func (failingBatch) Write() error     { return errors.New("disk full") }
+func (failingBatch) WriteSync() error { return errors.New("disk full") }
+
+// newShadowTree creates a tree with shadow writes, comparing every read, and returns it with the
+// divergences found.
+func newShadowTree(t *testing.T, primary, secondary db.DB) (*MutableTree, *[]ShadowDivergence) {
+	divergences := &[]ShadowDivergence{}
+	tree, err := NewMutableTreeWithOpts(primary, 0, &Options{Shadow: &ShadowOptions{
+		DB:             secondary,
+		ReadSampleRate: 1,
+		OnDivergence: func(d ShadowDivergence) {
+			*divergences = append(*divergences, d)
+		},
+	}})
+	require.NoError(t, err)
+	return tree, divergences
+}
+
+// setupShadowTree creates a tree with shadow writes and saves 3 versions.
+func setupShadowTree(t *testing.T, primary, secondary db.DB) (*MutableTree, *[]ShadowDivergence) {
+	tree, divergences := newShadowTree(t, primary, secondary)
+	var err error
+	for v := 1; v <= 3; v++ {
+		for i := 0; i < 50; i++ {
+			tree.Set([]byte(fmt.Sprintf("key-%02d", (i*v)%60)), []byte(fmt.Sprintf("value-%v-%v", v, i)))
+		}
+		_, _, err = tree.SaveVersion()
+		require.NoError(t, err)
+	}
+	return tree, divergences
+}
+
+func TestShadow(t *testing.T) {
+	primary, secondary := db.NewMemDB(), db.NewMemDB()
+	tree, divergences := setupShadowTree(t, primary, secondary)
+	require.NoError(t, tree.DeleteVersion(1))
+	require.Equal(t, dumpDB(t, primary), dumpDB(t, secondary))
+	require.Empty(t, *divergences)
+	stats := tree.ShadowStats()
+	require.EqualValues(t, 3, stats.ComparedRoots)
+	require.GreaterOrEqual(t, stats.Batches, int64(4))
+
+	// Reads from a reloaded tree are compared.
+	tree, divergences = newShadowTree(t, primary, secondary)
+	_, err := tree.LoadVersion(3)
+	require.NoError(t, err)
+	tree.Iterate(func(key, value []byte) bool { return false })
+	require.Empty(t, *divergences)
+	require.Greater(t, tree.ShadowStats().ComparedReads, int64(0))
+
+	// Corrupted shadow nodes are reported.
+	itr, err := secondary.Iterator(nodeKeyFormat.Key(), rootKeyFormat.Key())
+	require.NoError(t, err)
+	var nodeKeys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		nodeKeys = append(nodeKeys, itr.Key())
+	}
+	itr.Close()
+	for _, key := range nodeKeys {
+		require.NoError(t, secondary.Delete(key))
+	}
+	tree, divergences = newShadowTree(t, primary, secondary)
+	_, err = tree.LoadVersion(3)
+	require.NoError(t, err)
+	tree.Iterate(func(key, value []byte) bool { return false })
+	require.NotEmpty(t, *divergences)
+	for _, d := range *divergences {
+		require.Equal(t, ShadowOpRead, d.Op)
+		require.Contains(t, d.String(), "node missing")
+	}
+}
+
+func TestShadow_Encoding(t *testing.T) {
+	primary, secondary := db.NewMemDB(), trackableDB{db.NewMemDB()}
+	setupShadowTree(t, primary, secondary)
+	tree, divergences := newShadowTree(t, primary, secondary)
+	_, err := tree.LoadVersion(3)
+	require.NoError(t, err)
+	tree.Iterate(func(key, value []byte) bool { return false })
+	require.Empty(t, *divergences)
+	require.Greater(t, tree.ShadowStats().ComparedReads, int64(0))
+
+	// Leaf nodes are encoded differently, but the shadow database holds the same tree.
+	require.NotEqual(t, dumpDB(t, primary), dumpDB(t, secondary))
+	shadowTree, err := NewMutableTree(secondary, 0)
+	require.NoError(t, err)
+	_, err = shadowTree.Load()
+	require.NoError(t, err)
+	require.Equal(t, tree.Hash(), shadowTree.Hash())
+	tree.Iterate(func(key, value []byte) bool {
+		_, shadowValue := shadowTree.Get(key)
+		require.Equal(t, value, shadowValue)
+		return false
+	})
+}
+
+func TestShadow_WriteFailure(t *testing.T) {
+	primary := db.NewMemDB()
+	tree, divergences := setupShadowTree(t, primary, failingBatchDB{db.NewMemDB()})
+
+	// Tree operations succeed, but the failed writes and missing roots are reported.
+	require.EqualValues(t, 3, tree.Version())
+	ops := map[string]int{}
+	for _, d := range *divergences {
+		ops[d.Op]++
+	}
+	require.Equal(t, 3, ops[ShadowOpRoot])
+	require.GreaterOrEqual(t, ops[ShadowOpWrite], 3)
+	require.EqualValues(t, len(*divergences), tree.ShadowStats().Divergences)
+	require.Zero(t, tree.ShadowStats().Batches)
+}
@@ -0,0 +1,270 @@
+package iavl
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"math"
+
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/pkg/errors"
+
+	iavlproto "github.com/cosmos/iavl/proto"
+)
+
+const (
+	// DefaultSnapshotChunkSize is the chunk size used by Cosmos SDK state sync snapshots.
+	DefaultSnapshotChunkSize = 10e6
+
+	// snapshotBufferSize, snapshotCompressionLevel and snapshotMaxItemSize must match the Cosmos
+	// SDK snapshot format, such that snapshots can be exchanged with SDK nodes.
+	snapshotBufferSize       = 4e6
+	snapshotCompressionLevel = 7
+	snapshotMaxItemSize      = 64e6
+)
+
+// SnapshotWriter writes exported trees in the Cosmos SDK state sync snapshot format: a
+// zlib-compressed stream of length-prefixed SnapshotItem protobuf messages, where each store is
+// given as a store item followed by the exported nodes of its tree. Users must call Close() when
+// done, which flushes the stream but does not close the underlying writer.
+type SnapshotWriter struct {
+	bufWriter   *bufio.Writer
+	zWriter     *zlib.Writer
+	protoWriter protoio.WriteCloser
+}
+
+// NewSnapshotWriter creates a new snapshot writer writing to the given writer.
+func NewSnapshotWriter(w io.Writer) (*SnapshotWriter, error) {
+	bufWriter := bufio.NewWriterSize(w, snapshotBufferSize)
+	zWriter, err := zlib.NewWriterLevel(bufWriter, snapshotCompressionLevel)
+	if err != nil {
+		return nil, errors.Wrap(err, "zlib failure")
+	}
+	return &SnapshotWriter{
+		bufWriter:   bufWriter,
+		zWriter:     zWriter,
+		protoWriter: protoio.NewDelimitedWriter(zWriter),
+	}, nil
+}
+
+// WriteStore starts a new store with the given name. All nodes written after it belong to the
+// store, until the next store is started.
+func (w *SnapshotWriter) WriteStore(name string) error {
+	return w.protoWriter.WriteMsg(&iavlproto.SnapshotItem{
+		Item: &iavlproto.SnapshotItem_Store{
+			Store: &iavlproto.SnapshotStoreItem{Name: name},
+		},
+	})
+}
+
+// WriteNode writes an exported node to the current store.
+func (w *SnapshotWriter) WriteNode(node *ExportNode) error {
+	if node == nil {
+		return errors.New("node cannot be nil")
+	}
+	return w.protoWriter.WriteMsg(&iavlproto.SnapshotItem{
+		Item: &iavlproto.SnapshotItem_Iavl{
+			Iavl: &iavlproto.SnapshotIAVLItem{
+				Key:     node.Key,
+				Value:   node.Value,
+				Version: node.Version,
+				Height:  int32(node.Height),
+			},
+		},
+	})
+}
+
+// WriteExport writes a store with the given name, followed by all nodes read from the reader
+// (typically an Exporter) until it returns ExportDone.
+func (w *SnapshotWriter) WriteExport(name string, reader ExportNodeReader) error {
+	if err := w.WriteStore(name); err != nil {
+		return err
+	}
+	for {
+		node, err := reader.Next()
+		if err == ExportDone {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err = w.WriteNode(node); err != nil {
+			return err
+		}
+	}
+}
+
+// Close finishes the compressed stream and flushes it to the underlying writer.
+func (w *SnapshotWriter) Close() error {
+	if err := w.protoWriter.Close(); err != nil {
+		return err
+	}
+	return w.bufWriter.Flush()
+}
+
+// SnapshotReader reads trees written in the Cosmos SDK state sync snapshot format, e.g. by
+// SnapshotWriter. Stores are iterated with NextStore(), and the nodes of the current store with
+// Next(), which can be given directly to an Importer or ParallelImporter segment. Snapshot chunks
+// can be read by concatenating them, e.g. with io.MultiReader().
+//
+// Items other than stores and nodes, such as the extension items of later SDK versions, end the
+// store section of the stream: NextStore() returns io.EOF when encountering them.
+type SnapshotReader struct {
+	zReader     io.ReadCloser
+	protoReader protoio.ReadCloser
+	item        *iavlproto.SnapshotItem // the next unprocessed item, if any
+	done        bool
+}
+
+var _ ExportNodeReader = (*SnapshotReader)(nil)
+
+// NewSnapshotReader creates a new snapshot reader reading from the given reader.
+func NewSnapshotReader(r io.Reader) (*SnapshotReader, error) {
+	zReader, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "zlib failure")
+	}
+	return &SnapshotReader{
+		zReader:     zReader,
+		protoReader: protoio.NewDelimitedReader(zReader, snapshotMaxItemSize),
+	}, nil
+}
+
+// peek returns the next unprocessed item without consuming it, or nil at the end of the stream.
+func (r *SnapshotReader) peek() (*iavlproto.SnapshotItem, error) {
+	if r.item != nil || r.done {
+		return r.item, nil
+	}
+	item := &iavlproto.SnapshotItem{}
+	err := r.protoReader.ReadMsg(item)
+	if err == io.EOF {
+		r.done = true
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "invalid protobuf message")
+	}
+	r.item = item
+	return item, nil
+}
+
+// NextStore skips to the next store, and returns its name. It returns io.EOF when there are no
+// more stores. Any remaining nodes of the current store are skipped.
+func (r *SnapshotReader) NextStore() (string, error) {
+	for {
+		item, err := r.peek()
+		if err != nil {
+			return "", err
+		}
+		switch item := item.GetItem().(type) {
+		case *iavlproto.SnapshotItem_Store:
+			r.item = nil
+			return item.Store.Name, nil
+		case *iavlproto.SnapshotItem_Iavl:
+			r.item = nil
+		default:
+			return "", io.EOF
+		}
+	}
+}
+
+// Next returns the next node of the current store, or ExportDone when all of its nodes have been
+// read.
+func (r *SnapshotReader) Next() (*ExportNode, error) {
+	item, err := r.peek()
+	if err != nil {
+		return nil, err
+	}
+	iavlItem := item.GetIavl()
+	if iavlItem == nil {
+		return nil, ExportDone
+	}
+	r.item = nil
+
+	if iavlItem.Height < 0 || iavlItem.Height > math.MaxInt8 {
+		return nil, errors.Errorf("node height %v cannot exceed %v", iavlItem.Height, math.MaxInt8)
+	}
+	node := &ExportNode{
+		Key:     iavlItem.Key,
+		Value:   iavlItem.Value,
+		Version: iavlItem.Version,
+		Height:  int8(iavlItem.Height),
+	}
+	// Protobuf does not differentiate between []byte{} and nil, but IAVL does not allow nil keys
+	// nor nil values for leaf nodes, so we can always set them to empty.
+	if node.Key == nil {
+		node.Key = []byte{}
+	}
+	if node.Height == 0 && node.Value == nil {
+		node.Value = []byte{}
+	}
+	return node, nil
+}
+
+// Close releases the reader's resources. It does not close the underlying reader.
+func (r *SnapshotReader) Close() error {
+	return r.zReader.Close()
+}
+
+// SnapshotChunkWriter splits a snapshot stream into chunks of a fixed size, as used by Cosmos SDK
+// state sync. It is typically given to NewSnapshotWriter(). Users must call Close() after closing
+// the SnapshotWriter, to emit the final chunk.
+type SnapshotChunkWriter struct {
+	chunkSize int
+	onChunk   func(chunk []byte) error
+	buf       bytes.Buffer
+	closed    bool
+}
+
+// NewSnapshotChunkWriter creates a new chunk writer, which calls onChunk with each complete chunk
+// of chunkSize bytes. The chunk is not retained after onChunk returns.
+func NewSnapshotChunkWriter(chunkSize int, onChunk func(chunk []byte) error) (*SnapshotChunkWriter, error) {
+	if chunkSize <= 0 {
+		return nil, errors.Errorf("chunk size must be positive, got %v", chunkSize)
+	}
+	if onChunk == nil {
+		return nil, errors.New("chunk callback cannot be nil")
+	}
+	return &SnapshotChunkWriter{chunkSize: chunkSize, onChunk: onChunk}, nil
+}
+
+// Write implements io.Writer.
+func (w *SnapshotChunkWriter) Write(data []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("cannot write to closed chunk writer")
+	}
+	written := 0
+	for len(data) > 0 {
+		n := w.chunkSize - w.buf.Len()
+		if n > len(data) {
+			n = len(data)
+		}
+		w.buf.Write(data[:n])
+		data = data[n:]
+		written += n
+		if w.buf.Len() == w.chunkSize {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// flush emits the buffered data as a chunk.
+func (w *SnapshotChunkWriter) flush() error {
+	err := w.onChunk(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// Close emits the final chunk, if any. It is safe to call multiple times.
+func (w *SnapshotChunkWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.buf.Len() > 0 {
+		return w.flush()
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package iavl
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+
+	iavlproto "github.com/cosmos/iavl/proto"
+)
+
+// importSnapshotStore imports the nodes of the current snapshot store into a new tree.
+func importSnapshotStore(t *testing.T, reader *SnapshotReader, version int64) *MutableTree {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	importer, err := tree.Import(version)
+	require.NoError(t, err)
+	defer importer.Close()
+
+	for {
+		node, err := reader.Next()
+		if err == ExportDone {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, importer.Add(node))
+	}
+	require.NoError(t, importer.Commit())
+	return tree
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	trees := map[string]*ImmutableTree{
+		"basic": setupExportTreeBasic(t),
+		"empty": NewImmutableTree(db.NewMemDB(), 0),
+		"sized": setupExportTreeSized(t, 1024),
+	}
+	names := []string{"basic", "empty", "sized"}
+
+	chunks := [][]byte{}
+	chunkWriter, err := NewSnapshotChunkWriter(1024, func(chunk []byte) error {
+		chunks = append(chunks, append([]byte{}, chunk...))
+		return nil
+	})
+	require.NoError(t, err)
+	writer, err := NewSnapshotWriter(chunkWriter)
+	require.NoError(t, err)
+	for _, name := range names {
+		exporter := trees[name].Export()
+		require.NoError(t, writer.WriteExport(name, exporter))
+		exporter.Close()
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, chunkWriter.Close())
+	require.Greater(t, len(chunks), 1)
+
+	readers := make([]io.Reader, 0, len(chunks))
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, len(chunk), 1024)
+		readers = append(readers, bytes.NewReader(chunk))
+	}
+	reader, err := NewSnapshotReader(io.MultiReader(readers...))
+	require.NoError(t, err)
+	defer reader.Close()
+
+	for _, name := range names {
+		storeName, err := reader.NextStore()
+		require.NoError(t, err)
+		require.Equal(t, name, storeName)
+
+		tree := trees[name]
+		newTree := importSnapshotStore(t, reader, tree.Version())
+		require.Equal(t, tree.Hash(), newTree.Hash())
+		require.Equal(t, tree.Size(), newTree.Size())
+	}
+	_, err = reader.NextStore()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestSnapshot_SkipStore(t *testing.T) {
+	tree := setupExportTreeBasic(t)
+	buf := &bytes.Buffer{}
+	writer, err := NewSnapshotWriter(buf)
+	require.NoError(t, err)
+	for _, name := range []string{"a", "b"} {
+		exporter := tree.Export()
+		require.NoError(t, writer.WriteExport(name, exporter))
+		exporter.Close()
+	}
+	require.NoError(t, writer.Close())
+
+	reader, err := NewSnapshotReader(buf)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	name, err := reader.NextStore()
+	require.NoError(t, err)
+	require.Equal(t, "a", name)
+	_, err = reader.Next()
+	require.NoError(t, err)
+
+	name, err = reader.NextStore()
+	require.NoError(t, err)
+	require.Equal(t, "b", name)
+	newTree := importSnapshotStore(t, reader, tree.Version())
+	require.Equal(t, tree.Hash(), newTree.Hash())
+
+	_, err = reader.NextStore()
+	require.Equal(t, io.EOF, err)
+	_, err = reader.Next()
+	require.Equal(t, ExportDone, err)
+}
+
+func TestSnapshot_EmptyKeyValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := NewSnapshotWriter(buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteStore("store"))
+	require.NoError(t, writer.WriteNode(&ExportNode{Key: []byte{}, Value: []byte{}, Version: 1, Height: 0}))
+	require.NoError(t, writer.WriteNode(&ExportNode{Key: []byte{}, Value: nil, Version: 1, Height: 1}))
+	require.NoError(t, writer.Close())
+
+	reader, err := NewSnapshotReader(buf)
+	require.NoError(t, err)
+	defer reader.Close()
+	_, err = reader.NextStore()
+	require.NoError(t, err)
+
+	node, err := reader.Next()
+	require.NoError(t, err)
+	require.Equal(t, &ExportNode{Key: []byte{}, Value: []byte{}, Version: 1, Height: 0}, node)
+	node, err = reader.Next()
+	require.NoError(t, err)
+	require.Equal(t, &ExportNode{Key: []byte{}, Value: nil, Version: 1, Height: 1}, node)
+}
+
+func TestSnapshot_InvalidHeight(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := NewSnapshotWriter(buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteStore("store"))
+	require.NoError(t, writer.protoWriter.WriteMsg(&iavlproto.SnapshotItem{
+		Item: &iavlproto.SnapshotItem_Iavl{
+			Iavl: &iavlproto.SnapshotIAVLItem{Key: []byte("a"), Version: 1, Height: 128},
+		},
+	}))
+	require.NoError(t, writer.Close())
+
+	reader, err := NewSnapshotReader(buf)
+	require.NoError(t, err)
+	defer reader.Close()
+	_, err = reader.NextStore()
+	require.NoError(t, err)
+	_, err = reader.Next()
+	require.Error(t, err)
+}
+
+func TestSnapshot_UnknownItem(t *testing.T) {
+	// An item with an unknown field, such as an SDK extension item, ends the stores.
+	extension, err := proto.Marshal(&iavlproto.SnapshotStoreItem{Name: "extension"})
+	require.NoError(t, err)
+	unknown := append([]byte{0x1a, byte(len(extension))}, extension...)
+
+	buf := &bytes.Buffer{}
+	writer, err := NewSnapshotWriter(buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteStore("store"))
+	_, err = writer.zWriter.Write(append([]byte{byte(len(unknown))}, unknown...))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	reader, err := NewSnapshotReader(buf)
+	require.NoError(t, err)
+	defer reader.Close()
+	_, err = reader.NextStore()
+	require.NoError(t, err)
+	_, err = reader.Next()
+	require.Equal(t, ExportDone, err)
+	_, err = reader.NextStore()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestSnapshot_InvalidData(t *testing.T) {
+	_, err := NewSnapshotReader(bytes.NewReader([]byte("invalid")))
+	require.Error(t, err)
+}
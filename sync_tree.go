@@ -0,0 +1,582 @@
+package iavl
+
+import (
+	"sync"
+	"sync/atomic"
+
+	ics23 "github.com/confio/ics23/go"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// SyncMutableTree is a goroutine-safe wrapper around MutableTree. Reads take a shared read lock,
+// while writes take an exclusive lock, such that any number of readers can run concurrently with
+// each other but not with writers.
+//
+// Hashing the working tree and generating proofs for it computes and stores node hashes, so
+// WorkingHash() and the working tree proof methods take the exclusive lock too. The versioned
+// variants only read saved nodes, and take the read lock.
+//
+// Callbacks given to Iterate and Stream methods run while holding the lock, and open iterators
+// hold the read lock until they are closed or exhausted. Since a waiting writer blocks new
+// readers, the tree must not be accessed from within callbacks, nor by the goroutine holding an
+// open iterator, as this may deadlock.
+//
+// Similarly, exporters hold the read lock until they are closed, and importers hold the exclusive
+// lock until they are committed or closed, so callers must always close them. The underlying tree
+// must not be accessed directly after wrapping.
+type SyncMutableTree struct {
+	mtx  sync.RWMutex
+	tree *MutableTree
+}
+
+// NewSyncMutableTree wraps the given tree, making it safe for concurrent use.
+func NewSyncMutableTree(tree *MutableTree) *SyncMutableTree {
+	return &SyncMutableTree{tree: tree}
+}
+
+// IsEmpty returns whether or not the working tree has any keys.
+func (t *SyncMutableTree) IsEmpty() bool {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.IsEmpty()
+}
+
+// Size returns the number of leaf nodes in the working tree.
+func (t *SyncMutableTree) Size() int64 {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Size()
+}
+
+// Height returns the height of the working tree.
+func (t *SyncMutableTree) Height() int8 {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Height()
+}
+
+// Version returns the version of the latest saved or loaded tree.
+func (t *SyncMutableTree) Version() int64 {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Version()
+}
+
+// VersionExists returns whether or not a version exists.
+func (t *SyncMutableTree) VersionExists(version int64) bool {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.VersionExists(version)
+}
+
+// AvailableVersions returns all available versions in ascending order.
+func (t *SyncMutableTree) AvailableVersions() []int {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.AvailableVersions()
+}
+
+// Hash returns the hash of the latest saved version of the tree.
+func (t *SyncMutableTree) Hash() []byte {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Hash()
+}
+
+// WorkingHash returns the hash of the working tree. It takes the exclusive lock, since it
+// computes and stores the hashes of modified nodes.
+func (t *SyncMutableTree) WorkingHash() []byte {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.WorkingHash()
+}
+
+// String returns a string representation of the tree.
+func (t *SyncMutableTree) String() string {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.String()
+}
+
+// Has returns whether or not a key exists in the working tree.
+func (t *SyncMutableTree) Has(key []byte) bool {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Has(key)
+}
+
+// Get returns the index and value of the given key in the working tree. See ImmutableTree.Get().
+func (t *SyncMutableTree) Get(key []byte) (index int64, value []byte) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Get(key)
+}
+
+// GetByIndex returns the key and value at the given index in the working tree.
+func (t *SyncMutableTree) GetByIndex(index int64) (key []byte, value []byte) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetByIndex(index)
+}
+
+// GetVersioned returns the index and value of the given key at the given version.
+func (t *SyncMutableTree) GetVersioned(key []byte, version int64) (index int64, value []byte) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetVersioned(key, version)
+}
+
+// GetImmutable returns the immutable tree at the given version. The returned tree is safe for
+// concurrent use on its own, as long as the version is not deleted.
+func (t *SyncMutableTree) GetImmutable(version int64) (*ImmutableTree, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetImmutable(version)
+}
+
+// GetEncodedNode returns the persisted node with the given hash in its canonical encoding. See
+// ImmutableTree.GetEncodedNode().
+func (t *SyncMutableTree) GetEncodedNode(hash []byte) ([]byte, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetEncodedNode(hash)
+}
+
+// RenderShape provides a nested shape of the working tree. See ImmutableTree.RenderShape().
+func (t *SyncMutableTree) RenderShape(indent string, encoder NodeEncoder) []string {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.RenderShape(indent, encoder)
+}
+
+// GetWithProof returns the value of the given key in the working tree along with a range proof.
+// It takes the exclusive lock, since it computes and stores the hashes of modified nodes.
+func (t *SyncMutableTree) GetWithProof(key []byte) ([]byte, *RangeProof, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.GetWithProof(key)
+}
+
+// GetRangeWithProof returns the keys and values in the given range of the working tree along with
+// a range proof. It takes the exclusive lock, since it computes and stores the hashes of modified
+// nodes.
+func (t *SyncMutableTree) GetRangeWithProof(startKey, endKey []byte, limit int) (
+	keys, values [][]byte, proof *RangeProof, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.GetRangeWithProof(startKey, endKey, limit)
+}
+
+// GetVersionedWithProof returns the value of the given key at the given version along with a
+// range proof.
+func (t *SyncMutableTree) GetVersionedWithProof(key []byte, version int64) ([]byte, *RangeProof, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetVersionedWithProof(key, version)
+}
+
+// GetVersionedRangeWithProof returns the keys and values in the given range at the given version
+// along with a range proof.
+func (t *SyncMutableTree) GetVersionedRangeWithProof(startKey, endKey []byte, limit int, version int64) (
+	keys, values [][]byte, proof *RangeProof, err error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetVersionedRangeWithProof(startKey, endKey, limit, version)
+}
+
+// GetWithValueHashProof returns the hash of the value of the given key in the working tree along
+// with a range proof. It takes the exclusive lock, since it computes and stores the hashes of
+// modified nodes.
+func (t *SyncMutableTree) GetWithValueHashProof(key []byte) (valueHash []byte, proof *RangeProof, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.GetWithValueHashProof(key)
+}
+
+// GetVersionedWithValueHashProof returns the hash of the value of the given key at the given
+// version along with a range proof.
+func (t *SyncMutableTree) GetVersionedWithValueHashProof(key []byte, version int64) ([]byte, *RangeProof, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.GetVersionedWithValueHashProof(key, version)
+}
+
+// SampleWithProofs returns n pseudo-random keys of the given version with their values and proofs.
+// See MutableTree.SampleWithProofs().
+func (t *SyncMutableTree) SampleWithProofs(version int64, seed []byte, n int) ([]KeySample, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.SampleWithProofs(version, seed, n)
+}
+
+// StreamRangeWithProof streams the keys and values in the given range of the working tree along
+// with a range proof, see ImmutableTree.StreamRangeWithProof(). It holds the exclusive lock while
+// streaming, since it computes and stores the hashes of modified nodes.
+func (t *SyncMutableTree) StreamRangeWithProof(start, end []byte,
+	fn func(item RangeProofItem, value []byte, inRange bool) error) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.StreamRangeWithProof(start, end, fn)
+}
+
+// StreamVersionedRangeWithProof streams the keys and values in the given range at the given
+// version along with a range proof, holding the read lock while streaming. See
+// MutableTree.StreamVersionedRangeWithProof().
+func (t *SyncMutableTree) StreamVersionedRangeWithProof(start, end []byte, version int64,
+	fn func(item RangeProofItem, value []byte, inRange bool) error) error {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.StreamVersionedRangeWithProof(start, end, version, fn)
+}
+
+// GetMembershipProof returns an ICS23 existence proof for the given key in the working tree. It
+// takes the exclusive lock, since it computes and stores the hashes of modified nodes.
+func (t *SyncMutableTree) GetMembershipProof(key []byte) (*ics23.CommitmentProof, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.GetMembershipProof(key)
+}
+
+// GetNonMembershipProof returns an ICS23 non-existence proof for the given key in the working
+// tree. It takes the exclusive lock, since it computes and stores the hashes of modified nodes.
+func (t *SyncMutableTree) GetNonMembershipProof(key []byte) (*ics23.CommitmentProof, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.GetNonMembershipProof(key)
+}
+
+// GetValueHashMembershipProof returns an ICS23 existence proof for the given key in the working
+// tree which does not disclose its value. It takes the exclusive lock, since it computes and
+// stores the hashes of modified nodes.
+func (t *SyncMutableTree) GetValueHashMembershipProof(key []byte) (*ics23.CommitmentProof, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.GetValueHashMembershipProof(key)
+}
+
+// Iterate iterates over all keys of the working tree in order, holding the read lock.
+func (t *SyncMutableTree) Iterate(fn func(key []byte, value []byte) bool) (stopped bool) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.Iterate(fn)
+}
+
+// IterateRange iterates over the keys in the given range of the working tree, holding the read
+// lock. See ImmutableTree.IterateRange().
+func (t *SyncMutableTree) IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.IterateRange(start, end, ascending, fn)
+}
+
+// IterateRangeInclusive iterates over the keys in the given inclusive range of the working tree,
+// holding the read lock. See ImmutableTree.IterateRangeInclusive().
+func (t *SyncMutableTree) IterateRangeInclusive(start, end []byte, ascending bool, fn func(key, value []byte, version int64) bool) (stopped bool) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.IterateRangeInclusive(start, end, ascending, fn)
+}
+
+// Iterator returns an iterator over the given range of the working tree. It holds the read lock
+// until it is closed or exhausted, so callers must always close it.
+func (t *SyncMutableTree) Iterator(start, end []byte, ascending bool) dbm.Iterator {
+	t.mtx.RLock()
+	iter := &syncIterator{Iterator: t.tree.Iterator(start, end, ascending), unlock: t.mtx.RUnlock}
+	if !iter.Valid() {
+		iter.release()
+	}
+	return iter
+}
+
+// Export returns an exporter for the working tree. It holds the read lock until it is closed, so
+// callers must always close it.
+func (t *SyncMutableTree) Export() *Exporter {
+	t.mtx.RLock()
+	exporter := t.tree.Export()
+	exporter.onClose = t.mtx.RUnlock
+	return exporter
+}
+
+// ExportSegments splits the working tree into independently exported segments. See
+// ImmutableTree.ExportSegments(). The exporters share the read lock, which is held until all of
+// them are closed.
+func (t *SyncMutableTree) ExportSegments(depth int) (top *Exporter, segments []*Exporter, err error) {
+	t.mtx.RLock()
+	top, segments, err = t.tree.ExportSegments(depth)
+	if err != nil {
+		t.mtx.RUnlock()
+		return nil, nil, err
+	}
+	open := int32(len(segments) + 1)
+	release := func() {
+		if atomic.AddInt32(&open, -1) == 0 {
+			t.mtx.RUnlock()
+		}
+	}
+	top.onClose = release
+	for _, segment := range segments {
+		segment.onClose = release
+	}
+	return top, segments, nil
+}
+
+// Import returns an importer into the empty tree. See MutableTree.Import(). It holds the exclusive
+// lock until it is committed or closed, so callers must always close it.
+func (t *SyncMutableTree) Import(version int64) (*Importer, error) {
+	t.mtx.Lock()
+	importer, err := t.tree.Import(version)
+	if err != nil {
+		t.mtx.Unlock()
+		return nil, err
+	}
+	importer.onClose = t.mtx.Unlock
+	return importer, nil
+}
+
+// ImportParallel returns a parallel importer into the empty tree. See MutableTree.ImportParallel().
+// It holds the exclusive lock until it is committed or closed, so callers must always close it.
+func (t *SyncMutableTree) ImportParallel(version int64) (*ParallelImporter, error) {
+	t.mtx.Lock()
+	importer, err := t.tree.ImportParallel(version)
+	if err != nil {
+		t.mtx.Unlock()
+		return nil, err
+	}
+	importer.top.onClose = t.mtx.Unlock
+	return importer, nil
+}
+
+// Set sets a key in the working tree. See MutableTree.Set().
+func (t *SyncMutableTree) Set(key, value []byte) (updated bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.Set(key, value)
+}
+
+// Remove removes a key from the working tree. See MutableTree.Remove().
+func (t *SyncMutableTree) Remove(key []byte) ([]byte, bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.Remove(key)
+}
+
+// MovePrefix moves all keys from one prefix to another. See MutableTree.MovePrefix().
+func (t *SyncMutableTree) MovePrefix(from, to []byte) (int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.MovePrefix(from, to)
+}
+
+// SaveVersion saves a new tree version. See MutableTree.SaveVersion().
+func (t *SyncMutableTree) SaveVersion() ([]byte, int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.SaveVersion()
+}
+
+// SaveVersionInto saves a new tree version into the given batch. See MutableTree.SaveVersionInto().
+func (t *SyncMutableTree) SaveVersionInto(batch dbm.Batch) ([]byte, int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.SaveVersionInto(batch)
+}
+
+// FinalizeVersion finalizes the version saved by SaveVersionInto(). See
+// MutableTree.FinalizeVersion().
+func (t *SyncMutableTree) FinalizeVersion() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.FinalizeVersion()
+}
+
+// DiscardVersion discards the version saved by SaveVersionInto(). See
+// MutableTree.DiscardVersion().
+func (t *SyncMutableTree) DiscardVersion() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.DiscardVersion()
+}
+
+// Rebuild rewrites the latest saved version into a canonical tree as a new version. See
+// MutableTree.Rebuild().
+func (t *SyncMutableTree) Rebuild() (*RebuildResult, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.Rebuild()
+}
+
+// Migrate migrates the keys in a range of the latest saved version as a new version. See
+// MutableTree.Migrate().
+func (t *SyncMutableTree) Migrate(opts MigrationOptions) ([]byte, int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.Migrate(opts)
+}
+
+// AbortMigration discards an interrupted migration. See MutableTree.AbortMigration().
+func (t *SyncMutableTree) AbortMigration() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.AbortMigration()
+}
+
+// Rollback discards all changes to the working tree since the last saved version.
+func (t *SyncMutableTree) Rollback() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.tree.Rollback()
+}
+
+// Load loads the latest version. See MutableTree.Load().
+func (t *SyncMutableTree) Load() (int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.Load()
+}
+
+// LoadVersion loads the given version. See MutableTree.LoadVersion().
+func (t *SyncMutableTree) LoadVersion(targetVersion int64) (int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.LoadVersion(targetVersion)
+}
+
+// LazyLoadVersion loads the given version lazily. See MutableTree.LazyLoadVersion().
+func (t *SyncMutableTree) LazyLoadVersion(targetVersion int64) (int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.LazyLoadVersion(targetVersion)
+}
+
+// LoadVersionForOverwriting loads the given version and deletes all newer versions. See
+// MutableTree.LoadVersionForOverwriting().
+func (t *SyncMutableTree) LoadVersionForOverwriting(targetVersion int64) (int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.LoadVersionForOverwriting(targetVersion)
+}
+
+// DryRunLoadVersionForOverwriting reports what LoadVersionForOverwriting() would remove. See
+// MutableTree.DryRunLoadVersionForOverwriting().
+func (t *SyncMutableTree) DryRunLoadVersionForOverwriting(targetVersion int64) (OverwriteReport, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.DryRunLoadVersionForOverwriting(targetVersion)
+}
+
+// SoftLoadVersionForOverwriting loads the given version and moves all newer versions into the
+// trash. See MutableTree.SoftLoadVersionForOverwriting().
+func (t *SyncMutableTree) SoftLoadVersionForOverwriting(targetVersion int64) (OverwriteReport, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.SoftLoadVersionForOverwriting(targetVersion)
+}
+
+// OverwrittenVersions returns the versions in the trash. See MutableTree.OverwrittenVersions().
+func (t *SyncMutableTree) OverwrittenVersions() ([]int64, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.OverwrittenVersions()
+}
+
+// RestoreOverwritten restores the versions in the trash. See MutableTree.RestoreOverwritten().
+func (t *SyncMutableTree) RestoreOverwritten() (int64, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.RestoreOverwritten()
+}
+
+// PurgeTrash permanently deletes the versions in the trash. See MutableTree.PurgeTrash().
+func (t *SyncMutableTree) PurgeTrash() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.PurgeTrash()
+}
+
+// SetInitialVersion sets the initial version of the tree. See MutableTree.SetInitialVersion().
+func (t *SyncMutableTree) SetInitialVersion(version uint64) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.tree.SetInitialVersion(version)
+}
+
+// DeleteVersion deletes a tree version. See MutableTree.DeleteVersion().
+func (t *SyncMutableTree) DeleteVersion(version int64) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.DeleteVersion(version)
+}
+
+// DeleteVersions deletes the given tree versions. See MutableTree.DeleteVersions().
+func (t *SyncMutableTree) DeleteVersions(versions ...int64) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.DeleteVersions(versions...)
+}
+
+// DeleteVersionsRange deletes the tree versions in the given range. See
+// MutableTree.DeleteVersionsRange().
+func (t *SyncMutableTree) DeleteVersionsRange(fromVersion, toVersion int64) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.tree.DeleteVersionsRange(fromVersion, toVersion)
+}
+
+// StartAudit starts a background self-audit of the tree. See MutableTree.StartAudit(). The audit
+// only reads saved versions, and runs concurrently with other operations.
+func (t *SyncMutableTree) StartAudit(opts AuditOptions) (*Auditor, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.StartAudit(opts)
+}
+
+// ShadowStats returns the shadow write metrics. See MutableTree.ShadowStats().
+func (t *SyncMutableTree) ShadowStats() ShadowStats {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.ShadowStats()
+}
+
+// ColdStats returns the cold storage metrics. See MutableTree.ColdStats().
+func (t *SyncMutableTree) ColdStats() ColdStats {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.ColdStats()
+}
+
+// StartColdMover starts moving old nodes to the cold database in the background. See
+// MutableTree.StartColdMover(). The mover runs concurrently with other operations.
+func (t *SyncMutableTree) StartColdMover(opts ColdMoverOptions) (*ColdMover, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tree.StartColdMover(opts)
+}
+
+// syncIterator is an Iterator holding a read lock until it is closed or exhausted.
+type syncIterator struct {
+	*Iterator
+	unlock func()
+	once   sync.Once
+}
+
+var _ dbm.Iterator = (*syncIterator)(nil)
+
+// release releases the read lock, if it is still held.
+func (iter *syncIterator) release() {
+	iter.once.Do(iter.unlock)
+}
+
+// Next implements dbm.Iterator.
+func (iter *syncIterator) Next() {
+	iter.Iterator.Next()
+	if !iter.Valid() {
+		iter.release()
+	}
+}
+
+// Close implements dbm.Iterator.
+func (iter *syncIterator) Close() error {
+	err := iter.Iterator.Close()
+	iter.release()
+	return err
+}
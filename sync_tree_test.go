@@ -0,0 +1,222 @@
+package iavl
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func setupSyncMutableTree(t *testing.T) *SyncMutableTree {
+	tree, err := NewMutableTree(db.NewMemDB(), 100)
+	require.NoError(t, err)
+	return NewSyncMutableTree(tree)
+}
+
+// TestSyncMutableTree_Concurrent runs concurrent writers and readers over the complete API. It is
+// primarily useful with the race detector enabled.
+func TestSyncMutableTree_Concurrent(t *testing.T) {
+	const (
+		writers = 4
+		readers = 4
+		keys    = 200
+	)
+	tree := setupSyncMutableTree(t)
+	for i := 0; i < keys; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte{0})
+	}
+	_, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers+readers)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < keys; i++ {
+				key := []byte(fmt.Sprintf("key-%03d", i))
+				if i%writers == w {
+					tree.Set(key, []byte{byte(w), byte(i)})
+				}
+				if i%50 == 0 {
+					if _, _, err := tree.SaveVersion(); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+			tree.Remove([]byte(fmt.Sprintf("key-%03d", w)))
+			tree.WorkingHash()
+			if _, _, err := tree.GetWithProof([]byte("key-100")); err != nil {
+				errs <- err
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < keys; i++ {
+				key := []byte(fmt.Sprintf("key-%03d", i))
+				tree.Get(key)
+				tree.Has(key)
+				tree.GetByIndex(int64(i))
+				tree.Size()
+				tree.Hash()
+				version := tree.Version()
+				tree.GetVersioned(key, version)
+				if _, _, err := tree.GetVersionedWithProof(key, version); err != nil && version > 0 {
+					errs <- err
+					return
+				}
+				if i%20 == 0 {
+					tree.Iterate(func(key, value []byte) bool { return false })
+					iter := tree.Iterator(nil, nil, true)
+					for ; iter.Valid(); iter.Next() {
+						_ = iter.Key()
+					}
+					if err := iter.Close(); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	_, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+	require.True(t, tree.VersionExists(version))
+	require.EqualValues(t, keys-writers, tree.Size())
+	for w := 0; w < writers; w++ {
+		require.False(t, tree.Has([]byte(fmt.Sprintf("key-%03d", w))))
+	}
+	for i := writers; i < keys; i++ {
+		_, value := tree.Get([]byte(fmt.Sprintf("key-%03d", i)))
+		require.Equal(t, []byte{byte(i % writers), byte(i)}, value)
+	}
+}
+
+func TestSyncMutableTree_IteratorReleasesLock(t *testing.T) {
+	tree := setupSyncMutableTree(t)
+	tree.Set([]byte("a"), []byte{1})
+	tree.Set([]byte("b"), []byte{2})
+
+	// An exhausted iterator releases the lock, even if it is not closed.
+	iter := tree.Iterator(nil, nil, true)
+	for ; iter.Valid(); iter.Next() {
+	}
+	tree.Set([]byte("c"), []byte{3})
+	require.NoError(t, iter.Close())
+
+	// A closed iterator releases the lock, and can be closed again.
+	iter = tree.Iterator(nil, nil, false)
+	require.True(t, iter.Valid())
+	require.Equal(t, []byte("c"), iter.Key())
+	require.NoError(t, iter.Close())
+	require.NoError(t, iter.Close())
+	tree.Set([]byte("d"), []byte{4})
+
+	// An empty iterator releases the lock immediately.
+	iter = tree.Iterator([]byte("x"), []byte("y"), true)
+	require.False(t, iter.Valid())
+	tree.Set([]byte("e"), []byte{5})
+	require.NoError(t, iter.Close())
+
+	// An open iterator blocks writers until it is closed.
+	iter = tree.Iterator(nil, nil, true)
+	done := make(chan struct{})
+	go func() {
+		tree.Set([]byte("f"), []byte{6})
+		close(done)
+	}()
+	count := 0
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+	<-done
+	require.Equal(t, 5, count)
+	require.EqualValues(t, 6, tree.Size())
+}
+
+func TestSyncMutableTree_ExportImport(t *testing.T) {
+	tree := setupSyncMutableTree(t)
+	for i := 0; i < 50; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte{byte(i)})
+	}
+	hash, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	// The exporter holds the read lock, so other readers can run until it is closed.
+	exporter := tree.Export()
+	require.EqualValues(t, 50, tree.Size())
+	newTree := setupSyncMutableTree(t)
+	importer, err := newTree.Import(version)
+	require.NoError(t, err)
+	for {
+		item, err := exporter.Next()
+		if err == ExportDone {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, importer.Add(item))
+	}
+	exporter.Close()
+	exporter.Close()
+	tree.Set([]byte("a"), []byte{1})
+
+	require.NoError(t, importer.Commit())
+	importer.Close()
+	require.Equal(t, hash, newTree.Hash())
+
+	// Segment exporters share the read lock until all of them are closed, and a failed parallel
+	// import releases the exclusive lock.
+	top, segments, err := tree.ExportSegments(2)
+	require.NoError(t, err)
+	newTree = setupSyncMutableTree(t)
+	parallel, err := newTree.ImportParallel(version)
+	require.NoError(t, err)
+	for _, segment := range segments {
+		require.NoError(t, parallel.AddSegment(segment))
+	}
+	require.Error(t, parallel.Commit(top, []byte("invalid")))
+	top.Close()
+	for _, segment := range segments {
+		segment.Close()
+	}
+	tree.Set([]byte("b"), []byte{2})
+	require.True(t, newTree.IsEmpty())
+}
+
+// TestSyncMutableTree_CompleteAPI checks that every exported MutableTree method is wrapped, such
+// that APIs added to MutableTree are added to SyncMutableTree along with them.
+func TestSyncMutableTree_CompleteAPI(t *testing.T) {
+	syncType := reflect.TypeOf(&SyncMutableTree{})
+	treeType := reflect.TypeOf(&MutableTree{})
+	for i := 0; i < treeType.NumMethod(); i++ {
+		method := treeType.Method(i)
+		wrapper, ok := syncType.MethodByName(method.Name)
+		require.True(t, ok, "SyncMutableTree does not wrap %v()", method.Name)
+		// Compare signatures without the receiver. Results may be wrapped in interfaces, e.g. the
+		// iterator holding the read lock.
+		require.Equal(t, method.Type.NumIn(), wrapper.Type.NumIn(), method.Name)
+		for j := 1; j < method.Type.NumIn(); j++ {
+			require.Equal(t, method.Type.In(j), wrapper.Type.In(j), method.Name)
+		}
+		require.Equal(t, method.Type.NumOut(), wrapper.Type.NumOut(), method.Name)
+		for j := 0; j < method.Type.NumOut(); j++ {
+			require.True(t, method.Type.Out(j).AssignableTo(wrapper.Type.Out(j)), method.Name)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package iavl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultSlowNodeReadThreshold is the default minimum duration of a node database read to be
+// traced.
+const DefaultSlowNodeReadThreshold = 10 * time.Millisecond
+
+// Span names used by IAVL.
+const (
+	SpanSaveVersion            = "iavl.SaveVersion"
+	SpanSaveVersionHash        = "iavl.SaveVersion.hash"
+	SpanSaveVersionSaveBranch  = "iavl.SaveVersion.saveBranch"
+	SpanSaveVersionSaveOrphans = "iavl.SaveVersion.saveOrphans"
+	SpanSaveVersionCommit      = "iavl.SaveVersion.commit"
+	SpanLoadVersion            = "iavl.LoadVersion"
+	SpanDeleteVersion          = "iavl.DeleteVersion"
+	SpanDeleteVersionsRange    = "iavl.DeleteVersionsRange"
+	SpanExport                 = "iavl.Export"
+	SpanImport                 = "iavl.Import"
+	SpanGetNode                = "iavl.GetNode" // only slow database reads
+)
+
+// Tracer receives spans around tree operations, e.g. the phases of SaveVersion(), which can be
+// used to find out why a particular commit was slow. It is set via Options.Tracer, and must be
+// safe for concurrent use.
+type Tracer interface {
+	// StartSpan starts a span with the given name at the given time. The span is ended by
+	// calling End() on the returned span.
+	StartSpan(name string, start time.Time) Span
+}
+
+// Span is a traced operation. It is only used by a single goroutine.
+type Span interface {
+	// SetAttribute sets an attribute of the span, e.g. a node count.
+	SetAttribute(key string, value interface{})
+	// End ends the span.
+	End()
+}
+
+// NopTracer is a Tracer which discards all spans. It is used when Options.Tracer is nil.
+type NopTracer struct{}
+
+var _ Tracer = NopTracer{}
+
+// StartSpan implements Tracer.
+func (NopTracer) StartSpan(name string, start time.Time) Span {
+	return nopSpan{}
+}
+
+// nopSpan is a span which does nothing.
+type nopSpan struct{}
+
+// SetAttribute implements Span.
+func (nopSpan) SetAttribute(key string, value interface{}) {}
+
+// End implements Span.
+func (nopSpan) End() {}
+
+// startSpan starts a span with the configured tracer, if any.
+func (ndb *nodeDB) startSpan(name string) Span {
+	if ndb.opts.Tracer == nil {
+		return nopSpan{}
+	}
+	return ndb.opts.Tracer.StartSpan(name, time.Now())
+}
+
+// traceNodeRead traces a node database read started at the given time, if it was slow.
+func (ndb *nodeDB) traceNodeRead(hash []byte, size int, start time.Time) {
+	threshold := ndb.opts.SlowNodeReadThreshold
+	if threshold == 0 {
+		threshold = DefaultSlowNodeReadThreshold
+	}
+	if time.Since(start) < threshold {
+		return
+	}
+	span := ndb.opts.Tracer.StartSpan(SpanGetNode, start)
+	span.SetAttribute("hash", fmt.Sprintf("%X", hash))
+	span.SetAttribute("bytes", size)
+	span.End()
+}
+
+// RecordedSpan is a span recorded by a TraceRecorder.
+type RecordedSpan struct {
+	Name       string
+	Start      time.Time
+	Duration   time.Duration
+	Attributes map[string]interface{}
+}
+
+// TraceRecorder is a Tracer which records spans in memory, and writes them as a JSON trace in the
+// Trace Event Format, which can be opened with trace viewers such as chrome://tracing or Perfetto.
+type TraceRecorder struct {
+	mtx   sync.Mutex
+	spans []RecordedSpan
+}
+
+var _ Tracer = (*TraceRecorder)(nil)
+
+// NewTraceRecorder creates a new trace recorder.
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{}
+}
+
+// StartSpan implements Tracer.
+func (r *TraceRecorder) StartSpan(name string, start time.Time) Span {
+	return &recorderSpan{
+		recorder: r,
+		span:     RecordedSpan{Name: name, Start: start, Attributes: map[string]interface{}{}},
+	}
+}
+
+// Spans returns the ended spans, in the order they ended.
+func (r *TraceRecorder) Spans() []RecordedSpan {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]RecordedSpan{}, r.spans...)
+}
+
+// Reset discards all recorded spans.
+func (r *TraceRecorder) Reset() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.spans = nil
+}
+
+// traceEvent is a complete event in the Trace Event Format.
+type traceEvent struct {
+	Name  string                 `json:"name"`
+	Phase string                 `json:"ph"`
+	TS    float64                `json:"ts"`  // microseconds
+	Dur   float64                `json:"dur"` // microseconds
+	PID   int                    `json:"pid"`
+	TID   int                    `json:"tid"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+// WriteJSON writes the recorded spans as a JSON trace. Spans are assigned to threads such that the
+// spans of each thread are properly nested, since e.g. exports run concurrently with commits.
+func (r *TraceRecorder) WriteJSON(w io.Writer) error {
+	spans := r.Spans()
+	sort.SliceStable(spans, func(i, j int) bool {
+		if !spans[i].Start.Equal(spans[j].Start) {
+			return spans[i].Start.Before(spans[j].Start)
+		}
+		return spans[i].Duration > spans[j].Duration
+	})
+
+	var threads [][]time.Time // stacks of open span end times
+	events := make([]traceEvent, 0, len(spans))
+	for _, span := range spans {
+		end := span.Start.Add(span.Duration)
+		tid := -1
+		for i, stack := range threads {
+			for len(stack) > 0 && !stack[len(stack)-1].After(span.Start) {
+				stack = stack[:len(stack)-1]
+			}
+			threads[i] = stack
+			if tid < 0 && (len(stack) == 0 || !end.After(stack[len(stack)-1])) {
+				tid = i
+			}
+		}
+		if tid < 0 {
+			tid = len(threads)
+			threads = append(threads, nil)
+		}
+		threads[tid] = append(threads[tid], end)
+
+		events = append(events, traceEvent{
+			Name:  span.Name,
+			Phase: "X",
+			TS:    float64(span.Start.UnixNano()) / 1e3,
+			Dur:   float64(span.Duration.Nanoseconds()) / 1e3,
+			PID:   1,
+			TID:   tid + 1,
+			Args:  span.Attributes,
+		})
+	}
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
+
+// recorderSpan is a span of a TraceRecorder.
+type recorderSpan struct {
+	recorder *TraceRecorder
+	span     RecordedSpan
+	ended    bool
+}
+
+// SetAttribute implements Span.
+func (s *recorderSpan) SetAttribute(key string, value interface{}) {
+	s.span.Attributes[key] = value
+}
+
+// End implements Span. Only the first call has any effect.
+func (s *recorderSpan) End() {
+	if s.ended {
+		return
+	}
+	s.ended = true
+	s.span.Duration = time.Since(s.span.Start)
+	s.recorder.mtx.Lock()
+	defer s.recorder.mtx.Unlock()
+	s.recorder.spans = append(s.recorder.spans, s.span)
+}
@@ -0,0 +1,159 @@
+package iavl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+// spanNames returns the names of the spans.
+func spanNames(spans []RecordedSpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+func TestTracing(t *testing.T) {
+	recorder := NewTraceRecorder()
+	memDB := db.NewMemDB()
+	tree, err := NewMutableTreeWithOpts(memDB, 0, &Options{Tracer: recorder})
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%03d", i)), []byte("value"))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+
+	spans := recorder.Spans()
+	require.Equal(t, []string{SpanSaveVersionHash, SpanSaveVersionSaveBranch, SpanSaveVersionSaveOrphans,
+		SpanSaveVersionCommit, SpanSaveVersion}, spanNames(spans))
+	require.EqualValues(t, 199, spans[0].Attributes["nodes"])
+	require.EqualValues(t, 199, spans[1].Attributes["nodes"])
+	require.Greater(t, spans[1].Attributes["bytes"].(int64), int64(0))
+	require.EqualValues(t, 0, spans[2].Attributes["orphans"])
+	require.EqualValues(t, 1, spans[4].Attributes["version"])
+	for _, span := range spans[:4] {
+		require.False(t, span.Start.Before(spans[4].Start))
+		require.LessOrEqual(t, int64(span.Duration), int64(spans[4].Duration))
+	}
+
+	// Pruning.
+	tree.Set([]byte("key-000"), []byte("new"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	recorder.Reset()
+	require.NoError(t, tree.DeleteVersion(1))
+	spans = recorder.Spans()
+	require.Equal(t, []string{SpanDeleteVersion}, spanNames(spans))
+	require.EqualValues(t, 1, spans[0].Attributes["version"])
+	require.EqualValues(t, 8, spans[0].Attributes["nodes"])
+
+	// Loading, and slow node reads.
+	recorder.Reset()
+	tree, err = NewMutableTreeWithOpts(memDB, 0, &Options{Tracer: recorder, SlowNodeReadThreshold: time.Nanosecond})
+	require.NoError(t, err)
+	_, err = tree.Load()
+	require.NoError(t, err)
+	_, value := tree.Get([]byte("key-050"))
+	require.Equal(t, []byte("value"), value)
+	spans = recorder.Spans()
+	require.Equal(t, SpanGetNode, spans[0].Name)
+	require.Equal(t, SpanLoadVersion, spans[1].Name)
+	require.EqualValues(t, 2, spans[1].Attributes["version"])
+	require.Len(t, spans, 2+int(tree.Height()))
+	for _, span := range spans[2:] {
+		require.Equal(t, SpanGetNode, span.Name)
+		require.Greater(t, span.Attributes["bytes"].(int), 0)
+	}
+
+	// Export and import.
+	recorder.Reset()
+	exporter := tree.Export()
+	for {
+		_, err = exporter.Next()
+		if err == ExportDone {
+			break
+		}
+		require.NoError(t, err)
+	}
+	exporter.Close()
+	exporter.Close()
+	spans = recorder.Spans()
+	require.Equal(t, SpanExport, spans[len(spans)-1].Name)
+	require.EqualValues(t, 199, spans[len(spans)-1].Attributes["nodes"])
+
+	target, err := NewMutableTreeWithOpts(db.NewMemDB(), 0, &Options{Tracer: recorder})
+	require.NoError(t, err)
+	importer, err := target.Import(tree.Version())
+	require.NoError(t, err)
+	exporter = tree.Export()
+	for {
+		node, err := exporter.Next()
+		if err == ExportDone {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, importer.Add(node))
+	}
+	exporter.Close()
+	recorder.Reset()
+	require.NoError(t, importer.Commit())
+	spans = recorder.Spans()
+	require.Equal(t, []string{SpanLoadVersion, SpanImport}, spanNames(spans))
+	require.EqualValues(t, 199, spans[1].Attributes["nodes"])
+}
+
+func TestTraceRecorder_WriteJSON(t *testing.T) {
+	start := time.Unix(1000, 0)
+	recorder := NewTraceRecorder()
+	recorder.spans = []RecordedSpan{
+		{Name: "commit", Start: start, Duration: 10 * time.Millisecond},
+		{Name: "hash", Start: start.Add(time.Millisecond), Duration: 2 * time.Millisecond,
+			Attributes: map[string]interface{}{"nodes": 3}},
+		{Name: "export", Start: start.Add(5 * time.Millisecond), Duration: 10 * time.Millisecond},
+		{Name: "save", Start: start.Add(3 * time.Millisecond), Duration: 4 * time.Millisecond},
+		{Name: "next", Start: start.Add(20 * time.Millisecond), Duration: time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, recorder.WriteJSON(&buf))
+	var trace struct {
+		TraceEvents []struct {
+			Name string                 `json:"name"`
+			Ph   string                 `json:"ph"`
+			TS   float64                `json:"ts"`
+			Dur  float64                `json:"dur"`
+			TID  int                    `json:"tid"`
+			Args map[string]interface{} `json:"args"`
+		} `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	require.Len(t, trace.TraceEvents, 5)
+
+	tids := map[string]int{}
+	for _, event := range trace.TraceEvents {
+		require.Equal(t, "X", event.Ph)
+		tids[event.Name] = event.TID
+	}
+	require.Equal(t, map[string]int{"commit": 1, "hash": 1, "save": 1, "export": 2, "next": 1}, tids)
+	require.Equal(t, "commit", trace.TraceEvents[0].Name)
+	require.Equal(t, 1000e6, trace.TraceEvents[0].TS)
+	require.Equal(t, 10e3, trace.TraceEvents[0].Dur)
+	require.EqualValues(t, 3, trace.TraceEvents[1].Args["nodes"])
+}
+
+func TestNopTracer(t *testing.T) {
+	tree, err := NewMutableTreeWithOpts(db.NewMemDB(), 0, &Options{Tracer: NopTracer{}})
+	require.NoError(t, err)
+	tree.Set([]byte("a"), []byte("b"))
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+}
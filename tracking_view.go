@@ -0,0 +1,253 @@
+package iavl
+
+import (
+	"bytes"
+	"sort"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// KeyRange is a range of keys from Start inclusive to End exclusive. A nil Start or End leaves
+// the range open on that side.
+type KeyRange struct {
+	Start []byte
+	End   []byte
+}
+
+// Contains returns whether the key is in the range.
+func (r KeyRange) Contains(key []byte) bool {
+	return (r.Start == nil || bytes.Compare(key, r.Start) >= 0) &&
+		(r.End == nil || bytes.Compare(key, r.End) < 0)
+}
+
+// ReadWriteSet is the set of keys read and written in a scope of a TrackingView, e.g. a
+// transaction. Reads include the key ranges of iterations, which also cover absent keys.
+type ReadWriteSet struct {
+	reads  map[string]struct{}
+	ranges []KeyRange
+	writes map[string]struct{}
+}
+
+// NewReadWriteSet creates an empty read/write set.
+func NewReadWriteSet() *ReadWriteSet {
+	return &ReadWriteSet{
+		reads:  map[string]struct{}{},
+		writes: map[string]struct{}{},
+	}
+}
+
+// Reads returns the keys read, in sorted order. Iterated ranges are returned by Ranges().
+func (s *ReadWriteSet) Reads() [][]byte {
+	return sortedKeys(s.reads)
+}
+
+// Ranges returns the key ranges iterated over, in the order they were iterated.
+func (s *ReadWriteSet) Ranges() []KeyRange {
+	return s.ranges
+}
+
+// Writes returns the keys set or removed, in sorted order.
+func (s *ReadWriteSet) Writes() [][]byte {
+	return sortedKeys(s.writes)
+}
+
+// HasRead returns whether the key was read, either directly or as part of an iterated range.
+func (s *ReadWriteSet) HasRead(key []byte) bool {
+	if _, ok := s.reads[string(key)]; ok {
+		return true
+	}
+	for _, r := range s.ranges {
+		if r.Contains(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWritten returns whether the key was set or removed.
+func (s *ReadWriteSet) HasWritten(key []byte) bool {
+	_, ok := s.writes[string(key)]
+	return ok
+}
+
+// DependsOn returns whether this set read a key written by the earlier set. If so, a transaction
+// executed optimistically in parallel with the earlier transaction must be re-executed after it.
+func (s *ReadWriteSet) DependsOn(earlier *ReadWriteSet) bool {
+	// Look up the smaller set in the larger one, unless ranges must be checked.
+	if len(s.ranges) == 0 && len(s.reads) < len(earlier.writes) {
+		for key := range s.reads {
+			if _, ok := earlier.writes[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	for key := range earlier.writes {
+		if s.HasRead([]byte(key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Conflicts returns whether the sets conflict, i.e. either set read a key written by the other, or
+// both sets wrote the same key. Transactions with non-conflicting sets can be executed in any
+// order with the same result.
+func (s *ReadWriteSet) Conflicts(other *ReadWriteSet) bool {
+	small, large := s.writes, other.writes
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for key := range small {
+		if _, ok := large[key]; ok {
+			return true
+		}
+	}
+	return s.DependsOn(other) || other.DependsOn(s)
+}
+
+// sortedKeys returns the keys of the map in sorted order.
+func sortedKeys(set map[string]struct{}) [][]byte {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	res := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, []byte(key))
+	}
+	return res
+}
+
+// TrackingView is a view of a tree which records the keys read and written through it in a
+// ReadWriteSet, for optimistic parallel execution of transactions. Each scope, e.g. a
+// transaction, is ended by EndScope(), which returns its set and starts a new scope.
+//
+// Iterations record the range iterated over; if stopped early, the range ends at the last key
+// visited. Set() and Remove() only record writes, even though their return values depend on
+// the existing key. Reads via index, e.g. GetByIndex(), are not available, since they depend on
+// all preceding keys. A TrackingView is not safe for concurrent use.
+type TrackingView struct {
+	immutable *ImmutableTree
+	mutable   *MutableTree
+	set       *ReadWriteSet
+}
+
+// NewTrackingView creates a tracking view of the working tree of the mutable tree.
+func NewTrackingView(tree *MutableTree) *TrackingView {
+	return &TrackingView{mutable: tree, set: NewReadWriteSet()}
+}
+
+// NewImmutableTrackingView creates a read-only tracking view of the immutable tree.
+func NewImmutableTrackingView(tree *ImmutableTree) *TrackingView {
+	return &TrackingView{immutable: tree, set: NewReadWriteSet()}
+}
+
+// tree returns the tree to read from.
+func (v *TrackingView) tree() *ImmutableTree {
+	if v.mutable != nil {
+		return v.mutable.ImmutableTree
+	}
+	return v.immutable
+}
+
+// ReadWriteSet returns the set of the current scope.
+func (v *TrackingView) ReadWriteSet() *ReadWriteSet {
+	return v.set
+}
+
+// EndScope ends the current scope and returns its set, starting a new scope with an empty set.
+func (v *TrackingView) EndScope() *ReadWriteSet {
+	set := v.set
+	v.set = NewReadWriteSet()
+	return set
+}
+
+// Get returns the value of the key, or nil if it does not exist.
+func (v *TrackingView) Get(key []byte) []byte {
+	v.set.reads[string(key)] = struct{}{}
+	_, value := v.tree().Get(key)
+	return value
+}
+
+// Has returns whether the key exists.
+func (v *TrackingView) Has(key []byte) bool {
+	v.set.reads[string(key)] = struct{}{}
+	return v.tree().Has(key)
+}
+
+// Set sets the key to the value. It panics if the view is read-only.
+func (v *TrackingView) Set(key, value []byte) bool {
+	if v.mutable == nil {
+		panic("cannot write to a read-only tracking view")
+	}
+	v.set.writes[string(key)] = struct{}{}
+	return v.mutable.Set(key, value)
+}
+
+// Remove removes the key, returning its value and whether it existed. It panics if the view is
+// read-only.
+func (v *TrackingView) Remove(key []byte) ([]byte, bool) {
+	if v.mutable == nil {
+		panic("cannot write to a read-only tracking view")
+	}
+	v.set.writes[string(key)] = struct{}{}
+	return v.mutable.Remove(key)
+}
+
+// IterateRange iterates over keys from start inclusive to end exclusive, like
+// ImmutableTree.IterateRange(), and records the range iterated over.
+func (v *TrackingView) IterateRange(start, end []byte, ascending bool, fn func(key, value []byte) bool) bool {
+	var last []byte
+	stopped := v.tree().IterateRange(start, end, ascending, func(key, value []byte) bool {
+		last = key
+		return fn(key, value)
+	})
+	v.set.ranges = append(v.set.ranges, iteratedRange(start, end, ascending, last, stopped))
+	return stopped
+}
+
+// Iterator returns an iterator over keys from start inclusive to end exclusive, like
+// ImmutableTree.Iterator(). The full range is recorded, and narrowed to the keys visited when the
+// iterator is closed.
+func (v *TrackingView) Iterator(start, end []byte, ascending bool) dbm.Iterator {
+	v.set.ranges = append(v.set.ranges, KeyRange{Start: start, End: end})
+	return &trackingIterator{
+		Iterator:  v.tree().Iterator(start, end, ascending),
+		set:       v.set,
+		index:     len(v.set.ranges) - 1,
+		ascending: ascending,
+	}
+}
+
+// iteratedRange returns the range iterated over from start to end, which ends at the last key
+// visited if the iteration was stopped.
+func iteratedRange(start, end []byte, ascending bool, last []byte, stopped bool) KeyRange {
+	r := KeyRange{Start: start, End: end}
+	switch {
+	case !stopped:
+	case ascending:
+		r.End = append(append(make([]byte, 0, len(last)+1), last...), 0)
+	default:
+		r.Start = append([]byte{}, last...)
+	}
+	return r
+}
+
+// trackingIterator is an iterator of a TrackingView, which narrows its recorded range when closed.
+type trackingIterator struct {
+	*Iterator
+	set       *ReadWriteSet
+	index     int
+	ascending bool
+}
+
+// Close implements dbm.Iterator.
+func (iter *trackingIterator) Close() error {
+	if iter.Valid() {
+		r := iter.set.ranges[iter.index]
+		iter.set.ranges[iter.index] = iteratedRange(r.Start, r.End, iter.ascending, iter.Key(), true)
+	}
+	return iter.Iterator.Close()
+}
@@ -0,0 +1,163 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func setupTrackingTree(t *testing.T) *MutableTree {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		tree.Set([]byte(fmt.Sprintf("k%v", i)), []byte(fmt.Sprintf("v%v", i)))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(t, err)
+	return tree
+}
+
+func TestTrackingView(t *testing.T) {
+	tree := setupTrackingTree(t)
+	view := NewTrackingView(tree)
+
+	require.Equal(t, []byte("v1"), view.Get([]byte("k1")))
+	require.Nil(t, view.Get([]byte("x")))
+	require.True(t, view.Has([]byte("k2")))
+	view.Set([]byte("k3"), []byte("new"))
+	_, removed := view.Remove([]byte("k4"))
+	require.True(t, removed)
+	require.Equal(t, []byte("new"), view.Get([]byte("k3")))
+
+	set := view.EndScope()
+	require.Equal(t, [][]byte{[]byte("k1"), []byte("k2"), []byte("k3"), []byte("x")}, set.Reads())
+	require.Equal(t, [][]byte{[]byte("k3"), []byte("k4")}, set.Writes())
+	require.Empty(t, set.Ranges())
+	require.True(t, set.HasRead([]byte("x")))
+	require.False(t, set.HasRead([]byte("k4")))
+	require.True(t, set.HasWritten([]byte("k4")))
+
+	// A new scope starts empty.
+	require.Empty(t, view.ReadWriteSet().Reads())
+	require.Empty(t, view.ReadWriteSet().Writes())
+}
+
+func TestTrackingView_Iteration(t *testing.T) {
+	tree := setupTrackingTree(t)
+	view := NewTrackingView(tree)
+
+	var keys []string
+	view.IterateRange([]byte("k2"), []byte("k5"), true, func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return false
+	})
+	require.Equal(t, []string{"k2", "k3", "k4"}, keys)
+	require.Equal(t, []KeyRange{{Start: []byte("k2"), End: []byte("k5")}}, view.EndScope().Ranges())
+
+	// Stopped iterations only record the keys visited.
+	view.IterateRange(nil, nil, true, func(key, value []byte) bool {
+		return string(key) == "k1"
+	})
+	view.IterateRange([]byte("k3"), nil, false, func(key, value []byte) bool {
+		return string(key) == "k8"
+	})
+	set := view.EndScope()
+	require.Equal(t, []KeyRange{
+		{Start: nil, End: []byte("k1\x00")},
+		{Start: []byte("k8"), End: nil},
+	}, set.Ranges())
+	require.True(t, set.HasRead([]byte("k1")))
+	require.True(t, set.HasRead([]byte("a")))
+	require.False(t, set.HasRead([]byte("k1a")))
+	require.False(t, set.HasRead([]byte("k7")))
+	require.True(t, set.HasRead([]byte("k9")))
+
+	itr := view.Iterator([]byte("k2"), []byte("k8"), true)
+	require.Equal(t, []byte("k2"), itr.Key())
+	itr.Next()
+	require.Equal(t, []KeyRange{{Start: []byte("k2"), End: []byte("k8")}}, view.ReadWriteSet().Ranges())
+	require.NoError(t, itr.Close())
+	require.Equal(t, []KeyRange{{Start: []byte("k2"), End: []byte("k3\x00")}}, view.ReadWriteSet().Ranges())
+
+	itr = view.Iterator(nil, []byte("k3"), false)
+	for ; itr.Valid(); itr.Next() {
+	}
+	require.NoError(t, itr.Close())
+	require.Equal(t, KeyRange{Start: nil, End: []byte("k3")}, view.ReadWriteSet().Ranges()[1])
+}
+
+func TestTrackingView_ReadOnly(t *testing.T) {
+	tree := setupTrackingTree(t)
+	immutable, err := tree.GetImmutable(1)
+	require.NoError(t, err)
+	view := NewImmutableTrackingView(immutable)
+	require.Equal(t, []byte("v5"), view.Get([]byte("k5")))
+	require.Panics(t, func() { view.Set([]byte("k5"), []byte("new")) })
+	require.Panics(t, func() { view.Remove([]byte("k5")) })
+	require.Empty(t, view.ReadWriteSet().Writes())
+}
+
+func TestReadWriteSet_Conflicts(t *testing.T) {
+	tree := setupTrackingTree(t)
+	view := NewTrackingView(tree)
+
+	view.Get([]byte("k1"))
+	view.Set([]byte("k2"), []byte("a"))
+	tx1 := view.EndScope()
+
+	view.Get([]byte("k2"))
+	tx2 := view.EndScope()
+
+	view.Set([]byte("k1"), []byte("b"))
+	tx3 := view.EndScope()
+
+	view.IterateRange([]byte("k5"), []byte("k7"), true, func(key, value []byte) bool { return false })
+	view.Set([]byte("k9"), []byte("c"))
+	tx4 := view.EndScope()
+
+	view.Set([]byte("k6"), []byte("d"))
+	tx5 := view.EndScope()
+
+	view.Set([]byte("k9"), []byte("e"))
+	tx6 := view.EndScope()
+
+	require.True(t, tx2.DependsOn(tx1))
+	require.False(t, tx1.DependsOn(tx2))
+	require.True(t, tx1.DependsOn(tx3))
+	require.False(t, tx3.DependsOn(tx1))
+	require.False(t, tx2.DependsOn(tx3))
+	require.True(t, tx4.DependsOn(tx5))
+	require.False(t, tx5.DependsOn(tx4))
+
+	require.True(t, tx1.Conflicts(tx2))
+	require.True(t, tx2.Conflicts(tx1))
+	require.True(t, tx5.Conflicts(tx4))
+	require.True(t, tx4.Conflicts(tx6)) // write-write
+	require.False(t, tx2.Conflicts(tx3))
+	require.False(t, tx2.Conflicts(tx4))
+	require.False(t, tx5.Conflicts(tx6))
+	require.False(t, NewReadWriteSet().Conflicts(tx1))
+}
+
+func BenchmarkTrackingView(b *testing.B) {
+	tree, err := NewMutableTree(db.NewMemDB(), 0)
+	require.NoError(b, err)
+	for i := 0; i < 1000; i++ {
+		tree.Set([]byte(fmt.Sprintf("key-%04d", i)), []byte("value"))
+	}
+	_, _, err = tree.SaveVersion()
+	require.NoError(b, err)
+	view := NewTrackingView(tree)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		view.Get([]byte(fmt.Sprintf("key-%04d", i%1000)))
+		view.Set([]byte(fmt.Sprintf("key-%04d", (i+1)%1000)), []byte("new"))
+		if i%10 == 0 {
+			view.EndScope()
+		}
+	}
+}